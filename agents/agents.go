@@ -3,6 +3,7 @@ package agents
 import (
 	"context"
 
+	"github.com/tmc/langchaingo/chains"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/tools"
 )
@@ -16,3 +17,17 @@ type Agent interface {
 	GetOutputKeys() []string
 	GetTools() []tools.Tool
 }
+
+// CallOptionsAgent is an Agent using the chain call options given to the
+// executor, e.g. with chains.Run, for its LLM calls. The executor calls
+// PlanWithOptions instead of Plan for such agents.
+type CallOptionsAgent interface {
+	Agent
+	// PlanWithOptions is like Plan, using the given call options.
+	PlanWithOptions(
+		ctx context.Context,
+		intermediateSteps []schema.AgentStep,
+		inputs map[string]string,
+		options ...chains.ChainCallOption,
+	) ([]schema.AgentAction, *schema.AgentFinish, error)
+}
@@ -0,0 +1,143 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// ApprovalPolicy reports whether an action needs the approval of a person
+// before the executor runs it.
+type ApprovalPolicy func(ctx context.Context, action schema.AgentAction) bool
+
+// RequireApproval is an ApprovalPolicy requiring the approval of every action.
+func RequireApproval(context.Context, schema.AgentAction) bool {
+	return true
+}
+
+// Interrupt is returned as an error by the executor when actions of a plan
+// need approval. No action of the plan has been run yet. It holds everything
+// needed to continue the run with Executor.Resume, and can be serialized to
+// JSON to resume the run in a different process.
+type Interrupt struct {
+	// Inputs are the inputs of the run.
+	Inputs map[string]string `json:"inputs"`
+	// Steps are the steps taken before the plan.
+	Steps []schema.AgentStep `json:"steps"`
+	// Actions are the actions of the plan, in order.
+	Actions []PendingAction `json:"actions"`
+	// Iteration is the number of iterations of the run, including the plan.
+	Iteration int `json:"iteration"`
+	// ThreadID and CheckpointID identify the checkpoint saved for the
+	// interrupt, if the executor has a checkpointer.
+	ThreadID     string `json:"thread_id,omitempty"`
+	CheckpointID int    `json:"checkpoint_id,omitempty"`
+}
+
+// PendingAction is an action of an interrupted plan.
+type PendingAction struct {
+	// ID identifies the action in the decisions given to Executor.Resume. It
+	// is the tool call ID of the action if it has a unique one.
+	ID     string             `json:"id"`
+	Action schema.AgentAction `json:"action"`
+	// RequiresApproval is true if the action can only run once approved.
+	RequiresApproval bool `json:"requires_approval"`
+}
+
+func (i *Interrupt) Error() string {
+	n := 0
+	for _, pending := range i.Actions {
+		if pending.RequiresApproval {
+			n++
+		}
+	}
+	return fmt.Sprintf("%s: %d actions awaiting approval", ErrInterrupted, n)
+}
+
+func (i *Interrupt) Unwrap() error {
+	return ErrInterrupted
+}
+
+// AwaitingApproval returns the actions that need a decision.
+func (i *Interrupt) AwaitingApproval() []PendingAction {
+	res := make([]PendingAction, 0, len(i.Actions))
+	for _, pending := range i.Actions {
+		if pending.RequiresApproval {
+			res = append(res, pending)
+		}
+	}
+	return res
+}
+
+func newInterrupt(steps []schema.AgentStep, actions []schema.AgentAction, requiresApproval []bool) *Interrupt {
+	toolIDs := make(map[string]int, len(actions))
+	for _, action := range actions {
+		toolIDs[action.ToolID]++
+	}
+
+	pending := make([]PendingAction, len(actions))
+	for i, action := range actions {
+		id := action.ToolID
+		if id == "" || toolIDs[id] > 1 {
+			id = fmt.Sprintf("action-%d", i)
+		}
+		pending[i] = PendingAction{
+			ID:               id,
+			Action:           action,
+			RequiresApproval: requiresApproval[i],
+		}
+	}
+
+	return &Interrupt{
+		Steps:   steps,
+		Actions: pending,
+	}
+}
+
+// ApprovalDecisionType is the type of decision taken on an action awaiting
+// approval.
+type ApprovalDecisionType string
+
+const (
+	// ApprovalApprove runs the action as proposed.
+	ApprovalApprove ApprovalDecisionType = "approve"
+	// ApprovalReject does not run the action, and gives the feedback to the
+	// agent as the observation of the step.
+	ApprovalReject ApprovalDecisionType = "reject"
+	// ApprovalEdit runs the action with another tool input.
+	ApprovalEdit ApprovalDecisionType = "edit"
+)
+
+// ApprovalDecision is the decision taken by a person on an action awaiting
+// approval.
+type ApprovalDecision struct {
+	Type ApprovalDecisionType `json:"type"`
+	// Feedback is the observation given to the agent for a rejected action.
+	Feedback string `json:"feedback,omitempty"`
+	// ToolInput is the input the tool is called with for an edited action.
+	ToolInput string `json:"tool_input,omitempty"`
+}
+
+// Approve returns a decision approving an action.
+func Approve() ApprovalDecision {
+	return ApprovalDecision{Type: ApprovalApprove}
+}
+
+// Reject returns a decision rejecting an action, with feedback for the agent.
+func Reject(feedback string) ApprovalDecision {
+	return ApprovalDecision{Type: ApprovalReject, Feedback: feedback}
+}
+
+// EditInput returns a decision running an action with another tool input.
+func EditInput(toolInput string) ApprovalDecision {
+	return ApprovalDecision{Type: ApprovalEdit, ToolInput: toolInput}
+}
+
+// rejectionObservation returns the observation of a rejected action.
+func rejectionObservation(action schema.AgentAction, feedback string) string {
+	if feedback == "" {
+		return fmt.Sprintf("The call to %s was rejected by the user.", action.Tool)
+	}
+	return fmt.Sprintf("The call to %s was rejected by the user: %s", action.Tool, feedback)
+}
@@ -0,0 +1,164 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/tmc/langchaingo/schema"
+)
+
+// ErrCheckpointNotFound is returned when a checkpoint or a thread does not
+// exist.
+var ErrCheckpointNotFound = errors.New("checkpoint not found")
+
+// Checkpoint is the state of a run of the executor, saved after each step. A
+// run can be resumed from any of its checkpoints.
+type Checkpoint struct {
+	// ThreadID identifies the run the checkpoint belongs to.
+	ThreadID string `json:"thread_id"`
+	// ID identifies the checkpoint in its thread. IDs are assigned by the
+	// checkpointer, starting at 1 and increasing with each checkpoint.
+	ID int `json:"id"`
+	// ParentID is the ID of the previous checkpoint of the run, or 0 for the
+	// first checkpoint of a run. Replaying a run from an older checkpoint
+	// creates a branch of checkpoints with the replayed one as parent.
+	ParentID int `json:"parent_id,omitempty"`
+	// Inputs are the inputs of the run.
+	Inputs map[string]string `json:"inputs"`
+	// Steps are the steps taken so far.
+	Steps []schema.AgentStep `json:"steps"`
+	// Iteration is the number of iterations of the run so far.
+	Iteration int `json:"iteration"`
+	// Pending are the actions of the last plan that have not been run yet.
+	Pending []schema.AgentAction `json:"pending,omitempty"`
+	// Interrupt is set if the run is waiting for the approval of actions.
+	Interrupt *Interrupt `json:"interrupt,omitempty"`
+	// Outputs are the outputs of the run, once it has finished.
+	Outputs map[string]any `json:"outputs"`
+	// CreatedAt is the time the checkpoint was saved.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Finished reports whether the run finished at this checkpoint.
+func (c Checkpoint) Finished() bool {
+	return c.Outputs != nil
+}
+
+// Checkpointer persists the checkpoints of the runs of an executor.
+type Checkpointer interface {
+	// Put saves a checkpoint, setting its ID and creation time.
+	Put(ctx context.Context, checkpoint *Checkpoint) error
+	// Get returns a checkpoint of a thread.
+	Get(ctx context.Context, threadID string, id int) (*Checkpoint, error)
+	// List returns the checkpoints of a thread, oldest first.
+	List(ctx context.Context, threadID string) ([]Checkpoint, error)
+	// Threads returns the IDs of the threads with checkpoints.
+	Threads(ctx context.Context) ([]string, error)
+}
+
+// LatestCheckpoint returns the most recent checkpoint of a thread.
+func LatestCheckpoint(ctx context.Context, checkpointer Checkpointer, threadID string) (*Checkpoint, error) {
+	checkpoints, err := checkpointer.List(ctx, threadID)
+	if err != nil {
+		return nil, err
+	}
+	if len(checkpoints) == 0 {
+		return nil, ErrCheckpointNotFound
+	}
+	return &checkpoints[len(checkpoints)-1], nil
+}
+
+type threadIDKey struct{}
+
+// ContextWithThreadID returns a context making the executor save the
+// checkpoints of the run under the given thread ID.
+func ContextWithThreadID(ctx context.Context, threadID string) context.Context {
+	return context.WithValue(ctx, threadIDKey{}, threadID)
+}
+
+// ThreadIDFromContext returns the thread ID set with ContextWithThreadID.
+func ThreadIDFromContext(ctx context.Context) (string, bool) {
+	threadID, ok := ctx.Value(threadIDKey{}).(string)
+	return threadID, ok && threadID != ""
+}
+
+// InMemoryCheckpointer is a Checkpointer keeping the checkpoints in memory.
+type InMemoryCheckpointer struct {
+	mu      sync.Mutex
+	threads map[string][][]byte
+}
+
+var _ Checkpointer = (*InMemoryCheckpointer)(nil)
+
+// NewInMemoryCheckpointer creates a new InMemoryCheckpointer.
+func NewInMemoryCheckpointer() *InMemoryCheckpointer {
+	return &InMemoryCheckpointer{
+		threads: make(map[string][][]byte),
+	}
+}
+
+// Put saves a checkpoint. The checkpoint is stored as JSON, like with the
+// persistent checkpointers, so that it is not affected by later changes.
+func (c *InMemoryCheckpointer) Put(_ context.Context, checkpoint *Checkpoint) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	checkpoint.ID = len(c.threads[checkpoint.ThreadID]) + 1
+	checkpoint.CreatedAt = time.Now()
+
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	c.threads[checkpoint.ThreadID] = append(c.threads[checkpoint.ThreadID], data)
+	return nil
+}
+
+// Get returns a checkpoint of a thread.
+func (c *InMemoryCheckpointer) Get(_ context.Context, threadID string, id int) (*Checkpoint, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	thread := c.threads[threadID]
+	if id < 1 || id > len(thread) {
+		return nil, ErrCheckpointNotFound
+	}
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(thread[id-1], &checkpoint); err != nil {
+		return nil, err
+	}
+	return &checkpoint, nil
+}
+
+// List returns the checkpoints of a thread, oldest first.
+func (c *InMemoryCheckpointer) List(_ context.Context, threadID string) ([]Checkpoint, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	thread := c.threads[threadID]
+	checkpoints := make([]Checkpoint, len(thread))
+	for i, data := range thread {
+		if err := json.Unmarshal(data, &checkpoints[i]); err != nil {
+			return nil, err
+		}
+	}
+	return checkpoints, nil
+}
+
+// Threads returns the IDs of the threads with checkpoints, sorted.
+func (c *InMemoryCheckpointer) Threads(_ context.Context) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	threadIDs := make([]string, 0, len(c.threads))
+	for threadID := range c.threads {
+		threadIDs = append(threadIDs, threadID)
+	}
+	sort.Strings(threadIDs)
+	return threadIDs, nil
+}
@@ -0,0 +1,173 @@
+package agents_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// stepsAgent plans the search of two names, then finishes with the
+// observations. Unlike scriptedAgent it only depends on the steps, so runs can
+// be resumed.
+type stepsAgent struct {
+	tools []tools.Tool
+}
+
+func (a *stepsAgent) Plan(
+	_ context.Context,
+	intermediateSteps []schema.AgentStep,
+	_ map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	if len(intermediateSteps) == 0 {
+		return []schema.AgentAction{
+			{Tool: "search", ToolInput: "alice"},
+			{Tool: "search", ToolInput: "bob"},
+		}, nil, nil
+	}
+
+	observations := make([]string, 0, len(intermediateSteps))
+	for _, step := range intermediateSteps {
+		observations = append(observations, step.Observation)
+	}
+	return nil, &schema.AgentFinish{
+		ReturnValues: map[string]any{"output": strings.Join(observations, ",")},
+	}, nil
+}
+
+func (a *stepsAgent) GetInputKeys() []string  { return []string{"input"} }
+func (a *stepsAgent) GetOutputKeys() []string { return []string{"output"} }
+func (a *stepsAgent) GetTools() []tools.Tool  { return a.tools }
+
+func TestExecutorCheckpoints(t *testing.T) {
+	t.Parallel()
+
+	errCrash := errors.New("crash")
+	crashed := false
+	var searched []string
+	search := funcTool{name: "search", fn: func(_ context.Context, input string) (string, error) {
+		if input == "bob" && !crashed {
+			crashed = true
+			return "", errCrash
+		}
+		searched = append(searched, input)
+		return "found " + input, nil
+	}}
+
+	checkpointer := agents.NewInMemoryCheckpointer()
+	executor := agents.NewExecutor(&stepsAgent{tools: []tools.Tool{search}}, agents.WithCheckpointer(checkpointer))
+	ctx := agents.ContextWithThreadID(context.Background(), "thread-1")
+
+	_, err := chains.Run(ctx, executor, "search")
+	require.ErrorIs(t, err, errCrash)
+
+	latest, err := agents.LatestCheckpoint(ctx, checkpointer, "thread-1")
+	require.NoError(t, err)
+	require.Equal(t, 2, latest.ID)
+	require.Equal(t, 1, latest.Iteration)
+	require.Len(t, latest.Steps, 1)
+	require.Equal(t, []schema.AgentAction{{Tool: "search", ToolInput: "bob"}}, latest.Pending)
+
+	// Resuming runs the pending action only.
+	outputs, err := executor.ResumeThread(context.Background(), "thread-1")
+	require.NoError(t, err)
+	require.Equal(t, "found alice,found bob", outputs["output"])
+	require.Equal(t, []string{"alice", "bob"}, searched)
+
+	checkpoints, err := checkpointer.List(ctx, "thread-1")
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 4)
+	require.Equal(t, 2, checkpoints[2].ParentID)
+	require.True(t, checkpoints[3].Finished())
+
+	// Resuming a finished run returns its outputs.
+	outputs, err = executor.ResumeThread(context.Background(), "thread-1")
+	require.NoError(t, err)
+	require.Equal(t, "found alice,found bob", outputs["output"])
+
+	// Replaying from the first checkpoint runs the agent again, in a branch.
+	outputs, err = executor.Replay(context.Background(), "thread-1", 1)
+	require.NoError(t, err)
+	require.Equal(t, "found alice,found bob", outputs["output"])
+	require.Equal(t, []string{"alice", "bob", "alice", "bob"}, searched)
+
+	checkpoints, err = checkpointer.List(ctx, "thread-1")
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 7)
+	require.Equal(t, 1, checkpoints[4].ParentID)
+
+	threads, err := checkpointer.Threads(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"thread-1"}, threads)
+
+	_, err = executor.Replay(context.Background(), "thread-1", 42)
+	require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+	_, err = executor.ResumeThread(context.Background(), "thread-2")
+	require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+}
+
+func TestExecutorCheckpointsFinishedOutputs(t *testing.T) {
+	t.Parallel()
+
+	search := funcTool{name: "search", fn: func(_ context.Context, input string) (string, error) {
+		return "found " + input, nil
+	}}
+	executor := agents.NewExecutor(&stepsAgent{tools: []tools.Tool{search}},
+		agents.WithCheckpointer(agents.NewInMemoryCheckpointer()),
+		agents.WithReturnIntermediateSteps(),
+		agents.WithReturnStopInfo(),
+	)
+	ctx := agents.ContextWithThreadID(context.Background(), "thread-1")
+
+	outputs, err := chains.Call(ctx, executor, map[string]any{"input": "search"})
+	require.NoError(t, err)
+	require.IsType(t, []schema.AgentStep{}, outputs["intermediateSteps"])
+	require.IsType(t, agents.StopInfo{}, outputs["stopInfo"])
+
+	// The outputs of the finished run keep their types.
+	resumed, err := executor.ResumeThread(context.Background(), "thread-1")
+	require.NoError(t, err)
+	require.Equal(t, outputs, resumed)
+}
+
+func TestExecutorCheckpointsInterrupt(t *testing.T) {
+	t.Parallel()
+
+	search := funcTool{name: "search", fn: func(_ context.Context, input string) (string, error) {
+		return "found " + input, nil
+	}}
+	checkpointer := agents.NewInMemoryCheckpointer()
+	executor := agents.NewExecutor(&stepsAgent{tools: []tools.Tool{search}},
+		agents.WithCheckpointer(checkpointer),
+		agents.WithApprovalPolicy(agents.RequireApproval),
+	)
+	ctx := agents.ContextWithThreadID(context.Background(), "thread-1")
+
+	_, err := chains.Run(ctx, executor, "search")
+	require.ErrorIs(t, err, agents.ErrInterrupted)
+
+	// The interrupt is restored from the checkpoint, e.g. in another process.
+	_, err = executor.ResumeThread(context.Background(), "thread-1")
+	var interrupt *agents.Interrupt
+	require.ErrorAs(t, err, &interrupt)
+	require.Equal(t, "thread-1", interrupt.ThreadID)
+	require.Equal(t, 2, interrupt.CheckpointID)
+
+	outputs, err := executor.Resume(context.Background(), interrupt, map[string]agents.ApprovalDecision{
+		"action-0": agents.Approve(),
+		"action-1": agents.Reject(""),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "found alice,The call to search was rejected by the user.", outputs["output"])
+
+	latest, err := agents.LatestCheckpoint(ctx, checkpointer, "thread-1")
+	require.NoError(t, err)
+	require.True(t, latest.Finished())
+	require.Equal(t, 3, latest.ParentID)
+}
@@ -48,7 +48,7 @@ func NewConversationalAgent(llm llms.Model, tools []tools.Tool, opts ...Option)
 
 	return &ConversationalAgent{
 		Chain: chains.NewLLMChain(
-			llm,
+			usageRecordingLLM{llm},
 			options.getConversationalPrompt(tools),
 			chains.WithCallback(options.callbacksHandler),
 		),
@@ -63,6 +63,16 @@ func (a *ConversationalAgent) Plan(
 	ctx context.Context,
 	intermediateSteps []schema.AgentStep,
 	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	return a.PlanWithOptions(ctx, intermediateSteps, inputs)
+}
+
+// PlanWithOptions is like Plan, using the given call options for the LLM call.
+func (a *ConversationalAgent) PlanWithOptions(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+	options ...chains.ChainCallOption,
 ) ([]schema.AgentAction, *schema.AgentFinish, error) {
 	fullInputs := make(map[string]any, len(inputs))
 	for key, value := range inputs {
@@ -71,22 +81,14 @@ func (a *ConversationalAgent) Plan(
 
 	fullInputs["agent_scratchpad"] = constructScratchPad(intermediateSteps)
 
-	var stream func(ctx context.Context, chunk []byte) error
-
-	if a.CallbacksHandler != nil {
-		stream = func(ctx context.Context, chunk []byte) error {
-			a.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
-			return nil
-		}
-	}
-
-	output, err := chains.Predict(
-		ctx,
-		a.Chain,
-		fullInputs,
+	callOptions := make([]chains.ChainCallOption, 0, len(options)+2)
+	callOptions = append(callOptions, options...)
+	callOptions = append(callOptions,
 		chains.WithStopWords([]string{"\nObservation:", "\n\tObservation:"}),
-		chains.WithStreamingFunc(stream),
+		chains.WithStreamingFunc(streamingFunc(ctx, a.CallbacksHandler, options)),
 	)
+
+	output, err := chains.Predict(ctx, a.Chain, fullInputs, callOptions...)
 	if err != nil {
 		return nil, nil, err
 	}
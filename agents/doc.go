@@ -10,7 +10,9 @@
 // Package agents provides and implementation of the agent interface called
 // OneShotZeroAgent. This agent uses the ReAct Framework (based on the
 // descriptions of tools) to decide what action to take. This agent is
-// optimized to be used with LLMs.
+// optimized to be used with LLMs. The ToolCallingAgent instead uses the native
+// tool calling API of the model, and works with every provider supporting
+// llms.WithTools.
 //
 // To make agents more powerful we need to make them iterative, i.e. call the
 // model multiple times until they arrive at the final answer. That's the job of
@@ -19,4 +21,30 @@
 // calling the tool that the action references with the corresponding input,
 // getting the output of the tool, and then passing all that information back
 // into the Agent to get the next action it should take.
+//
+// Actions can require the approval of a person with an ApprovalPolicy. The
+// Executor then stops before running them and returns an *Interrupt, and the
+// run is continued with Executor.Resume once the actions have been approved,
+// rejected or edited.
+//
+// With a Checkpointer, the Executor saves the state of the runs with a thread
+// ID after each step. A run can then be resumed with Executor.ResumeThread,
+// e.g. after a crash, or replayed from any of its checkpoints with
+// Executor.Replay.
+//
+// Runs are limited in iterations, and optionally in duration and tokens. With
+// EarlyStoppingGenerate, the agent is asked for a best-effort final answer when
+// a limit is reached, and WithReturnStopInfo adds the reason the run stopped to
+// the outputs.
+//
+// PlanAndExecute is a chain asking a model for a plan of the whole task first,
+// then executing each step of the plan with an Executor.
+//
+// NewSupervisor creates a tool calling agent routing subtasks to workers, which
+// are other agents or chains, and combining their answers. Any chain can also be
+// given to an agent as a tool with the chaintool package.
+//
+// Executor.Stream runs the agent like chains.Call and sends the events of the
+// run, such as the tokens of the model and the calls of the tools, to a channel
+// as they happen.
 package agents
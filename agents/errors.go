@@ -1,6 +1,9 @@
 package agents
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 var (
 	// ErrExecutorInputNotString is returned if an input to the executor call function is not a string.
@@ -10,6 +13,12 @@ var (
 	// ErrNotFinished is returned if the agent does not give a finish before  the number of iterations
 	// is larger than max iterations.
 	ErrNotFinished = errors.New("agent not finished before max iterations")
+	// ErrMaxExecutionTime is returned if the agent does not give a finish before the max
+	// execution time of the executor. It wraps ErrNotFinished.
+	ErrMaxExecutionTime = fmt.Errorf("%w: max execution time reached", ErrNotFinished)
+	// ErrMaxTokens is returned if the agent does not give a finish before using the max
+	// number of tokens of the executor. It wraps ErrNotFinished.
+	ErrMaxTokens = fmt.Errorf("%w: max tokens reached", ErrNotFinished)
 	// ErrUnknownAgentType is returned if the type given to the initializer is invalid.
 	ErrUnknownAgentType = errors.New("unknown agent type")
 	// ErrInvalidOptions is returned if the options given to the initializer is invalid.
@@ -17,6 +26,18 @@ var (
 
 	// ErrUnableToParseOutput is returned if the output of the llm is unparsable.
 	ErrUnableToParseOutput = errors.New("unable to parse agent output")
+	// ErrInterrupted is returned, wrapped in an *Interrupt, if actions of the agent need approval
+	// before being run.
+	ErrInterrupted = errors.New("agent run interrupted")
+	// ErrMissingApproval is returned when resuming an interrupted run without a decision for an
+	// action awaiting approval.
+	ErrMissingApproval = errors.New("no decision for action awaiting approval")
+	// ErrInvalidApproval is returned when resuming an interrupted run with an unknown decision.
+	ErrInvalidApproval = errors.New("invalid approval decision")
+
+	// ErrNoCheckpointer is returned when resuming a thread with an executor without a checkpointer.
+	ErrNoCheckpointer = errors.New("executor has no checkpointer")
+
 	// ErrInvalidChainReturnType is returned if the internal chain of the agent returns a value in the
 	// "text" filed that is not a string.
 	ErrInvalidChainReturnType = errors.New("agent chain did not return a string")
@@ -38,3 +59,43 @@ func NewParserErrorHandler(formatFunc func(string) string) *ParserErrorHandler {
 		Formatter: formatFunc,
 	}
 }
+
+// ToolErrorPolicy decides what the executor does with an error returned by a tool once
+// the retries are exhausted.
+type ToolErrorPolicy int
+
+const (
+	// ToolErrorPolicyAbort stops the run and returns the error from the executor.
+	ToolErrorPolicyAbort ToolErrorPolicy = iota
+	// ToolErrorPolicyObserve gives the error to the agent as the observation of the step,
+	// letting it try another tool or another input.
+	ToolErrorPolicyObserve
+)
+
+// ToolErrorHandler is the struct used to handle errors returned by tools in the executor.
+// Executors without a ToolErrorHandler abort on the first tool error.
+type ToolErrorHandler struct {
+	// Policy is applied once the retries are exhausted.
+	Policy ToolErrorPolicy
+	// MaxRetries is the number of times a failed tool call is retried with the same input
+	// before the policy is applied. Canceled contexts are never retried.
+	MaxRetries int
+	// The formatter function can be used to format the error given as an observation. If nil
+	// the error message will be given as an observation directly.
+	Formatter func(toolName string, err error) string
+}
+
+// NewToolErrorHandler creates a new tool error handler that gives tool errors to the agent
+// as observations.
+func NewToolErrorHandler(formatFunc func(toolName string, err error) string) *ToolErrorHandler {
+	return &ToolErrorHandler{
+		Policy:    ToolErrorPolicyObserve,
+		Formatter: formatFunc,
+	}
+}
+
+// WithMaxRetries returns a copy of the handler retrying failed tool calls n times.
+func (h ToolErrorHandler) WithMaxRetries(n int) *ToolErrorHandler {
+	h.MaxRetries = n
+	return &h
+}
@@ -2,9 +2,13 @@ package agents
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/chains"
@@ -12,7 +16,10 @@ import (
 	"github.com/tmc/langchaingo/tools"
 )
 
-const _intermediateStepsOutputKey = "intermediateSteps"
+const (
+	_intermediateStepsOutputKey = "intermediateSteps"
+	_stopInfoOutputKey          = "stopInfo"
+)
 
 // Executor is the chain responsible for running agents.
 type Executor struct {
@@ -20,9 +27,43 @@ type Executor struct {
 	Memory           schema.Memory
 	CallbacksHandler callbacks.Handler
 	ErrorHandler     *ParserErrorHandler
+	// ToolErrorHandler handles the errors returned by tools without a handler in
+	// ToolErrorHandlers. If nil, tool errors abort the run.
+	ToolErrorHandler *ToolErrorHandler
+	// ToolErrorHandlers maps tool names to the handler used for their errors.
+	ToolErrorHandlers map[string]*ToolErrorHandler
+	// ApprovalPolicy decides which actions of tools without a policy in
+	// ApprovalPolicies need approval. If nil, they run without approval.
+	ApprovalPolicy ApprovalPolicy
+	// ApprovalPolicies maps tool names to the policy used for their actions.
+	ApprovalPolicies map[string]ApprovalPolicy
+	// Checkpointer saves the state of the runs with a thread ID, see
+	// ContextWithThreadID, after each step.
+	Checkpointer Checkpointer
+
+	MaxIterations int
+	// MaxExecutionTime is the maximum duration of a run, checked before each
+	// iteration. Zero means no limit.
+	MaxExecutionTime time.Duration
+	// MaxTokens is the maximum number of tokens used by a run, checked before
+	// each iteration. Zero means no limit. Tokens are counted from the usage
+	// reported by the LLMs to the agents of this package.
+	MaxTokens int
+	// EarlyStoppingMethod decides what happens when a run reaches one of the
+	// limits above. Defaults to EarlyStoppingForce.
+	EarlyStoppingMethod EarlyStoppingMethod
 
-	MaxIterations           int
 	ReturnIntermediateSteps bool
+	// ReturnStopInfo adds a StopInfo explaining why the run stopped to the
+	// outputs, under the "stopInfo" key.
+	ReturnStopInfo bool
+	// MaxParallelToolCalls is the maximum number of actions returned by a
+	// single plan that are executed concurrently. Values lower than 2 run the
+	// actions one at a time.
+	MaxParallelToolCalls int
+
+	// callOptions are the options of the call being run, see withCallOptions.
+	callOptions []chains.ChainCallOption
 }
 
 var (
@@ -44,76 +85,564 @@ func NewExecutor(agent Agent, opts ...Option) *Executor {
 		ReturnIntermediateSteps: options.returnIntermediateSteps,
 		CallbacksHandler:        options.callbacksHandler,
 		ErrorHandler:            options.errorHandler,
+		MaxParallelToolCalls:    options.maxParallelToolCalls,
+		ToolErrorHandler:        options.toolErrorHandler,
+		ToolErrorHandlers:       options.toolErrorHandlers,
+		ApprovalPolicy:          options.approvalPolicy,
+		ApprovalPolicies:        options.approvalPolicies,
+		Checkpointer:            options.checkpointer,
+		MaxExecutionTime:        options.maxExecutionTime,
+		MaxTokens:               options.maxTokens,
+		EarlyStoppingMethod:     options.earlyStoppingMethod,
+		ReturnStopInfo:          options.returnStopInfo,
 	}
 }
 
-func (e *Executor) Call(ctx context.Context, inputValues map[string]any, _ ...chains.ChainCallOption) (map[string]any, error) { //nolint:lll
+// Call runs the agent. The call options are used for the LLM calls of agents
+// implementing CallOptionsAgent, and the callback handler set with
+// chains.WithCallback is called along with the one of the executor.
+func (e *Executor) Call(ctx context.Context, inputValues map[string]any, options ...chains.ChainCallOption) (map[string]any, error) { //nolint:lll
 	inputs, err := inputsToString(inputValues)
 	if err != nil {
 		return nil, err
 	}
+
+	rs := &runState{
+		inputs: inputs,
+		steps:  make([]schema.AgentStep, 0),
+	}
+	rs.threadID, _ = ThreadIDFromContext(ctx)
+	if err := e.checkpoint(ctx, rs, nil, nil); err != nil {
+		return nil, err
+	}
+
+	return e.withCallOptions(options).run(ctx, rs)
+}
+
+// withCallOptions returns a copy of the executor running the agent with the
+// given call options.
+func (e *Executor) withCallOptions(options []chains.ChainCallOption) *Executor {
+	if len(options) == 0 {
+		return e
+	}
+
+	executor := *e
+	executor.callOptions = options
+	if handler := chains.GetCallbackHandler(options...); handler != nil {
+		executor.CallbacksHandler = handler
+		if e.CallbacksHandler != nil {
+			executor.CallbacksHandler = callbacks.CombiningHandler{
+				Callbacks: []callbacks.Handler{e.CallbacksHandler, handler},
+			}
+		}
+	}
+	return &executor
+}
+
+// Resume continues a run interrupted for approval. The decisions map the IDs
+// of the actions awaiting approval to the decision taken on them. The memory
+// of the executor is updated once the run finishes.
+func (e *Executor) Resume(
+	ctx context.Context,
+	interrupt *Interrupt,
+	decisions map[string]ApprovalDecision,
+) (map[string]any, error) {
 	nameToTool := getNameToTool(e.Agent.GetTools())
 
-	steps := make([]schema.AgentStep, 0)
-	for i := 0; i < e.MaxIterations; i++ {
-		var finish map[string]any
-		steps, finish, err = e.doIteration(ctx, steps, nameToTool, inputs)
+	steps, err := e.doApprovedActions(ctx, nameToTool, interrupt, decisions)
+	if err != nil {
+		return nil, err
+	}
+
+	rs := &runState{
+		threadID:  interrupt.ThreadID,
+		parentID:  interrupt.CheckpointID,
+		inputs:    interrupt.Inputs,
+		steps:     steps,
+		iteration: interrupt.Iteration,
+	}
+	if rs.threadID == "" {
+		rs.threadID, _ = ThreadIDFromContext(ctx)
+	}
+	if err := e.checkpoint(ctx, rs, nil, nil); err != nil {
+		return nil, err
+	}
+
+	return e.runAndSaveMemory(ctx, rs)
+}
+
+// ResumeThread continues a run from the latest checkpoint of its thread, e.g.
+// after the process running it died. If the run was interrupted for approval,
+// the *Interrupt is returned and the run must be continued with Resume.
+//
+// If the run has finished, its outputs are returned as saved in the
+// checkpoint. Since checkpoints are stored as JSON, the outputs the agent
+// returned with other types than strings come back as decoded from JSON, e.g.
+// numbers as float64, except for the intermediate steps and the stop info.
+func (e *Executor) ResumeThread(ctx context.Context, threadID string) (map[string]any, error) {
+	if e.Checkpointer == nil {
+		return nil, ErrNoCheckpointer
+	}
+	checkpoint, err := LatestCheckpoint(ctx, e.Checkpointer, threadID)
+	if err != nil {
+		return nil, err
+	}
+	return e.resumeFrom(ctx, checkpoint)
+}
+
+// Replay runs a thread again from one of its checkpoints. The new checkpoints
+// are added to the thread, with the replayed checkpoint as parent, so that the
+// original run is kept.
+func (e *Executor) Replay(ctx context.Context, threadID string, checkpointID int) (map[string]any, error) {
+	if e.Checkpointer == nil {
+		return nil, ErrNoCheckpointer
+	}
+	checkpoint, err := e.Checkpointer.Get(ctx, threadID, checkpointID)
+	if err != nil {
+		return nil, err
+	}
+	return e.resumeFrom(ctx, checkpoint)
+}
+
+func (e *Executor) resumeFrom(ctx context.Context, checkpoint *Checkpoint) (map[string]any, error) {
+	if checkpoint.Finished() {
+		return finishedOutputs(checkpoint)
+	}
+	if checkpoint.Interrupt != nil {
+		interrupt := *checkpoint.Interrupt
+		interrupt.ThreadID = checkpoint.ThreadID
+		interrupt.CheckpointID = checkpoint.ID
+		return nil, &interrupt
+	}
+
+	rs := &runState{
+		threadID:  checkpoint.ThreadID,
+		parentID:  checkpoint.ID,
+		inputs:    checkpoint.Inputs,
+		steps:     checkpoint.Steps,
+		iteration: checkpoint.Iteration,
+		pending:   checkpoint.Pending,
+	}
+	return e.runAndSaveMemory(ctx, rs)
+}
+
+// runAndSaveMemory runs the agent and saves the run to the memory of the
+// executor, for runs continued outside of chains.Call.
+func (e *Executor) runAndSaveMemory(ctx context.Context, rs *runState) (map[string]any, error) {
+	outputs, err := e.run(ctx, rs)
+	if err != nil || e.Memory == nil {
+		return outputs, err
+	}
+
+	memoryKeys := e.Memory.MemoryVariables(ctx)
+	inputValues := make(map[string]any, len(rs.inputs))
+	for key, value := range rs.inputs {
+		if !slices.Contains(memoryKeys, key) {
+			inputValues[key] = value
+		}
+	}
+	if err := e.Memory.SaveContext(ctx, inputValues, outputs); err != nil {
+		return outputs, err
+	}
+
+	return outputs, nil
+}
+
+// runState is the state of a run, saved in its checkpoints, except for the
+// start time and usage which are measured since the run was started or resumed.
+type runState struct {
+	threadID  string
+	parentID  int
+	inputs    map[string]string
+	steps     []schema.AgentStep
+	iteration int
+	pending   []schema.AgentAction
+
+	started time.Time
+	usage   *runUsage
+}
+
+func (rs *runState) stopInfo(reason StopReason) StopInfo {
+	return StopInfo{
+		Reason:     reason,
+		Iterations: rs.iteration,
+		Elapsed:    time.Since(rs.started),
+		Tokens:     int(rs.usage.tokens.Load()),
+	}
+}
+
+// run runs the agent from the given state.
+func (e *Executor) run(ctx context.Context, rs *runState) (map[string]any, error) {
+	nameToTool := getNameToTool(e.Agent.GetTools())
+	rs.started = time.Now()
+	rs.usage = newRunUsage(ctx)
+	ctx = context.WithValue(ctx, runUsageKey{}, rs.usage)
+
+	if err := e.doPending(withIteration(ctx, rs.iteration), rs, nameToTool); err != nil {
+		return nil, err
+	}
+
+	for {
+		if reason := e.limitReached(rs); reason != "" {
+			return e.stop(ctx, rs, reason)
+		}
+		finish, err := e.doIteration(ctx, rs, nameToTool)
 		if finish != nil || err != nil {
 			return finish, err
 		}
 	}
+}
+
+// limitReached returns the reason to stop the run if it reached a limit.
+func (e *Executor) limitReached(rs *runState) StopReason {
+	switch {
+	case rs.iteration >= e.MaxIterations:
+		return StopReasonMaxIterations
+	case e.MaxExecutionTime > 0 && time.Since(rs.started) >= e.MaxExecutionTime:
+		return StopReasonMaxExecutionTime
+	case e.MaxTokens > 0 && rs.usage.tokens.Load() >= int64(e.MaxTokens):
+		return StopReasonMaxTokens
+	default:
+		return ""
+	}
+}
+
+// stop ends a run that reached a limit. With EarlyStoppingGenerate the agent
+// is asked for a final answer first.
+func (e *Executor) stop(ctx context.Context, rs *runState, reason StopReason) (map[string]any, error) {
+	if e.EarlyStoppingMethod == EarlyStoppingGenerate {
+		finish, err := e.generateFinalAnswer(ctx, rs)
+		if err != nil {
+			return nil, err
+		}
+		if finish != nil {
+			if e.CallbacksHandler != nil {
+				e.CallbacksHandler.HandleAgentFinish(ctx, *finish)
+			}
+			info := rs.stopInfo(reason)
+			info.FinalAnswerGenerated = true
+			outputs := e.getReturn(finish, rs.steps, info)
+			if err := e.checkpoint(ctx, rs, nil, outputs); err != nil {
+				return nil, err
+			}
+			return outputs, nil
+		}
+	}
 
+	err := ErrNotFinished
+	switch reason { //nolint:exhaustive
+	case StopReasonMaxExecutionTime:
+		err = ErrMaxExecutionTime
+	case StopReasonMaxTokens:
+		err = ErrMaxTokens
+	}
 	if e.CallbacksHandler != nil {
 		e.CallbacksHandler.HandleAgentFinish(ctx, schema.AgentFinish{
-			ReturnValues: map[string]any{"output": ErrNotFinished.Error()},
+			ReturnValues: map[string]any{"output": err.Error()},
 		})
 	}
 	return e.getReturn(
 		&schema.AgentFinish{ReturnValues: make(map[string]any)},
-		steps,
-	), ErrNotFinished
+		rs.steps,
+		rs.stopInfo(reason),
+	), err
+}
+
+// generateFinalAnswer asks the agent for a final answer from the steps of the
+// run. It returns a nil finish if the agent does not give one.
+func (e *Executor) generateFinalAnswer(ctx context.Context, rs *runState) (*schema.AgentFinish, error) {
+	ctx = withIteration(ctx, rs.iteration+1)
+	emit(ctx, Event{Type: EventPlanStarted})
+
+	steps := make([]schema.AgentStep, 0, len(rs.steps)+1)
+	steps = append(steps, rs.steps...)
+	steps = append(steps, schema.AgentStep{Observation: _forceFinalAnswerObservation})
+
+	_, finish, err := e.plan(ctx, steps, rs.inputs)
+	rs.iteration++
+	if errors.Is(err, ErrUnableToParseOutput) {
+		return nil, nil
+	}
+	return finish, err
 }
 
 func (e *Executor) doIteration( // nolint
 	ctx context.Context,
-	steps []schema.AgentStep,
+	rs *runState,
 	nameToTool map[string]tools.Tool,
-	inputs map[string]string,
-) ([]schema.AgentStep, map[string]any, error) {
-	actions, finish, err := e.Agent.Plan(ctx, steps, inputs)
+) (map[string]any, error) {
+	ctx = withIteration(ctx, rs.iteration+1)
+	emit(ctx, Event{Type: EventPlanStarted})
+	actions, finish, err := e.plan(ctx, rs.steps, rs.inputs)
+	rs.iteration++
 	if errors.Is(err, ErrUnableToParseOutput) && e.ErrorHandler != nil {
 		formattedObservation := err.Error()
 		if e.ErrorHandler.Formatter != nil {
 			formattedObservation = e.ErrorHandler.Formatter(formattedObservation)
 		}
-		steps = append(steps, schema.AgentStep{
+		rs.steps = append(rs.steps, schema.AgentStep{
 			Observation: formattedObservation,
 		})
-		return steps, nil, nil
+		return nil, e.checkpoint(ctx, rs, nil, nil)
 	}
 	if err != nil {
-		return steps, nil, err
+		return nil, err
 	}
 
 	if len(actions) == 0 && finish == nil {
-		return steps, nil, ErrAgentNoReturn
+		return nil, ErrAgentNoReturn
 	}
 
 	if finish != nil {
 		if e.CallbacksHandler != nil {
 			e.CallbacksHandler.HandleAgentFinish(ctx, *finish)
 		}
-		return steps, e.getReturn(finish, steps), nil
+		outputs := e.getReturn(finish, rs.steps, rs.stopInfo(StopReasonFinished))
+		if err := e.checkpoint(ctx, rs, nil, outputs); err != nil {
+			return nil, err
+		}
+		return outputs, nil
+	}
+
+	rs.pending = actions
+	if interrupt := e.checkApprovals(ctx, rs.steps, actions); interrupt != nil {
+		interrupt.ThreadID = rs.threadID
+		interrupt.Inputs = rs.inputs
+		interrupt.Iteration = rs.iteration
+		if err := e.checkpoint(ctx, rs, interrupt, nil); err != nil {
+			return nil, err
+		}
+		return nil, interrupt
+	}
+
+	return nil, e.doPending(ctx, rs, nameToTool)
+}
+
+// plan calls the agent, with the call options if it accepts them.
+func (e *Executor) plan(
+	ctx context.Context,
+	steps []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	if agent, ok := e.Agent.(CallOptionsAgent); ok {
+		return agent.PlanWithOptions(ctx, steps, inputs, e.callOptions...)
+	}
+	return e.Agent.Plan(ctx, steps, inputs)
+}
+
+// doPending runs the pending actions of the run, saving a checkpoint after
+// each step. Actions run concurrently are saved in a single checkpoint.
+func (e *Executor) doPending(ctx context.Context, rs *runState, nameToTool map[string]tools.Tool) error {
+	if len(rs.pending) == 0 {
+		return nil
+	}
+	firstStep := len(rs.steps)
+
+	if e.MaxParallelToolCalls > 1 && len(rs.pending) > 1 {
+		steps, err := e.doActionsParallel(ctx, nameToTool, rs.pending)
+		if err != nil {
+			return err
+		}
+		rs.steps = append(rs.steps, steps...)
+		rs.pending = nil
+		if err := e.checkpoint(ctx, rs, nil, nil); err != nil {
+			return err
+		}
+		emit(ctx, Event{Type: EventStepCompleted, Steps: steps})
+		return nil
+	}
+
+	for len(rs.pending) > 0 {
+		var err error
+		rs.steps, err = e.doAction(ctx, rs.steps, nameToTool, rs.pending[0])
+		if err != nil {
+			return err
+		}
+		rs.pending = rs.pending[1:]
+		if err := e.checkpoint(ctx, rs, nil, nil); err != nil {
+			return err
+		}
+	}
+
+	emit(ctx, Event{Type: EventStepCompleted, Steps: rs.steps[firstStep:]})
+	return nil
+}
+
+// checkpoint saves the state of the run, if the executor has a checkpointer
+// and the run a thread ID.
+func (e *Executor) checkpoint(ctx context.Context, rs *runState, interrupt *Interrupt, outputs map[string]any) error {
+	if e.Checkpointer == nil || rs.threadID == "" {
+		return nil
+	}
+
+	checkpoint := &Checkpoint{
+		ThreadID:  rs.threadID,
+		ParentID:  rs.parentID,
+		Inputs:    rs.inputs,
+		Steps:     rs.steps,
+		Iteration: rs.iteration,
+		Pending:   rs.pending,
+		Interrupt: interrupt,
+		Outputs:   outputs,
+	}
+	if err := e.Checkpointer.Put(ctx, checkpoint); err != nil {
+		return fmt.Errorf("saving checkpoint: %w", err)
 	}
 
+	rs.parentID = checkpoint.ID
+	if interrupt != nil {
+		interrupt.CheckpointID = checkpoint.ID
+	}
+	return nil
+}
+
+// doActions runs the actions of a plan and returns their steps.
+func (e *Executor) doActions(
+	ctx context.Context,
+	nameToTool map[string]tools.Tool,
+	actions []schema.AgentAction,
+) ([]schema.AgentStep, error) {
+	if e.MaxParallelToolCalls > 1 && len(actions) > 1 {
+		return e.doActionsParallel(ctx, nameToTool, actions)
+	}
+
+	steps := make([]schema.AgentStep, 0, len(actions))
 	for _, action := range actions {
+		var err error
 		steps, err = e.doAction(ctx, steps, nameToTool, action)
 		if err != nil {
-			return steps, nil, err
+			return nil, err
 		}
 	}
 
-	return steps, nil, nil
+	return steps, nil
+}
+
+// checkApprovals returns an interrupt if any of the actions needs approval.
+func (e *Executor) checkApprovals(
+	ctx context.Context,
+	steps []schema.AgentStep,
+	actions []schema.AgentAction,
+) *Interrupt {
+	requiresApproval := make([]bool, len(actions))
+	interrupted := false
+	for i, action := range actions {
+		if policy := e.getApprovalPolicy(action.Tool); policy != nil && policy(ctx, action) {
+			requiresApproval[i] = true
+			interrupted = true
+		}
+	}
+	if !interrupted {
+		return nil
+	}
+
+	return newInterrupt(steps, actions, requiresApproval)
+}
+
+// doApprovedActions applies the decisions to the actions of an interrupted
+// plan, runs the actions that are not rejected and returns the steps of the
+// run.
+func (e *Executor) doApprovedActions(
+	ctx context.Context,
+	nameToTool map[string]tools.Tool,
+	interrupt *Interrupt,
+	decisions map[string]ApprovalDecision,
+) ([]schema.AgentStep, error) {
+	newSteps := make([]schema.AgentStep, len(interrupt.Actions))
+	toRun := make([]schema.AgentAction, 0, len(interrupt.Actions))
+	toRunIndices := make([]int, 0, len(interrupt.Actions))
+
+	for i, pending := range interrupt.Actions {
+		action := pending.Action
+		if pending.RequiresApproval {
+			decision, ok := decisions[pending.ID]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s", ErrMissingApproval, pending.ID)
+			}
+			switch decision.Type {
+			case ApprovalApprove:
+			case ApprovalEdit:
+				action.ToolInput = decision.ToolInput
+			case ApprovalReject:
+				newSteps[i] = schema.AgentStep{
+					Action:      action,
+					Observation: rejectionObservation(action, decision.Feedback),
+				}
+				continue
+			default:
+				return nil, fmt.Errorf("%w: %q for %s", ErrInvalidApproval, decision.Type, pending.ID)
+			}
+		}
+		toRun = append(toRun, action)
+		toRunIndices = append(toRunIndices, i)
+	}
+
+	ranSteps, err := e.doActions(ctx, nameToTool, toRun)
+	if err != nil {
+		return nil, err
+	}
+	for i, step := range ranSteps {
+		newSteps[toRunIndices[i]] = step
+	}
+
+	steps := make([]schema.AgentStep, 0, len(interrupt.Steps)+len(newSteps))
+	steps = append(steps, interrupt.Steps...)
+	return append(steps, newSteps...), nil
+}
+
+// doActionsParallel runs the actions concurrently, with at most
+// MaxParallelToolCalls actions running at the same time. The steps are
+// returned in the order of the actions. If an action fails, the context of the
+// other actions is canceled and the first error is returned.
+func (e *Executor) doActionsParallel(
+	ctx context.Context,
+	nameToTool map[string]tools.Tool,
+	actions []schema.AgentAction,
+) ([]schema.AgentStep, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	steps := make([]schema.AgentStep, len(actions))
+	sem := make(chan struct{}, e.MaxParallelToolCalls)
+
+	for i, action := range actions {
+		wg.Add(1)
+		go func(i int, action schema.AgentAction) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			step, err := e.runAction(ctx, nameToTool, action)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			steps[i] = step
+		}(i, action)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return steps, nil
 }
 
 func (e *Executor) doAction(
@@ -122,33 +651,134 @@ func (e *Executor) doAction(
 	nameToTool map[string]tools.Tool,
 	action schema.AgentAction,
 ) ([]schema.AgentStep, error) {
+	step, err := e.runAction(ctx, nameToTool, action)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(steps, step), nil
+}
+
+func (e *Executor) runAction(
+	ctx context.Context,
+	nameToTool map[string]tools.Tool,
+	action schema.AgentAction,
+) (schema.AgentStep, error) {
 	if e.CallbacksHandler != nil {
 		e.CallbacksHandler.HandleAgentAction(ctx, action)
 	}
+	emit(ctx, Event{Type: EventToolCall, Action: &action})
 
+	step := schema.AgentStep{Action: action}
 	tool, ok := nameToTool[strings.ToUpper(action.Tool)]
-	if !ok {
-		return append(steps, schema.AgentStep{
-			Action:      action,
-			Observation: fmt.Sprintf("%s is not a valid tool, try another one", action.Tool),
-		}), nil
+	if ok {
+		observation, err := e.callTool(ctx, tool, action)
+		if err != nil {
+			return schema.AgentStep{}, err
+		}
+		step.Observation = observation
+	} else {
+		step.Observation = fmt.Sprintf("%s is not a valid tool, try another one", action.Tool)
 	}
 
+	emit(ctx, Event{Type: EventToolResult, Action: &action, Steps: []schema.AgentStep{step}})
+	return step, nil
+}
+
+// callTool calls the tool, applying the tool error handler of the tool to the
+// errors it returns.
+func (e *Executor) callTool(ctx context.Context, tool tools.Tool, action schema.AgentAction) (string, error) {
+	handler := e.getToolErrorHandler(tool.Name())
+
 	observation, err := tool.Call(ctx, action.ToolInput)
+	if err == nil {
+		return observation, nil
+	}
+	if handler == nil {
+		return "", err
+	}
+
+	for retry := 0; retry < handler.MaxRetries && ctx.Err() == nil; retry++ {
+		if e.CallbacksHandler != nil {
+			e.CallbacksHandler.HandleToolError(ctx, err)
+		}
+		observation, err = tool.Call(ctx, action.ToolInput)
+		if err == nil {
+			return observation, nil
+		}
+	}
+
+	if e.CallbacksHandler != nil {
+		e.CallbacksHandler.HandleToolError(ctx, err)
+	}
+	if handler.Policy != ToolErrorPolicyObserve || ctx.Err() != nil {
+		return "", err
+	}
+	if handler.Formatter != nil {
+		return handler.Formatter(tool.Name(), err), nil
+	}
+	return err.Error(), nil
+}
+
+// finishedOutputs returns the outputs of a finished checkpoint, with the
+// intermediate steps and the stop info decoded again into their types.
+func finishedOutputs(checkpoint *Checkpoint) (map[string]any, error) {
+	outputs := make(map[string]any, len(checkpoint.Outputs))
+	for key, value := range checkpoint.Outputs {
+		outputs[key] = value
+	}
+
+	if value, ok := outputs[_intermediateStepsOutputKey]; ok {
+		var steps []schema.AgentStep
+		if err := redecode(value, &steps); err != nil {
+			return nil, err
+		}
+		outputs[_intermediateStepsOutputKey] = steps
+	}
+	if value, ok := outputs[_stopInfoOutputKey]; ok {
+		var info StopInfo
+		if err := redecode(value, &info); err != nil {
+			return nil, err
+		}
+		outputs[_stopInfoOutputKey] = info
+	}
+	return outputs, nil
+}
+
+// redecode decodes a value decoded from JSON again into v.
+func redecode(value, v any) error {
+	data, err := json.Marshal(value)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func (e *Executor) getToolErrorHandler(toolName string) *ToolErrorHandler {
+	for name, handler := range e.ToolErrorHandlers {
+		if strings.EqualFold(name, toolName) {
+			return handler
+		}
 	}
+	return e.ToolErrorHandler
+}
 
-	return append(steps, schema.AgentStep{
-		Action:      action,
-		Observation: observation,
-	}), nil
+func (e *Executor) getApprovalPolicy(toolName string) ApprovalPolicy {
+	for name, policy := range e.ApprovalPolicies {
+		if strings.EqualFold(name, toolName) {
+			return policy
+		}
+	}
+	return e.ApprovalPolicy
 }
 
-func (e *Executor) getReturn(finish *schema.AgentFinish, steps []schema.AgentStep) map[string]any {
+func (e *Executor) getReturn(finish *schema.AgentFinish, steps []schema.AgentStep, info StopInfo) map[string]any {
 	if e.ReturnIntermediateSteps {
 		finish.ReturnValues[_intermediateStepsOutputKey] = steps
 	}
+	if e.ReturnStopInfo {
+		finish.ReturnValues[_stopInfoOutputKey] = info
+	}
 
 	return finish.ReturnValues
 }
@@ -2,13 +2,19 @@ package agents_test
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"os"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/llms/openai"
 	"github.com/tmc/langchaingo/prompts"
 	"github.com/tmc/langchaingo/schema"
@@ -141,3 +147,290 @@ func TestExecutorWithOpenAIFunctionAgent(t *testing.T) {
 	require.True(t, strings.Contains(result, "47") || strings.Contains(result, "49"),
 		"correct answer 47 or 49 not in response")
 }
+
+type scriptedAgent struct {
+	plans [][]schema.AgentAction
+	tools []tools.Tool
+
+	numPlanCalls int
+}
+
+func (a *scriptedAgent) Plan(
+	_ context.Context,
+	intermediateSteps []schema.AgentStep,
+	_ map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	defer func() { a.numPlanCalls++ }()
+	if a.numPlanCalls < len(a.plans) {
+		return a.plans[a.numPlanCalls], nil, nil
+	}
+
+	observations := make([]string, 0, len(intermediateSteps))
+	for _, step := range intermediateSteps {
+		observations = append(observations, step.Observation)
+	}
+	return nil, &schema.AgentFinish{
+		ReturnValues: map[string]any{"output": strings.Join(observations, ",")},
+	}, nil
+}
+
+func (a *scriptedAgent) GetInputKeys() []string  { return []string{"input"} }
+func (a *scriptedAgent) GetOutputKeys() []string { return []string{"output"} }
+func (a *scriptedAgent) GetTools() []tools.Tool  { return a.tools }
+
+type funcTool struct {
+	name string
+	fn   func(ctx context.Context, input string) (string, error)
+}
+
+func (t funcTool) Name() string        { return t.name }
+func (t funcTool) Description() string { return t.name }
+func (t funcTool) Call(ctx context.Context, input string) (string, error) {
+	return t.fn(ctx, input)
+}
+
+func TestExecutorParallelToolCalls(t *testing.T) {
+	t.Parallel()
+
+	var running, maxRunning atomic.Int32
+	weather := funcTool{name: "weather", fn: func(_ context.Context, input string) (string, error) {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			m := maxRunning.Load()
+			if n <= m || maxRunning.CompareAndSwap(m, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+		return "sunny in " + input, nil
+	}}
+
+	a := &scriptedAgent{
+		plans: [][]schema.AgentAction{{
+			{Tool: "weather", ToolInput: "paris"},
+			{Tool: "weather", ToolInput: "tokyo"},
+			{Tool: "weather", ToolInput: "lima"},
+		}},
+		tools: []tools.Tool{weather},
+	}
+	executor := agents.NewExecutor(a, agents.WithMaxParallelToolCalls(2))
+
+	result, err := chains.Run(context.Background(), executor, "weather?")
+	require.NoError(t, err)
+	require.Equal(t, "sunny in paris,sunny in tokyo,sunny in lima", result)
+	require.Equal(t, int32(2), maxRunning.Load())
+}
+
+func TestExecutorParallelToolCallsCancelsOnError(t *testing.T) {
+	t.Parallel()
+
+	errFatal := errors.New("fatal")
+	failing := funcTool{name: "fail", fn: func(context.Context, string) (string, error) {
+		return "", errFatal
+	}}
+	slow := funcTool{name: "slow", fn: func(ctx context.Context, _ string) (string, error) {
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(5 * time.Second):
+			return "done", nil
+		}
+	}}
+
+	a := &scriptedAgent{
+		plans: [][]schema.AgentAction{{
+			{Tool: "slow"},
+			{Tool: "fail"},
+		}},
+		tools: []tools.Tool{failing, slow},
+	}
+	executor := agents.NewExecutor(a, agents.WithMaxParallelToolCalls(2))
+
+	start := time.Now()
+	_, err := chains.Run(context.Background(), executor, "go")
+	require.ErrorIs(t, err, errFatal)
+	require.Less(t, time.Since(start), time.Second)
+}
+
+func TestExecutorToolErrorHandler(t *testing.T) {
+	t.Parallel()
+
+	errFlaky := errors.New("connection reset")
+	var searchCalls, sqlCalls atomic.Int32
+	search := funcTool{name: "search", fn: func(context.Context, string) (string, error) {
+		if searchCalls.Add(1) < 3 {
+			return "", errFlaky
+		}
+		return "found", nil
+	}}
+	sql := funcTool{name: "sql", fn: func(context.Context, string) (string, error) {
+		sqlCalls.Add(1)
+		return "", errors.New("no such table")
+	}}
+
+	newAgent := func() *scriptedAgent {
+		return &scriptedAgent{
+			plans: [][]schema.AgentAction{{{Tool: "search"}}, {{Tool: "sql"}}},
+			tools: []tools.Tool{search, sql},
+		}
+	}
+
+	// Without a handler the first error aborts the run.
+	_, err := chains.Run(context.Background(), agents.NewExecutor(newAgent()), "go")
+	require.ErrorIs(t, err, errFlaky)
+
+	searchCalls.Store(0)
+	executor := agents.NewExecutor(newAgent(),
+		agents.WithToolErrorHandler(agents.NewToolErrorHandler(func(tool string, err error) string {
+			return tool + " failed: " + err.Error()
+		})),
+		agents.WithToolErrorHandlerFor("search", agents.NewToolErrorHandler(nil).WithMaxRetries(2)),
+	)
+	result, err := chains.Run(context.Background(), executor, "go")
+	require.NoError(t, err)
+	require.Equal(t, "found,sql failed: no such table", result)
+	require.Equal(t, int32(3), searchCalls.Load())
+	require.Equal(t, int32(1), sqlCalls.Load())
+
+	executor = agents.NewExecutor(newAgent(),
+		agents.WithToolErrorHandler(agents.NewToolErrorHandler(nil)),
+		agents.WithToolErrorHandlerFor("SQL", &agents.ToolErrorHandler{
+			Policy:     agents.ToolErrorPolicyAbort,
+			MaxRetries: 1,
+		}),
+	)
+	_, err = chains.Run(context.Background(), executor, "go")
+	require.ErrorContains(t, err, "no such table")
+	require.Equal(t, int32(3), sqlCalls.Load())
+}
+
+func TestExecutorApproval(t *testing.T) {
+	t.Parallel()
+
+	var sent []string
+	email := funcTool{name: "send_email", fn: func(_ context.Context, input string) (string, error) {
+		sent = append(sent, input)
+		return "sent to " + input, nil
+	}}
+	search := funcTool{name: "search", fn: func(_ context.Context, input string) (string, error) {
+		return "found " + input, nil
+	}}
+
+	a := &scriptedAgent{
+		plans: [][]schema.AgentAction{
+			{
+				{Tool: "search", ToolInput: "alice"},
+				{Tool: "send_email", ToolInput: "alice@example.com", ToolID: "call_1"},
+			},
+			{{Tool: "send_email", ToolInput: "bob@example.com"}},
+		},
+		tools: []tools.Tool{email, search},
+	}
+	executor := agents.NewExecutor(a, agents.WithApprovalPolicyFor("send_email", agents.RequireApproval))
+
+	_, err := chains.Run(context.Background(), executor, "email alice and bob")
+	require.ErrorIs(t, err, agents.ErrInterrupted)
+	var interrupt *agents.Interrupt
+	require.ErrorAs(t, err, &interrupt)
+	require.Empty(t, sent)
+
+	// The interrupt can be stored and resumed later.
+	data, err := json.Marshal(interrupt)
+	require.NoError(t, err)
+	interrupt = nil
+	require.NoError(t, json.Unmarshal(data, &interrupt))
+
+	awaiting := interrupt.AwaitingApproval()
+	require.Len(t, awaiting, 1)
+	require.Equal(t, "call_1", awaiting[0].ID)
+	require.Equal(t, "alice@example.com", awaiting[0].Action.ToolInput)
+
+	_, err = executor.Resume(context.Background(), interrupt, nil)
+	require.ErrorIs(t, err, agents.ErrMissingApproval)
+
+	_, err = executor.Resume(context.Background(), interrupt, map[string]agents.ApprovalDecision{
+		"call_1": agents.EditInput("alice@example.org"),
+	})
+	require.ErrorAs(t, err, &interrupt)
+	require.Equal(t, []string{"alice@example.org"}, sent)
+	require.Len(t, interrupt.Steps, 2)
+	require.Equal(t, "action-0", interrupt.AwaitingApproval()[0].ID)
+
+	outputs, err := executor.Resume(context.Background(), interrupt, map[string]agents.ApprovalDecision{
+		"action-0": agents.Reject("bob is on holiday"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"alice@example.org"}, sent)
+	require.Equal(t,
+		"found alice,sent to alice@example.org,The call to send_email was rejected by the user: bob is on holiday",
+		outputs["output"])
+}
+
+// recordingHandler records the streamed chunks and the finishes of the agent.
+type recordingHandler struct {
+	callbacks.SimpleHandler
+	chunks   []string
+	finishes []schema.AgentFinish
+}
+
+func (h *recordingHandler) HandleStreamingFunc(_ context.Context, chunk []byte) {
+	h.chunks = append(h.chunks, string(chunk))
+}
+
+func (h *recordingHandler) HandleAgentFinish(_ context.Context, finish schema.AgentFinish) {
+	h.finishes = append(h.finishes, finish)
+}
+
+func TestExecutorCallOptions(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name     string
+		newAgent func(llm *toolCallingLLM) agents.Agent
+		response string
+	}{
+		{
+			name:     "mrkl",
+			newAgent: func(llm *toolCallingLLM) agents.Agent { return agents.NewOneShotAgent(llm, nil) },
+			response: "Final Answer: done",
+		},
+		{
+			name:     "conversational",
+			newAgent: func(llm *toolCallingLLM) agents.Agent { return agents.NewConversationalAgent(llm, nil) },
+			response: "AI: done",
+		},
+		{
+			name:     "openai functions",
+			newAgent: func(llm *toolCallingLLM) agents.Agent { return agents.NewOpenAIFunctionsAgent(llm, nil) },
+			response: "done",
+		},
+	}
+
+	for _, tc := range testCases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			llm := &toolCallingLLM{responses: []*llms.ContentResponse{
+				{Choices: []*llms.ContentChoice{{Content: tc.response}}},
+			}}
+			handler := &recordingHandler{}
+			executor := agents.NewExecutor(tc.newAgent(llm))
+
+			output, err := chains.Run(context.Background(), executor, "hello",
+				chains.WithTemperature(0.3),
+				chains.WithModel("some-model"),
+				chains.WithCallback(handler),
+			)
+			require.NoError(t, err)
+			require.Equal(t, "done", strings.TrimSpace(output))
+
+			require.Len(t, llm.options, 1)
+			require.InDelta(t, 0.3, llm.options[0].Temperature, 1e-9)
+			require.Equal(t, "some-model", llm.options[0].Model)
+			require.Equal(t, []string{tc.response}, handler.chunks)
+			require.Len(t, handler.finishes, 1)
+		})
+	}
+}
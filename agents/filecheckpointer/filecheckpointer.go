@@ -0,0 +1,193 @@
+// Package filecheckpointer provides an agents.Checkpointer saving checkpoints
+// as JSON files.
+//
+// Each thread has its own directory, named after the base64 encoding of the
+// thread ID, containing a file per checkpoint. Checkpoint files are never
+// modified once written, so several processes can share a directory.
+package filecheckpointer
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tmc/langchaingo/agents"
+)
+
+const fileExt = ".json"
+
+// ErrEmptyThreadID is returned when saving a checkpoint without a thread ID.
+var ErrEmptyThreadID = errors.New("checkpoint has no thread ID")
+
+// Checkpointer is an agents.Checkpointer saving checkpoints as JSON files in a
+// directory.
+type Checkpointer struct {
+	dir string
+}
+
+var _ agents.Checkpointer = (*Checkpointer)(nil)
+
+// New creates a new Checkpointer saving checkpoints in dir, which is created
+// if it does not exist.
+func New(dir string) (*Checkpointer, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &Checkpointer{dir: dir}, nil
+}
+
+// Put saves a checkpoint in a new file of the directory of its thread.
+func (c *Checkpointer) Put(_ context.Context, checkpoint *agents.Checkpoint) error {
+	if checkpoint.ThreadID == "" {
+		return ErrEmptyThreadID
+	}
+	threadDir := c.threadDir(checkpoint.ThreadID)
+	if err := os.MkdirAll(threadDir, 0o700); err != nil {
+		return err
+	}
+	ids, err := c.ids(checkpoint.ThreadID)
+	if err != nil {
+		return err
+	}
+	id := 1
+	if len(ids) > 0 {
+		id = ids[len(ids)-1] + 1
+	}
+
+	tmp, err := os.CreateTemp(threadDir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	// Linking the temporary file fails if the checkpoint file exists, e.g.
+	// if another process saved a checkpoint in the meantime: try the next ID.
+	for ; ; id++ {
+		checkpoint.ID = id
+		checkpoint.CreatedAt = time.Now()
+		if err := writeJSON(tmp, checkpoint); err != nil {
+			tmp.Close()
+			return err
+		}
+		err := os.Link(tmp.Name(), c.checkpointPath(checkpoint.ThreadID, id))
+		if err == nil {
+			return tmp.Close()
+		}
+		if !errors.Is(err, os.ErrExist) {
+			tmp.Close()
+			return err
+		}
+	}
+}
+
+// Get returns a checkpoint of a thread.
+func (c *Checkpointer) Get(_ context.Context, threadID string, id int) (*agents.Checkpoint, error) {
+	data, err := os.ReadFile(c.checkpointPath(threadID, id))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, agents.ErrCheckpointNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint agents.Checkpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("reading checkpoint %d of thread %s: %w", id, threadID, err)
+	}
+	return &checkpoint, nil
+}
+
+// List returns the checkpoints of a thread, oldest first.
+func (c *Checkpointer) List(ctx context.Context, threadID string) ([]agents.Checkpoint, error) {
+	ids, err := c.ids(threadID)
+	if err != nil {
+		return nil, err
+	}
+
+	checkpoints := make([]agents.Checkpoint, 0, len(ids))
+	for _, id := range ids {
+		checkpoint, err := c.Get(ctx, threadID, id)
+		if err != nil {
+			return nil, err
+		}
+		checkpoints = append(checkpoints, *checkpoint)
+	}
+	return checkpoints, nil
+}
+
+// Threads returns the IDs of the threads with checkpoints, sorted.
+func (c *Checkpointer) Threads(_ context.Context) ([]string, error) {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	threadIDs := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		threadID, err := base64.RawURLEncoding.DecodeString(entry.Name())
+		if err != nil {
+			continue
+		}
+		threadIDs = append(threadIDs, string(threadID))
+	}
+	sort.Strings(threadIDs)
+	return threadIDs, nil
+}
+
+// ids returns the sorted IDs of the checkpoints of a thread.
+func (c *Checkpointer) ids(threadID string) ([]int, error) {
+	entries, err := os.ReadDir(c.threadDir(threadID))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), fileExt)
+		if !ok {
+			continue
+		}
+		id, err := strconv.Atoi(name)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids, nil
+}
+
+func (c *Checkpointer) threadDir(threadID string) string {
+	return filepath.Join(c.dir, base64.RawURLEncoding.EncodeToString([]byte(threadID)))
+}
+
+func (c *Checkpointer) checkpointPath(threadID string, id int) string {
+	return filepath.Join(c.threadDir(threadID), fmt.Sprintf("%08d%s", id, fileExt))
+}
+
+func writeJSON(f *os.File, v any) error {
+	if err := f.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, 0); err != nil {
+		return err
+	}
+	if err := json.NewEncoder(f).Encode(v); err != nil {
+		return err
+	}
+	return f.Sync()
+}
@@ -0,0 +1,60 @@
+package filecheckpointer_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/agents/filecheckpointer"
+	"github.com/tmc/langchaingo/schema"
+)
+
+func TestCheckpointer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+	c, err := filecheckpointer.New(dir)
+	require.NoError(t, err)
+
+	first := &agents.Checkpoint{ThreadID: "users/alice", Inputs: map[string]string{"input": "hi"}}
+	require.NoError(t, c.Put(ctx, first))
+	assert.Equal(t, 1, first.ID)
+
+	second := &agents.Checkpoint{
+		ThreadID:  "users/alice",
+		ParentID:  first.ID,
+		Steps:     []schema.AgentStep{{Action: schema.AgentAction{Tool: "search"}, Observation: "found"}},
+		Iteration: 1,
+	}
+	require.NoError(t, c.Put(ctx, second))
+	assert.Equal(t, 2, second.ID)
+	require.NoError(t, c.Put(ctx, &agents.Checkpoint{ThreadID: "bob"}))
+
+	// A new checkpointer on the same directory sees the same checkpoints.
+	c, err = filecheckpointer.New(dir)
+	require.NoError(t, err)
+
+	got, err := c.Get(ctx, "users/alice", 2)
+	require.NoError(t, err)
+	assert.Equal(t, second.Steps, got.Steps)
+	assert.Equal(t, 1, got.ParentID)
+
+	checkpoints, err := c.List(ctx, "users/alice")
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 2)
+	assert.Equal(t, "hi", checkpoints[0].Inputs["input"])
+
+	threads, err := c.Threads(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"bob", "users/alice"}, threads)
+
+	_, err = c.Get(ctx, "users/alice", 3)
+	require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+	checkpoints, err = c.List(ctx, "carol")
+	require.NoError(t, err)
+	assert.Empty(t, checkpoints)
+	require.ErrorIs(t, c.Put(ctx, &agents.Checkpoint{}), filecheckpointer.ErrEmptyThreadID)
+}
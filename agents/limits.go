@@ -0,0 +1,144 @@
+package agents
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/tmc/langchaingo/llms"
+)
+
+// _forceFinalAnswerObservation is the observation given to the agent to get a
+// final answer when a run is stopped with EarlyStoppingGenerate.
+const _forceFinalAnswerObservation = "You have reached the limit of steps for this task. " +
+	"Do not use any more tools. Give your best final answer now, based on the previous observations."
+
+// EarlyStoppingMethod decides what the executor does when a run reaches one of
+// its limits before the agent finishes.
+type EarlyStoppingMethod string
+
+const (
+	// EarlyStoppingForce stops the run with an error wrapping ErrNotFinished.
+	EarlyStoppingForce EarlyStoppingMethod = "force"
+	// EarlyStoppingGenerate asks the agent for a best-effort final answer from
+	// the steps taken so far, in one last planning call not subject to the
+	// limits. If the agent still does not finish, the run is stopped as with
+	// EarlyStoppingForce.
+	EarlyStoppingGenerate EarlyStoppingMethod = "generate"
+)
+
+// StopReason is the reason a run of the executor stopped.
+type StopReason string
+
+const (
+	// StopReasonFinished is used when the agent finished on its own.
+	StopReasonFinished StopReason = "finished"
+	// StopReasonMaxIterations is used when the run reached MaxIterations.
+	StopReasonMaxIterations StopReason = "max_iterations"
+	// StopReasonMaxExecutionTime is used when the run reached MaxExecutionTime.
+	StopReasonMaxExecutionTime StopReason = "max_execution_time"
+	// StopReasonMaxTokens is used when the run reached MaxTokens.
+	StopReasonMaxTokens StopReason = "max_tokens"
+)
+
+// StopInfo explains why a run stopped. It is added to the outputs of the
+// executor under the "stopInfo" key with WithReturnStopInfo.
+type StopInfo struct {
+	Reason StopReason `json:"reason"`
+	// Iterations is the number of iterations of the run.
+	Iterations int `json:"iterations"`
+	// Elapsed is the duration of the run, since it was started or resumed.
+	Elapsed time.Duration `json:"elapsed"`
+	// Tokens is the number of tokens used by the run, since it was started or
+	// resumed, as reported by the LLMs of the agents of this package.
+	Tokens int `json:"tokens"`
+	// FinalAnswerGenerated is true if the final answer was asked to the agent
+	// after a limit was reached, see EarlyStoppingGenerate.
+	FinalAnswerGenerated bool `json:"final_answer_generated"`
+}
+
+// runUsage counts the tokens used by a run. The tokens of nested runs, e.g.
+// of workers, are added to the usage of their parent run too.
+type runUsage struct {
+	tokens atomic.Int64
+	parent *runUsage
+}
+
+func newRunUsage(ctx context.Context) *runUsage {
+	parent, _ := ctx.Value(runUsageKey{}).(*runUsage)
+	return &runUsage{parent: parent}
+}
+
+type runUsageKey struct{}
+
+// recordUsage adds the tokens of a response to the usage of the run of the
+// context, if any. The usage is read from the generation info of the first
+// choice reporting it, since some providers (e.g. anthropic) repeat it in
+// every choice.
+func recordUsage(ctx context.Context, resp *llms.ContentResponse) {
+	usage, ok := ctx.Value(runUsageKey{}).(*runUsage)
+	if !ok || resp == nil {
+		return
+	}
+	for _, choice := range resp.Choices {
+		if tokens := tokenCount(choice.GenerationInfo); tokens > 0 {
+			for u := usage; u != nil; u = u.parent {
+				u.tokens.Add(int64(tokens))
+			}
+			return
+		}
+	}
+}
+
+// tokenCount returns the number of tokens in the generation info of a choice,
+// handling the keys used by the different providers.
+func tokenCount(info map[string]any) int {
+	for _, key := range []string{"TotalTokens", "total_tokens"} {
+		if n, ok := toInt(info[key]); ok {
+			return n
+		}
+	}
+
+	total := 0
+	for _, key := range []string{
+		"PromptTokens", "CompletionTokens",
+		"InputTokens", "OutputTokens",
+		"input_tokens", "output_tokens",
+	} {
+		if n, ok := toInt(info[key]); ok {
+			total += n
+		}
+	}
+	return total
+}
+
+func toInt(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int32:
+		return int(n), true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), true
+	default:
+		return 0, false
+	}
+}
+
+// usageRecordingLLM records the usage of the responses of an LLM, for the
+// agents calling it through a chain.
+type usageRecordingLLM struct {
+	llms.Model
+}
+
+func (l usageRecordingLLM) GenerateContent(
+	ctx context.Context,
+	messages []llms.MessageContent,
+	options ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	resp, err := l.Model.GenerateContent(ctx, messages, options...)
+	recordUsage(ctx, resp)
+	return resp, err
+}
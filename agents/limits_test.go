@@ -0,0 +1,105 @@
+package agents_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/tools"
+)
+
+func TestExecutorEarlyStoppingGenerate(t *testing.T) {
+	t.Parallel()
+
+	llm := &toolCallingLLM{
+		responses: []*llms.ContentResponse{
+			{Choices: []*llms.ContentChoice{
+				{ToolCalls: []llms.ToolCall{toolCall("call_1", "weather", `{"__arg1":"Paris"}`)}},
+			}},
+			{Choices: []*llms.ContentChoice{{Content: "Probably sunny."}}},
+		},
+	}
+	weather := funcTool{name: "weather", fn: func(_ context.Context, input string) (string, error) {
+		return "sunny in " + input, nil
+	}}
+	executor := agents.NewExecutor(
+		agents.NewToolCallingAgent(llm, []tools.Tool{weather}),
+		agents.WithMaxIterations(1),
+		agents.WithEarlyStoppingMethod(agents.EarlyStoppingGenerate),
+		agents.WithReturnStopInfo(),
+	)
+
+	outputs, err := chains.Call(context.Background(), executor, map[string]any{"input": "weather in Paris?"})
+	require.NoError(t, err)
+	require.Equal(t, "Probably sunny.", outputs["output"])
+
+	info, ok := outputs["stopInfo"].(agents.StopInfo)
+	require.True(t, ok)
+	require.Equal(t, agents.StopReasonMaxIterations, info.Reason)
+	require.True(t, info.FinalAnswerGenerated)
+	require.Equal(t, 2, info.Iterations)
+
+	// The last call asks for a final answer after the tool result.
+	lastCall := llm.calls[1]
+	last := lastCall[len(lastCall)-1]
+	require.Equal(t, llms.ChatMessageTypeHuman, last.Role)
+	require.Equal(t, llms.ChatMessageTypeTool, lastCall[len(lastCall)-2].Role)
+}
+
+func TestExecutorMaxTokens(t *testing.T) {
+	t.Parallel()
+
+	llm := &toolCallingLLM{
+		responses: []*llms.ContentResponse{
+			{Choices: []*llms.ContentChoice{{
+				ToolCalls:      []llms.ToolCall{toolCall("call_1", "weather", `{"__arg1":"Paris"}`)},
+				GenerationInfo: map[string]any{"InputTokens": 80, "OutputTokens": 20},
+			}}},
+		},
+	}
+	weather := funcTool{name: "weather", fn: func(_ context.Context, input string) (string, error) {
+		return "sunny in " + input, nil
+	}}
+	executor := agents.NewExecutor(
+		agents.NewToolCallingAgent(llm, []tools.Tool{weather}),
+		agents.WithMaxTokens(50),
+		agents.WithReturnStopInfo(),
+	)
+
+	outputs, err := executor.Call(context.Background(), map[string]any{"input": "weather in Paris?"})
+	require.ErrorIs(t, err, agents.ErrMaxTokens)
+	require.ErrorIs(t, err, agents.ErrNotFinished)
+
+	info, ok := outputs["stopInfo"].(agents.StopInfo)
+	require.True(t, ok)
+	require.Equal(t, agents.StopReasonMaxTokens, info.Reason)
+	require.Equal(t, 100, info.Tokens)
+	require.Equal(t, 1, info.Iterations)
+	require.False(t, info.FinalAnswerGenerated)
+}
+
+func TestExecutorMaxExecutionTime(t *testing.T) {
+	t.Parallel()
+
+	slow := funcTool{name: "search", fn: func(_ context.Context, input string) (string, error) {
+		time.Sleep(20 * time.Millisecond)
+		return "found " + input, nil
+	}}
+	executor := agents.NewExecutor(&stepsAgent{tools: []tools.Tool{slow}},
+		agents.WithMaxExecutionTime(10*time.Millisecond),
+		agents.WithReturnStopInfo(),
+	)
+
+	outputs, err := executor.Call(context.Background(), map[string]any{"input": "search"})
+	require.ErrorIs(t, err, agents.ErrMaxExecutionTime)
+
+	info, ok := outputs["stopInfo"].(agents.StopInfo)
+	require.True(t, ok)
+	require.Equal(t, agents.StopReasonMaxExecutionTime, info.Reason)
+	require.Equal(t, 1, info.Iterations)
+	require.GreaterOrEqual(t, info.Elapsed, 10*time.Millisecond)
+}
@@ -49,7 +49,7 @@ func NewOneShotAgent(llm llms.Model, tools []tools.Tool, opts ...Option) *OneSho
 
 	return &OneShotZeroAgent{
 		Chain: chains.NewLLMChain(
-			llm,
+			usageRecordingLLM{llm},
 			options.getMrklPrompt(tools),
 			chains.WithCallback(options.callbacksHandler),
 		),
@@ -64,6 +64,16 @@ func (a *OneShotZeroAgent) Plan(
 	ctx context.Context,
 	intermediateSteps []schema.AgentStep,
 	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	return a.PlanWithOptions(ctx, intermediateSteps, inputs)
+}
+
+// PlanWithOptions is like Plan, using the given call options for the LLM call.
+func (a *OneShotZeroAgent) PlanWithOptions(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+	options ...chains.ChainCallOption,
 ) ([]schema.AgentAction, *schema.AgentFinish, error) {
 	fullInputs := make(map[string]any, len(inputs))
 	for key, value := range inputs {
@@ -73,22 +83,14 @@ func (a *OneShotZeroAgent) Plan(
 	fullInputs["agent_scratchpad"] = constructMrklScratchPad(intermediateSteps)
 	fullInputs["today"] = time.Now().Format("January 02, 2006")
 
-	var stream func(ctx context.Context, chunk []byte) error
-
-	if a.CallbacksHandler != nil {
-		stream = func(ctx context.Context, chunk []byte) error {
-			a.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
-			return nil
-		}
-	}
-
-	output, err := chains.Predict(
-		ctx,
-		a.Chain,
-		fullInputs,
+	callOptions := make([]chains.ChainCallOption, 0, len(options)+2)
+	callOptions = append(callOptions, options...)
+	callOptions = append(callOptions,
 		chains.WithStopWords([]string{"\nObservation:", "\n\tObservation:"}),
-		chains.WithStreamingFunc(stream),
+		chains.WithStreamingFunc(streamingFunc(ctx, a.CallbacksHandler, options)),
 	)
+
+	output, err := chains.Predict(ctx, a.Chain, fullInputs, callOptions...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -57,10 +57,10 @@ func toolNames(tools []tools.Tool) string {
 	return tn.String()
 }
 
-func toolDescriptions(tools []tools.Tool) string {
+func toolDescriptions(agentTools []tools.Tool) string {
 	var ts strings.Builder
-	for _, tool := range tools {
-		ts.WriteString(fmt.Sprintf("- %s: %s\n", tool.Name(), tool.Description()))
+	for _, tool := range agentTools {
+		ts.WriteString(fmt.Sprintf("- %s: %s\n", tool.Name(), tools.DescriptionWithSchema(tool)))
 	}
 
 	return ts.String()
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
 	"github.com/tmc/langchaingo/llms"
 	"github.com/tmc/langchaingo/prompts"
 	"github.com/tmc/langchaingo/schema"
@@ -54,13 +56,7 @@ func (o *OpenAIFunctionsAgent) functions() []llms.FunctionDefinition {
 		res = append(res, llms.FunctionDefinition{
 			Name:        tool.Name(),
 			Description: tool.Description(),
-			Parameters: map[string]any{
-				"properties": map[string]any{
-					"__arg1": map[string]string{"title": "__arg1", "type": "string"},
-				},
-				"required": []string{"__arg1"},
-				"type":     "object",
-			},
+			Parameters:  tools.Parameters(tool),
 		})
 	}
 	return res
@@ -71,6 +67,16 @@ func (o *OpenAIFunctionsAgent) Plan(
 	ctx context.Context,
 	intermediateSteps []schema.AgentStep,
 	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	return o.PlanWithOptions(ctx, intermediateSteps, inputs)
+}
+
+// PlanWithOptions is like Plan, using the given call options for the LLM call.
+func (o *OpenAIFunctionsAgent) PlanWithOptions(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+	options ...chains.ChainCallOption,
 ) ([]schema.AgentAction, *schema.AgentFinish, error) {
 	fullInputs := make(map[string]any, len(inputs))
 	for key, value := range inputs {
@@ -78,15 +84,6 @@ func (o *OpenAIFunctionsAgent) Plan(
 	}
 	fullInputs[agentScratchpad] = o.constructScratchPad(intermediateSteps)
 
-	var stream func(ctx context.Context, chunk []byte) error
-
-	if o.CallbacksHandler != nil {
-		stream = func(ctx context.Context, chunk []byte) error {
-			o.CallbacksHandler.HandleStreamingFunc(ctx, chunk)
-			return nil
-		}
-	}
-
 	prompt, err := o.Prompt.FormatPrompt(fullInputs)
 	if err != nil {
 		return nil, nil, err
@@ -129,8 +126,12 @@ func (o *OpenAIFunctionsAgent) Plan(
 		mcList[i] = mc
 	}
 
-	result, err := o.LLM.GenerateContent(ctx, mcList,
-		llms.WithFunctions(o.functions()), llms.WithStreamingFunc(stream))
+	callOpts := append(chains.GetLLMCallOptions(options...),
+		llms.WithFunctions(o.functions()),
+		llms.WithStreamingFunc(streamingFunc(ctx, o.CallbacksHandler, options)),
+	)
+	result, err := o.LLM.GenerateContent(ctx, mcList, callOpts...)
+	recordUsage(ctx, result)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -180,6 +181,11 @@ func (o *OpenAIFunctionsAgent) constructScratchPad(steps []schema.AgentStep) []l
 
 	messages := make([]llms.ChatMessage, 0)
 	for _, step := range steps {
+		// Steps without a tool, e.g. for parsing errors, are given as text.
+		if step.Action.Tool == "" {
+			messages = append(messages, llms.HumanChatMessage{Content: step.Observation})
+			continue
+		}
 		messages = append(messages, llms.FunctionChatMessage{
 			Name:    step.Action.Tool,
 			Content: step.Observation,
@@ -215,10 +221,10 @@ func (o *OpenAIFunctionsAgent) ParseOutput(contentResp *llms.ContentResponse) (
 	}
 
 	toolInput := toolInputStr
-	if arg1, ok := toolInputMap["__arg1"]; ok {
-		toolInputCheck, ok := arg1.(string)
-		if ok {
-			toolInput = toolInputCheck
+	for _, tool := range o.Tools {
+		if strings.EqualFold(tool.Name(), functionName) {
+			toolInput = tools.InputFromArguments(tool, toolInputStr)
+			break
 		}
 	}
 
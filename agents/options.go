@@ -1,8 +1,11 @@
 package agents
 
 import (
+	"time"
+
 	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/memory"
+	"github.com/tmc/langchaingo/outputparser"
 	"github.com/tmc/langchaingo/prompts"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/tools"
@@ -13,16 +16,31 @@ type Options struct {
 	memory                  schema.Memory
 	callbacksHandler        callbacks.Handler
 	errorHandler            *ParserErrorHandler
+	toolErrorHandler        *ToolErrorHandler
+	toolErrorHandlers       map[string]*ToolErrorHandler
+	approvalPolicy          ApprovalPolicy
+	approvalPolicies        map[string]ApprovalPolicy
+	checkpointer            Checkpointer
 	maxIterations           int
+	maxExecutionTime        time.Duration
+	maxTokens               int
+	earlyStoppingMethod     EarlyStoppingMethod
+	returnStopInfo          bool
+	maxParallelToolCalls    int
 	returnIntermediateSteps bool
 	outputKey               string
 	promptPrefix            string
 	formatInstructions      string
 	promptSuffix            string
 
-	// openai
+	// openai and tool calling
 	systemMessage string
 	extraMessages []prompts.MessageFormatter
+
+	// plan and execute
+	replan       bool
+	replanPrompt prompts.PromptTemplate
+	planParser   schema.OutputParser[[]string]
 }
 
 // Option is a function type that can be used to modify the creation of the agents
@@ -31,9 +49,10 @@ type Option func(*Options)
 
 func executorDefaultOptions() Options {
 	return Options{
-		maxIterations: _defaultMaxIterations,
-		outputKey:     _defaultOutputKey,
-		memory:        memory.NewSimple(),
+		maxIterations:       _defaultMaxIterations,
+		earlyStoppingMethod: EarlyStoppingForce,
+		outputKey:           _defaultOutputKey,
+		memory:              memory.NewSimple(),
 	}
 }
 
@@ -62,6 +81,22 @@ func openAIFunctionsDefaultOptions() Options {
 	}
 }
 
+func toolCallingDefaultOptions() Options {
+	return Options{
+		systemMessage: "You are a helpful AI assistant.",
+		outputKey:     _defaultOutputKey,
+	}
+}
+
+func planAndExecuteDefaultOptions() Options {
+	return Options{
+		maxIterations: _defaultMaxPlanSteps,
+		outputKey:     _defaultOutputKey,
+		memory:        memory.NewSimple(),
+		planParser:    outputparser.NewNumberedList(),
+	}
+}
+
 func (co Options) getMrklPrompt(tools []tools.Tool) prompts.PromptTemplate {
 	if co.prompt.Template != "" {
 		return co.prompt
@@ -96,6 +131,48 @@ func WithMaxIterations(iterations int) Option {
 	}
 }
 
+// WithMaxExecutionTime is an option for setting the max duration of a run of the
+// executor.
+func WithMaxExecutionTime(d time.Duration) Option {
+	return func(co *Options) {
+		co.maxExecutionTime = d
+	}
+}
+
+// WithMaxTokens is an option for setting the max number of tokens used by a run of
+// the executor.
+func WithMaxTokens(tokens int) Option {
+	return func(co *Options) {
+		co.maxTokens = tokens
+	}
+}
+
+// WithEarlyStoppingMethod is an option for setting what the executor does when a run
+// reaches the max iterations, execution time or tokens.
+func WithEarlyStoppingMethod(method EarlyStoppingMethod) Option {
+	return func(co *Options) {
+		co.earlyStoppingMethod = method
+	}
+}
+
+// WithReturnStopInfo is an option for making the executor return a StopInfo explaining
+// why the run stopped.
+func WithReturnStopInfo() Option {
+	return func(co *Options) {
+		co.returnStopInfo = true
+	}
+}
+
+// WithMaxParallelToolCalls is an option for setting the max number of actions from
+// a single plan the executor runs concurrently. By default actions run one at a time.
+// The steps are always recorded in the order the agent returned the actions. Tools
+// and callbacks handlers must be safe for concurrent use when this is enabled.
+func WithMaxParallelToolCalls(n int) Option {
+	return func(co *Options) {
+		co.maxParallelToolCalls = n
+	}
+}
+
 // WithOutputKey is an option for setting the output key of the agent.
 func WithOutputKey(outputKey string) Option {
 	return func(co *Options) {
@@ -161,6 +238,71 @@ func WithParserErrorHandler(errorHandler *ParserErrorHandler) Option {
 	}
 }
 
+// WithToolErrorHandler is an option for setting the handler of the errors returned by
+// the tools of an executor. Without one, a tool error aborts the run.
+func WithToolErrorHandler(errorHandler *ToolErrorHandler) Option {
+	return func(co *Options) {
+		co.toolErrorHandler = errorHandler
+	}
+}
+
+// WithToolErrorHandlerFor is an option for setting the handler of the errors returned by
+// a single tool of an executor, overriding the handler set with WithToolErrorHandler.
+func WithToolErrorHandlerFor(toolName string, errorHandler *ToolErrorHandler) Option {
+	return func(co *Options) {
+		if co.toolErrorHandlers == nil {
+			co.toolErrorHandlers = make(map[string]*ToolErrorHandler)
+		}
+		co.toolErrorHandlers[toolName] = errorHandler
+	}
+}
+
+// WithSystemMessage is an option for setting the system message of the prompt used by
+// the tool calling agent.
+func WithSystemMessage(msg string) Option {
+	return func(co *Options) {
+		co.systemMessage = msg
+	}
+}
+
+// WithExtraMessages is an option for adding messages, e.g. a placeholder for the chat
+// history, between the system message and the input of the prompt used by the tool
+// calling agent.
+func WithExtraMessages(extraMessages []prompts.MessageFormatter) Option {
+	return func(co *Options) {
+		co.extraMessages = extraMessages
+	}
+}
+
+// WithApprovalPolicy is an option for setting the policy deciding which actions of an
+// executor need the approval of a person before being run. When an action needs approval,
+// the executor returns an *Interrupt, and the run is continued with Executor.Resume.
+func WithApprovalPolicy(policy ApprovalPolicy) Option {
+	return func(co *Options) {
+		co.approvalPolicy = policy
+	}
+}
+
+// WithApprovalPolicyFor is an option for setting the approval policy of the actions of a
+// single tool of an executor, overriding the policy set with WithApprovalPolicy.
+func WithApprovalPolicyFor(toolName string, policy ApprovalPolicy) Option {
+	return func(co *Options) {
+		if co.approvalPolicies == nil {
+			co.approvalPolicies = make(map[string]ApprovalPolicy)
+		}
+		co.approvalPolicies[toolName] = policy
+	}
+}
+
+// WithCheckpointer is an option for setting the checkpointer saving the state of the runs
+// of an executor after each step. Only the runs with a thread ID, set on their context
+// with ContextWithThreadID, are saved.
+func WithCheckpointer(checkpointer Checkpointer) Option {
+	return func(co *Options) {
+		co.checkpointer = checkpointer
+	}
+}
+
 type OpenAIOption struct{}
 
 func NewOpenAIOption() OpenAIOption {
@@ -178,3 +320,28 @@ func (o OpenAIOption) WithExtraMessages(extraMessages []prompts.MessageFormatter
 		co.extraMessages = extraMessages
 	}
 }
+
+// WithReplanning is an option making the plan and execute chain update its plan after
+// each step.
+func WithReplanning() Option {
+	return func(co *Options) {
+		co.replan = true
+	}
+}
+
+// WithReplanPrompt is an option for setting the prompt used by the plan and execute
+// chain to update its plan. The prompt receives the "input", "plan" and "past_steps"
+// variables.
+func WithReplanPrompt(prompt prompts.PromptTemplate) Option {
+	return func(co *Options) {
+		co.replanPrompt = prompt
+	}
+}
+
+// WithPlanParser is an option for setting the output parser of the plans of the plan
+// and execute chain. Defaults to a numbered list parser.
+func WithPlanParser(parser schema.OutputParser[[]string]) Option {
+	return func(co *Options) {
+		co.planParser = parser
+	}
+}
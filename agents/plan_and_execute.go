@@ -0,0 +1,276 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+)
+
+const (
+	_defaultMaxPlanSteps = 10
+
+	_planOutputKey      = "plan"
+	_planStepsOutputKey = "steps"
+)
+
+// PlanStep is a step of the plan of a PlanAndExecute chain, with its result.
+type PlanStep struct {
+	Step   string `json:"step"`
+	Result string `json:"result"`
+}
+
+// PlanAndExecute is a chain first asking a planner model for a plan of the
+// task, then executing the steps of the plan one by one with an executor. With
+// replanning, the planner updates the remaining steps after each step, or
+// gives the final answer.
+//
+// Besides the final answer under the output key, the outputs contain the
+// initial plan, as a []string, under the "plan" key and the executed steps, as
+// a []PlanStep, under the "steps" key.
+type PlanAndExecute struct {
+	// Planner is the model making and updating the plan.
+	Planner llms.Model
+	// Executor executes each step of the plan.
+	Executor *Executor
+	// PlanPrompt is the prompt asking for the plan, with the input of the
+	// chain as "input".
+	PlanPrompt prompts.PromptTemplate
+	// ReplanPrompt is the prompt asking for an update of the plan, with
+	// "input", "plan" and "past_steps".
+	ReplanPrompt prompts.PromptTemplate
+	// StepPrompt is the input of the executor for each step, with "input",
+	// "plan", "past_steps" and "step".
+	StepPrompt prompts.PromptTemplate
+	// PlanParser parses the plans of the planner.
+	PlanParser schema.OutputParser[[]string]
+	// Replan makes the planner update the plan after each step.
+	Replan bool
+	// MaxSteps is the maximum number of steps executed.
+	MaxSteps int
+
+	Memory           schema.Memory
+	CallbacksHandler callbacks.Handler
+	InputKey         string
+	OutputKey        string
+}
+
+var (
+	_ chains.Chain           = &PlanAndExecute{}
+	_ callbacks.HandlerHaver = &PlanAndExecute{}
+)
+
+// NewPlanAndExecute creates a new plan and execute chain, planning with the
+// planner model and executing the steps with the executor.
+func NewPlanAndExecute(planner llms.Model, executor *Executor, opts ...Option) *PlanAndExecute {
+	options := planAndExecuteDefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	planPrompt := options.prompt
+	if planPrompt.Template == "" {
+		planPrompt = createPlannerPrompt()
+	}
+	replanPrompt := options.replanPrompt
+	if replanPrompt.Template == "" {
+		replanPrompt = createReplannerPrompt()
+	}
+
+	return &PlanAndExecute{
+		Planner:          planner,
+		Executor:         executor,
+		PlanPrompt:       planPrompt,
+		ReplanPrompt:     replanPrompt,
+		StepPrompt:       createPlanStepPrompt(),
+		PlanParser:       options.planParser,
+		Replan:           options.replan,
+		MaxSteps:         options.maxIterations,
+		Memory:           options.memory,
+		CallbacksHandler: options.callbacksHandler,
+		InputKey:         "input",
+		OutputKey:        options.outputKey,
+	}
+}
+
+// Call plans the task given as input, then executes the plan.
+func (p *PlanAndExecute) Call(
+	ctx context.Context,
+	inputValues map[string]any,
+	options ...chains.ChainCallOption,
+) (map[string]any, error) {
+	input, ok := inputValues[p.InputKey].(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrExecutorInputNotString, p.InputKey)
+	}
+
+	plan, err := p.plan(ctx, p.PlanPrompt, map[string]any{"input": input}, options)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan) == 0 {
+		return nil, fmt.Errorf("%w: the plan has no steps", ErrUnableToParseOutput)
+	}
+	initialPlan := plan
+
+	steps := make([]PlanStep, 0, len(plan))
+	outputs := func(answer string) map[string]any {
+		return map[string]any{
+			p.OutputKey:         answer,
+			_planOutputKey:      initialPlan,
+			_planStepsOutputKey: steps,
+		}
+	}
+
+	for len(plan) > 0 {
+		if len(steps) >= p.MaxSteps {
+			return outputs(""), ErrNotFinished
+		}
+
+		result, err := p.executeStep(ctx, input, plan, steps, options)
+		if err != nil {
+			return nil, err
+		}
+		steps = append(steps, PlanStep{Step: plan[0], Result: result})
+		plan = plan[1:]
+
+		if !p.Replan {
+			continue
+		}
+		answer, newPlan, err := p.replan(ctx, input, initialPlan, steps, options)
+		if err != nil {
+			return nil, err
+		}
+		if newPlan == nil {
+			return outputs(answer), nil
+		}
+		plan = newPlan
+	}
+
+	return outputs(steps[len(steps)-1].Result), nil
+}
+
+// plan asks the planner for a plan with the given prompt.
+func (p *PlanAndExecute) plan(
+	ctx context.Context,
+	prompt prompts.PromptTemplate,
+	values map[string]any,
+	options []chains.ChainCallOption,
+) ([]string, error) {
+	text, err := p.generate(ctx, prompt, values, options)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := p.PlanParser.Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrUnableToParseOutput, err)
+	}
+	return plan, nil
+}
+
+// replan asks the planner for the remaining steps of the plan. It returns the
+// final answer and a nil plan if the planner gives one.
+func (p *PlanAndExecute) replan(
+	ctx context.Context,
+	input string,
+	plan []string,
+	steps []PlanStep,
+	options []chains.ChainCallOption,
+) (string, []string, error) {
+	values := map[string]any{
+		"input":      input,
+		"plan":       formatPlan(plan),
+		"past_steps": formatPlanSteps(steps),
+	}
+	text, err := p.generate(ctx, p.ReplanPrompt, values, options)
+	if err != nil {
+		return "", nil, err
+	}
+	if _, answer, ok := strings.Cut(text, _finalAnswerAction); ok {
+		return strings.TrimSpace(answer), nil, nil
+	}
+
+	newPlan, err := p.PlanParser.Parse(text)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrUnableToParseOutput, err)
+	}
+	return "", newPlan, nil
+}
+
+func (p *PlanAndExecute) generate(
+	ctx context.Context,
+	prompt prompts.PromptTemplate,
+	values map[string]any,
+	options []chains.ChainCallOption,
+) (string, error) {
+	text, err := prompt.Format(values)
+	if err != nil {
+		return "", err
+	}
+	return llms.GenerateFromSinglePrompt(ctx, p.Planner, text, chains.GetLLMCallOptions(options...)...)
+}
+
+// executeStep runs the executor on the first step of the plan.
+func (p *PlanAndExecute) executeStep(
+	ctx context.Context,
+	input string,
+	plan []string,
+	steps []PlanStep,
+	options []chains.ChainCallOption,
+) (string, error) {
+	stepInput, err := p.StepPrompt.Format(map[string]any{
+		"input":      input,
+		"plan":       formatPlan(plan),
+		"past_steps": formatPlanSteps(steps),
+		"step":       plan[0],
+	})
+	if err != nil {
+		return "", err
+	}
+
+	result, err := chains.Run(ctx, p.Executor, stepInput, options...)
+	if err != nil {
+		return "", fmt.Errorf("executing step %q: %w", plan[0], err)
+	}
+	return result, nil
+}
+
+func formatPlan(plan []string) string {
+	var sb strings.Builder
+	for i, step := range plan {
+		fmt.Fprintf(&sb, "%d. %s\n", i+1, step)
+	}
+	return sb.String()
+}
+
+func formatPlanSteps(steps []PlanStep) string {
+	var sb strings.Builder
+	for _, step := range steps {
+		fmt.Fprintf(&sb, "Step: %s\nResult: %s\n\n", step.Step, step.Result)
+	}
+	return sb.String()
+}
+
+// GetInputKeys returns the input key of the chain, "input".
+func (p *PlanAndExecute) GetInputKeys() []string {
+	return []string{p.InputKey}
+}
+
+// GetOutputKeys returns the key of the final answer. The plan and its steps
+// are returned too, see PlanAndExecute.
+func (p *PlanAndExecute) GetOutputKeys() []string {
+	return []string{p.OutputKey}
+}
+
+func (p *PlanAndExecute) GetMemory() schema.Memory { //nolint:ireturn
+	return p.Memory
+}
+
+func (p *PlanAndExecute) GetCallbackHandler() callbacks.Handler { //nolint:ireturn
+	return p.CallbacksHandler
+}
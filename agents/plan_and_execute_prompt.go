@@ -0,0 +1,49 @@
+package agents
+
+import "github.com/tmc/langchaingo/prompts"
+
+const (
+	_defaultPlannerTemplate = `Let's first understand the problem and devise a plan to solve the problem.
+Please output the plan starting with the header "Plan:" and then followed by a numbered list of steps.
+Please make the plan the minimum number of steps required to accurately complete the task.
+If the task is a question, the final step should almost always be "Given the above steps taken, please respond to the user's original question".
+
+Task: {{.input}}`
+
+	_defaultReplannerTemplate = `For the given objective, come up with a simple step by step plan.
+This plan should involve individual tasks, that if executed correctly will yield the correct answer. Do not add any superfluous steps.
+
+Your objective was this:
+{{.input}}
+
+Your original plan was this:
+{{.plan}}
+
+You have currently done the following steps:
+{{.past_steps}}
+
+Update your plan accordingly. If no more steps are needed and you can respond to the user, respond with "Final Answer:" followed by the response.
+Otherwise, respond with a numbered list of the steps that still need to be done. Do not return previously done steps as part of the plan.`
+
+	_defaultPlanStepTemplate = `Objective: {{.input}}
+
+Plan:
+{{.plan}}
+
+{{if .past_steps}}Steps already done, with their results:
+{{.past_steps}}
+
+{{end}}Your task is to do the following step of the plan: {{.step}}`
+)
+
+func createPlannerPrompt() prompts.PromptTemplate {
+	return prompts.NewPromptTemplate(_defaultPlannerTemplate, []string{"input"})
+}
+
+func createReplannerPrompt() prompts.PromptTemplate {
+	return prompts.NewPromptTemplate(_defaultReplannerTemplate, []string{"input", "plan", "past_steps"})
+}
+
+func createPlanStepPrompt() prompts.PromptTemplate {
+	return prompts.NewPromptTemplate(_defaultPlanStepTemplate, []string{"input", "plan", "past_steps", "step"})
+}
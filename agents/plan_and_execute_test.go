@@ -0,0 +1,112 @@
+package agents_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// stepAgent finishes immediately, answering the step it is given.
+type stepAgent struct{}
+
+func (stepAgent) Plan(
+	_ context.Context,
+	_ []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	_, step, _ := strings.Cut(inputs["input"], "do the following step of the plan: ")
+	return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": "done " + step}}, nil
+}
+
+func (stepAgent) GetInputKeys() []string  { return []string{"input"} }
+func (stepAgent) GetOutputKeys() []string { return []string{"output"} }
+func (stepAgent) GetTools() []tools.Tool  { return nil }
+
+func textResponse(text string) *llms.ContentResponse {
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: text}}}
+}
+
+func TestPlanAndExecute(t *testing.T) {
+	t.Parallel()
+
+	planner := &toolCallingLLM{responses: []*llms.ContentResponse{
+		textResponse("Plan:\n1. find the capital\n2. answer the question"),
+	}}
+	chain := agents.NewPlanAndExecute(planner, agents.NewExecutor(stepAgent{}))
+
+	outputs, err := chains.Call(context.Background(), chain, map[string]any{"input": "capital of France?"})
+	require.NoError(t, err)
+	require.Equal(t, "done answer the question", outputs["output"])
+	require.Equal(t, []string{"find the capital", "answer the question"}, outputs["plan"])
+	require.Equal(t, []agents.PlanStep{
+		{Step: "find the capital", Result: "done find the capital"},
+		{Step: "answer the question", Result: "done answer the question"},
+	}, outputs["steps"])
+}
+
+func TestPlanAndExecuteReplanning(t *testing.T) {
+	t.Parallel()
+
+	planner := &toolCallingLLM{responses: []*llms.ContentResponse{
+		textResponse("1. search\n2. compute\n3. answer"),
+		textResponse("1. compute differently\n2. answer"),
+		textResponse("Final Answer: 42"),
+	}}
+	chain := agents.NewPlanAndExecute(planner, agents.NewExecutor(stepAgent{}), agents.WithReplanning())
+
+	answer, err := chains.Run(context.Background(), chain, "what is the answer?")
+	require.NoError(t, err)
+	require.Equal(t, "42", answer)
+
+	// The second replan knows the steps done so far.
+	replan := planner.calls[2][0].Parts[0].(llms.TextContent).Text
+	require.Contains(t, replan, "Step: search\nResult: done search")
+	require.Contains(t, replan, "Step: compute differently\nResult: done compute differently")
+}
+
+func TestPlanAndExecuteMaxSteps(t *testing.T) {
+	t.Parallel()
+
+	planner := &toolCallingLLM{responses: []*llms.ContentResponse{
+		textResponse("1. a\n2. b\n3. c"),
+	}}
+	chain := agents.NewPlanAndExecute(planner, agents.NewExecutor(stepAgent{}), agents.WithMaxIterations(2))
+
+	outputs, err := chain.Call(context.Background(), map[string]any{"input": "abc"})
+	require.ErrorIs(t, err, agents.ErrNotFinished)
+	require.Len(t, outputs["steps"], 2)
+}
+
+// fieldsParser parses a plan with a step per word, returning an empty plan for
+// an empty text.
+type fieldsParser struct{}
+
+func (fieldsParser) Parse(text string) ([]string, error) {
+	return strings.Fields(text), nil
+}
+
+func (p fieldsParser) ParseWithPrompt(text string, _ llms.PromptValue) ([]string, error) {
+	return p.Parse(text)
+}
+
+func (fieldsParser) GetFormatInstructions() string { return "" }
+func (fieldsParser) Type() string                  { return "fields" }
+
+func TestPlanAndExecuteEmptyPlan(t *testing.T) {
+	t.Parallel()
+
+	planner := &toolCallingLLM{responses: []*llms.ContentResponse{
+		textResponse(""),
+	}}
+	chain := agents.NewPlanAndExecute(planner, agents.NewExecutor(stepAgent{}), agents.WithPlanParser(fieldsParser{}))
+
+	_, err := chains.Run(context.Background(), chain, "nothing to do")
+	require.ErrorIs(t, err, agents.ErrUnableToParseOutput)
+}
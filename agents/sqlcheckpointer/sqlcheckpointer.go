@@ -0,0 +1,197 @@
+// Package sqlcheckpointer provides an agents.Checkpointer saving checkpoints
+// in a SQL database through database/sql.
+//
+// Checkpoints are stored as JSON in a single table, created if it does not
+// exist. The SQL is portable, and has been used with SQLite, PostgreSQL and
+// MySQL; PostgreSQL requires the WithDollarPlaceholders option.
+package sqlcheckpointer
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tmc/langchaingo/agents"
+)
+
+// DefaultTableName is the default name of the checkpoints table.
+const DefaultTableName = "langchaingo_checkpoints"
+
+const schema = `CREATE TABLE IF NOT EXISTS %s (
+	thread_id VARCHAR(255) NOT NULL,
+	id INTEGER NOT NULL,
+	parent_id INTEGER NOT NULL,
+	data TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	PRIMARY KEY (thread_id, id)
+)`
+
+// Checkpointer is an agents.Checkpointer saving checkpoints in a SQL table.
+type Checkpointer struct {
+	db                 *sql.DB
+	tableName          string
+	dollarPlaceholders bool
+}
+
+var _ agents.Checkpointer = (*Checkpointer)(nil)
+
+// Option is a function for configuring a Checkpointer.
+type Option func(*Checkpointer)
+
+// WithTableName sets the name of the checkpoints table.
+func WithTableName(tableName string) Option {
+	return func(c *Checkpointer) {
+		c.tableName = tableName
+	}
+}
+
+// WithDollarPlaceholders makes the queries use $1, $2... placeholders, as
+// required by PostgreSQL, instead of ?.
+func WithDollarPlaceholders() Option {
+	return func(c *Checkpointer) {
+		c.dollarPlaceholders = true
+	}
+}
+
+// New creates a new Checkpointer, creating the checkpoints table if needed.
+func New(ctx context.Context, db *sql.DB, opts ...Option) (*Checkpointer, error) {
+	c := &Checkpointer{
+		db:        db,
+		tableName: DefaultTableName,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(schema, c.tableName)); err != nil {
+		return nil, fmt.Errorf("creating checkpoints table: %w", err)
+	}
+	return c, nil
+}
+
+// Put saves a checkpoint. Concurrent writers to the same thread may fail on
+// the primary key of the table, but never overwrite each other.
+func (c *Checkpointer) Put(ctx context.Context, checkpoint *agents.Checkpoint) error {
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	var lastID int
+	err = tx.QueryRowContext(ctx,
+		c.query("SELECT COALESCE(MAX(id), 0) FROM %s WHERE thread_id = ?"),
+		checkpoint.ThreadID,
+	).Scan(&lastID)
+	if err != nil {
+		return err
+	}
+
+	checkpoint.ID = lastID + 1
+	checkpoint.CreatedAt = time.Now().UTC()
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		c.query("INSERT INTO %s (thread_id, id, parent_id, data, created_at) VALUES (?, ?, ?, ?, ?)"),
+		checkpoint.ThreadID, checkpoint.ID, checkpoint.ParentID, string(data), checkpoint.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Get returns a checkpoint of a thread.
+func (c *Checkpointer) Get(ctx context.Context, threadID string, id int) (*agents.Checkpoint, error) {
+	var data string
+	err := c.db.QueryRowContext(ctx,
+		c.query("SELECT data FROM %s WHERE thread_id = ? AND id = ?"),
+		threadID, id,
+	).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, agents.ErrCheckpointNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var checkpoint agents.Checkpoint
+	if err := json.Unmarshal([]byte(data), &checkpoint); err != nil {
+		return nil, fmt.Errorf("reading checkpoint %d of thread %s: %w", id, threadID, err)
+	}
+	return &checkpoint, nil
+}
+
+// List returns the checkpoints of a thread, oldest first.
+func (c *Checkpointer) List(ctx context.Context, threadID string) ([]agents.Checkpoint, error) {
+	rows, err := c.db.QueryContext(ctx,
+		c.query("SELECT data FROM %s WHERE thread_id = ? ORDER BY id"),
+		threadID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var checkpoints []agents.Checkpoint
+	for rows.Next() {
+		var data string
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		var checkpoint agents.Checkpoint
+		if err := json.Unmarshal([]byte(data), &checkpoint); err != nil {
+			return nil, fmt.Errorf("reading checkpoint of thread %s: %w", threadID, err)
+		}
+		checkpoints = append(checkpoints, checkpoint)
+	}
+	return checkpoints, rows.Err()
+}
+
+// Threads returns the IDs of the threads with checkpoints, sorted.
+func (c *Checkpointer) Threads(ctx context.Context) ([]string, error) {
+	rows, err := c.db.QueryContext(ctx, c.query("SELECT DISTINCT thread_id FROM %s ORDER BY thread_id"))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var threadIDs []string
+	for rows.Next() {
+		var threadID string
+		if err := rows.Scan(&threadID); err != nil {
+			return nil, err
+		}
+		threadIDs = append(threadIDs, threadID)
+	}
+	return threadIDs, rows.Err()
+}
+
+// query formats a query with the table name, and replaces its placeholders
+// if needed.
+func (c *Checkpointer) query(format string) string {
+	q := fmt.Sprintf(format, c.tableName)
+	if !c.dollarPlaceholders {
+		return q
+	}
+
+	var sb strings.Builder
+	n := 0
+	for _, r := range q {
+		if r == '?' {
+			n++
+			fmt.Fprintf(&sb, "$%d", n)
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
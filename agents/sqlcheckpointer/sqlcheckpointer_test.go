@@ -0,0 +1,64 @@
+package sqlcheckpointer_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3" // sqlite3 driver.
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/agents/sqlcheckpointer"
+	"github.com/tmc/langchaingo/schema"
+)
+
+func TestCheckpointer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", ":memory:")
+	require.NoError(t, err)
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	c, err := sqlcheckpointer.New(ctx, db, sqlcheckpointer.WithTableName("checkpoints"))
+	require.NoError(t, err)
+
+	first := &agents.Checkpoint{ThreadID: "alice", Inputs: map[string]string{"input": "hi"}}
+	require.NoError(t, c.Put(ctx, first))
+	assert.Equal(t, 1, first.ID)
+
+	second := &agents.Checkpoint{
+		ThreadID:  "alice",
+		ParentID:  first.ID,
+		Steps:     []schema.AgentStep{{Action: schema.AgentAction{Tool: "search"}, Observation: "found"}},
+		Iteration: 1,
+		Outputs:   map[string]any{"output": "done"},
+	}
+	require.NoError(t, c.Put(ctx, second))
+	assert.Equal(t, 2, second.ID)
+	require.NoError(t, c.Put(ctx, &agents.Checkpoint{ThreadID: "bob"}))
+
+	// Creating a checkpointer again keeps the existing table.
+	c, err = sqlcheckpointer.New(ctx, db, sqlcheckpointer.WithTableName("checkpoints"))
+	require.NoError(t, err)
+
+	got, err := c.Get(ctx, "alice", 2)
+	require.NoError(t, err)
+	assert.Equal(t, second.Steps, got.Steps)
+	assert.True(t, got.Finished())
+
+	checkpoints, err := c.List(ctx, "alice")
+	require.NoError(t, err)
+	require.Len(t, checkpoints, 2)
+	assert.Equal(t, "hi", checkpoints[0].Inputs["input"])
+	assert.False(t, checkpoints[0].Finished())
+
+	threads, err := c.Threads(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, threads)
+
+	_, err = c.Get(ctx, "alice", 3)
+	require.ErrorIs(t, err, agents.ErrCheckpointNotFound)
+}
@@ -0,0 +1,156 @@
+package agents
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// EventType is the type of an event of a streamed run.
+type EventType string
+
+const (
+	// EventPlanStarted is sent when the agent starts planning the next step.
+	EventPlanStarted EventType = "plan_started"
+	// EventToken is sent for each chunk of text streamed by the model while
+	// planning. Only the agents of this package stream tokens.
+	EventToken EventType = "token"
+	// EventToolCall is sent when a tool is about to be called.
+	EventToolCall EventType = "tool_call"
+	// EventToolResult is sent when a tool call returns.
+	EventToolResult EventType = "tool_result"
+	// EventStepCompleted is sent when all the actions of a plan have run.
+	EventStepCompleted EventType = "step_completed"
+	// EventFinalAnswer is sent once with the outputs of a successful run. It
+	// is the last event of the run.
+	EventFinalAnswer EventType = "final_answer"
+	// EventError is sent once if the run fails, e.g. with an *Interrupt if
+	// actions need approval. It is the last event of the run.
+	EventError EventType = "error"
+)
+
+// Event is an event of a run streamed with Executor.Stream.
+type Event struct {
+	Type EventType
+	// Iteration is the iteration of the run the event belongs to.
+	Iteration int
+	// Token is the chunk of text of an EventToken.
+	Token string
+	// Action is the action of an EventToolCall or EventToolResult.
+	Action *schema.AgentAction
+	// Steps are the step of an EventToolResult, or the steps of the plan for
+	// an EventStepCompleted.
+	Steps []schema.AgentStep
+	// Outputs are the outputs of an EventFinalAnswer.
+	Outputs map[string]any
+	// Err is the error of an EventError.
+	Err error
+}
+
+// Stream runs the agent like chains.Call, sending the events of the run to the
+// returned channel as they happen. The channel is closed after the final
+// answer or error event. The caller must read the channel until it is closed,
+// or cancel the context.
+func (e *Executor) Stream(ctx context.Context, inputValues map[string]any, options ...chains.ChainCallOption) <-chan Event { //nolint:lll
+	events := make(chan Event)
+	go func() {
+		defer close(events)
+
+		s := &eventSink{ctx: ctx, events: events}
+		outputs, err := chains.Call(context.WithValue(ctx, eventSinkKey{}, s), e, inputValues, options...)
+		if err != nil {
+			s.send(Event{Type: EventError, Err: err})
+			return
+		}
+		s.send(Event{Type: EventFinalAnswer, Outputs: outputs})
+	}()
+	return events
+}
+
+type eventSinkKey struct{}
+
+// eventSink sends the events of a streamed run.
+type eventSink struct {
+	ctx    context.Context //nolint:containedctx
+	events chan<- Event
+}
+
+func (s *eventSink) send(event Event) {
+	select {
+	case s.events <- event:
+	case <-s.ctx.Done():
+	}
+}
+
+// sinkFromContext returns the sink of the streamed run of the context, or nil.
+func sinkFromContext(ctx context.Context) *eventSink {
+	s, _ := ctx.Value(eventSinkKey{}).(*eventSink)
+	return s
+}
+
+// withoutEvents returns a context for nested runs, e.g. of workers, whose
+// events are not sent to the streamed run of the context.
+func withoutEvents(ctx context.Context) context.Context {
+	if sinkFromContext(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, eventSinkKey{}, (*eventSink)(nil))
+}
+
+// emit sends an event if the run is streamed.
+func emit(ctx context.Context, event Event) {
+	if s := sinkFromContext(ctx); s != nil {
+		event.Iteration, _ = ctx.Value(iterationKey{}).(int)
+		s.send(event)
+	}
+}
+
+type iterationKey struct{}
+
+// withIteration returns a context giving the iteration of the run to the
+// events sent with it.
+func withIteration(ctx context.Context, iteration int) context.Context {
+	if sinkFromContext(ctx) == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, iterationKey{}, iteration)
+}
+
+// streamingFunc returns the streaming function used by the agents of this
+// package while planning, passing the chunks to the callbacks handler, to the
+// streaming function or callback handler of the call options and to the
+// streamed run, or nil if there are none.
+func streamingFunc(
+	ctx context.Context,
+	handler callbacks.Handler,
+	options []chains.ChainCallOption,
+) func(ctx context.Context, chunk []byte) error {
+	var opts llms.CallOptions
+	for _, opt := range chains.GetLLMCallOptions(options...) {
+		opt(&opts)
+	}
+	callStream := opts.StreamingFunc
+
+	s := sinkFromContext(ctx)
+	streamed := s != nil
+	if handler == nil && callStream == nil && !streamed {
+		return nil
+	}
+	iteration, _ := ctx.Value(iterationKey{}).(int)
+
+	return func(ctx context.Context, chunk []byte) error {
+		if handler != nil {
+			handler.HandleStreamingFunc(ctx, chunk)
+		}
+		if streamed {
+			s.send(Event{Type: EventToken, Iteration: iteration, Token: string(chunk)})
+		}
+		if callStream != nil {
+			return callStream(ctx, chunk)
+		}
+		return nil
+	}
+}
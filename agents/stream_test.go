@@ -0,0 +1,75 @@
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/tools"
+)
+
+func TestExecutorStream(t *testing.T) {
+	t.Parallel()
+
+	llm := &toolCallingLLM{
+		responses: []*llms.ContentResponse{
+			{Choices: []*llms.ContentChoice{
+				{Content: "Let me check."},
+				{ToolCalls: []llms.ToolCall{toolCall("call_1", "weather", `{"__arg1":"Paris"}`)}},
+			}},
+			{Choices: []*llms.ContentChoice{{Content: "It is sunny."}}},
+		},
+	}
+	weather := funcTool{name: "weather", fn: func(_ context.Context, input string) (string, error) {
+		return "sunny in " + input, nil
+	}}
+	executor := agents.NewExecutor(agents.NewToolCallingAgent(llm, []tools.Tool{weather}))
+
+	var events []agents.Event
+	for event := range executor.Stream(context.Background(), map[string]any{"input": "weather in Paris?"}) {
+		events = append(events, event)
+	}
+
+	types := make([]agents.EventType, len(events))
+	for i, event := range events {
+		types[i] = event.Type
+	}
+	require.Equal(t, []agents.EventType{
+		agents.EventPlanStarted,
+		agents.EventToken,
+		agents.EventToolCall,
+		agents.EventToolResult,
+		agents.EventStepCompleted,
+		agents.EventPlanStarted,
+		agents.EventToken,
+		agents.EventFinalAnswer,
+	}, types)
+
+	require.Equal(t, "Let me check.", events[1].Token)
+	require.Equal(t, "Paris", events[2].Action.ToolInput)
+	require.Equal(t, "sunny in Paris", events[3].Steps[0].Observation)
+	require.Len(t, events[4].Steps, 1)
+	require.Equal(t, 1, events[4].Iteration)
+	require.Equal(t, 2, events[6].Iteration)
+	require.Equal(t, "It is sunny.", events[7].Outputs["output"])
+}
+
+func TestExecutorStreamError(t *testing.T) {
+	t.Parallel()
+
+	search := funcTool{name: "search", fn: func(_ context.Context, input string) (string, error) {
+		return "found " + input, nil
+	}}
+	executor := agents.NewExecutor(&stepsAgent{tools: []tools.Tool{search}},
+		agents.WithApprovalPolicy(agents.RequireApproval),
+	)
+
+	var last agents.Event
+	for event := range executor.Stream(context.Background(), map[string]any{"input": "search"}) {
+		last = event
+	}
+	require.Equal(t, agents.EventError, last.Type)
+	require.ErrorIs(t, last.Err, agents.ErrInterrupted)
+}
@@ -0,0 +1,150 @@
+package agents
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/tools"
+	"github.com/tmc/langchaingo/tools/chaintool"
+)
+
+// ErrInvalidWorker is returned when creating a supervisor with an invalid worker.
+var ErrInvalidWorker = errors.New("invalid worker")
+
+const _defaultSupervisorSystemMessage = `You are a supervisor managing a team of workers: {{.workers}}.
+Break the request of the user into subtasks, and delegate each subtask to the most appropriate worker.
+The workers do not see the conversation: give them a precise task, and in the context the relevant parts of the conversation and the results of other workers they need.
+Once the workers have answered, combine their answers into a single final response to the user.
+Answer directly when no worker is needed.`
+
+// Worker is an agent, or any chain, a supervisor can delegate subtasks to.
+type Worker struct {
+	// Name is the name of the tool the supervisor calls the worker with.
+	Name string
+	// Description tells the supervisor what the worker is useful for.
+	Description string
+	// Chain runs the subtasks given to the worker. It must have a single input
+	// and a single output, like an *Executor.
+	Chain chains.Chain
+	// MaxIterations overrides the max iterations of the worker if it is an
+	// *Executor. Zero keeps the max iterations of the executor.
+	MaxIterations int
+}
+
+// NewSupervisor creates a tool calling agent delegating subtasks to workers,
+// each being a tool of the agent. The supervisor gives each worker a task and
+// the relevant history, and combines their answers. A worker not finishing
+// within its max iterations is reported to the supervisor as an observation,
+// not as an error. The events of the runs of the workers are not streamed with
+// Executor.Stream, but their tokens count in the tokens of the supervisor.
+//
+// The system message, set with WithSystemMessage, can use the names of the
+// workers as {{.workers}}.
+func NewSupervisor(llm llms.Model, workers []Worker, opts ...Option) (*ToolCallingAgent, error) {
+	workerTools := make([]tools.Tool, 0, len(workers))
+	names := make([]string, 0, len(workers))
+	for _, worker := range workers {
+		if worker.Name == "" || worker.Chain == nil {
+			return nil, fmt.Errorf("%w: %q has no name or chain", ErrInvalidWorker, worker.Name)
+		}
+		for _, name := range names {
+			if strings.EqualFold(name, worker.Name) {
+				return nil, fmt.Errorf("%w: duplicate name %s", ErrInvalidWorker, worker.Name)
+			}
+		}
+		names = append(names, worker.Name)
+		workerTools = append(workerTools, newWorkerTool(worker))
+	}
+
+	options := supervisorDefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	systemMessage := strings.ReplaceAll(options.systemMessage, "{{.workers}}", strings.Join(names, ", "))
+
+	return NewToolCallingAgent(llm, workerTools, append(slices.Clip(opts), WithSystemMessage(systemMessage))...), nil
+}
+
+func supervisorDefaultOptions() Options {
+	options := toolCallingDefaultOptions()
+	options.systemMessage = _defaultSupervisorSystemMessage
+	return options
+}
+
+// workerTool is the tool a supervisor calls a worker with.
+type workerTool struct {
+	name        string
+	description string
+	tool        *chaintool.Tool
+}
+
+var _ tools.StructuredTool = workerTool{}
+
+// workerArguments are the arguments of a call to a worker.
+type workerArguments struct {
+	Task    string `json:"task"`
+	Context string `json:"context,omitempty"`
+}
+
+func newWorkerTool(worker Worker) workerTool {
+	chain := worker.Chain
+	if executor, ok := chain.(*Executor); ok && worker.MaxIterations > 0 {
+		limited := *executor
+		limited.MaxIterations = worker.MaxIterations
+		chain = &limited
+	}
+
+	return workerTool{
+		name:        worker.Name,
+		description: worker.Description,
+		tool:        chaintool.New(worker.Name, worker.Description, chain),
+	}
+}
+
+func (t workerTool) Name() string        { return t.name }
+func (t workerTool) Description() string { return t.description }
+
+func (t workerTool) Parameters() jsonschema.Definition {
+	return jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"task": {
+				Type:        jsonschema.String,
+				Description: "The subtask for the worker, with everything it needs to know to do it.",
+			},
+			"context": {
+				Type: jsonschema.String,
+				Description: "The parts of the conversation and the results of other workers " +
+					"relevant to the subtask.",
+			},
+		},
+		Required: []string{"task"},
+	}
+}
+
+// Call runs the worker on the task of the arguments, given as JSON.
+func (t workerTool) Call(ctx context.Context, input string) (string, error) {
+	var args workerArguments
+	if err := json.Unmarshal([]byte(input), &args); err != nil || args.Task == "" {
+		// Not a structured call, e.g. from a text agent: the input is the task.
+		args = workerArguments{Task: input}
+	}
+
+	workerInput := args.Task
+	if args.Context != "" {
+		workerInput = fmt.Sprintf("%s\n\nContext:\n%s", args.Task, args.Context)
+	}
+
+	result, err := t.tool.Call(withoutEvents(ctx), workerInput)
+	if errors.Is(err, ErrNotFinished) {
+		return fmt.Sprintf("The worker %s did not finish the task within its limits.", t.name), nil
+	}
+	return result, err
+}
@@ -0,0 +1,94 @@
+package agents_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// echoAgent finishes immediately, answering its input.
+type echoAgent struct{}
+
+func (echoAgent) Plan(
+	_ context.Context,
+	_ []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	return nil, &schema.AgentFinish{ReturnValues: map[string]any{"output": inputs["input"]}}, nil
+}
+
+func (echoAgent) GetInputKeys() []string  { return []string{"input"} }
+func (echoAgent) GetOutputKeys() []string { return []string{"output"} }
+func (echoAgent) GetTools() []tools.Tool  { return nil }
+
+func TestSupervisor(t *testing.T) {
+	t.Parallel()
+
+	search := funcTool{name: "search", fn: func(_ context.Context, input string) (string, error) {
+		return "nothing about " + input, nil
+	}}
+	// The researcher keeps searching, and is stopped by its iteration limit.
+	researcher := agents.NewExecutor(&scriptedAgent{
+		plans: [][]schema.AgentAction{
+			{{Tool: "search", ToolInput: "cats"}},
+			{{Tool: "search", ToolInput: "more cats"}},
+		},
+		tools: []tools.Tool{search},
+	})
+
+	llm := &toolCallingLLM{responses: []*llms.ContentResponse{
+		{Choices: []*llms.ContentChoice{{ToolCalls: []llms.ToolCall{
+			toolCall("call_1", "writer", `{"task":"write a poem","context":"The user likes cats."}`),
+			toolCall("call_2", "researcher", `{"task":"find facts about cats"}`),
+		}}}},
+		textResponse("Here is your poem."),
+	}}
+	supervisor, err := agents.NewSupervisor(llm, []agents.Worker{
+		{Name: "writer", Description: "Writes texts.", Chain: agents.NewExecutor(echoAgent{})},
+		{Name: "researcher", Description: "Searches facts.", Chain: researcher, MaxIterations: 1},
+	})
+	require.NoError(t, err)
+
+	result, err := chains.Run(context.Background(), agents.NewExecutor(supervisor), "a poem about cats")
+	require.NoError(t, err)
+	require.Equal(t, "Here is your poem.", result)
+
+	system, ok := llm.calls[0][0].Parts[0].(llms.TextContent)
+	require.True(t, ok)
+	require.Contains(t, system.Text, "writer, researcher")
+
+	var observations []string
+	for _, message := range llm.calls[1] {
+		for _, part := range message.Parts {
+			if response, ok := part.(llms.ToolCallResponse); ok {
+				observations = append(observations, response.Content)
+			}
+		}
+	}
+	require.Equal(t, []string{
+		"write a poem\n\nContext:\nThe user likes cats.",
+		"The worker researcher did not finish the task within its limits.",
+	}, observations)
+	// The max iterations are set on a copy of the executor of the worker.
+	require.Equal(t, 5, researcher.MaxIterations)
+}
+
+func TestSupervisorInvalidWorkers(t *testing.T) {
+	t.Parallel()
+
+	worker := agents.NewExecutor(echoAgent{})
+	_, err := agents.NewSupervisor(&toolCallingLLM{}, []agents.Worker{
+		{Name: "writer", Chain: worker},
+		{Name: "Writer", Chain: worker},
+	})
+	require.ErrorIs(t, err, agents.ErrInvalidWorker)
+
+	_, err = agents.NewSupervisor(&toolCallingLLM{}, []agents.Worker{{Name: "writer"}})
+	require.ErrorIs(t, err, agents.ErrInvalidWorker)
+}
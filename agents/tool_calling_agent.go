@@ -0,0 +1,309 @@
+package agents
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// ToolCallingAgent is an Agent driven by the native tool calling API of the
+// llm. Unlike the OpenAIFunctionsAgent it works with every provider supporting
+// llms.WithTools, e.g. anthropic, googleai, mistral and openai, and it returns
+// an action for each of the tool calls of a turn.
+type ToolCallingAgent struct {
+	// LLM is the llm used to call with the values. It must support tool calls.
+	LLM llms.Model
+	// Prompt is the prompt formatted with the inputs. The tool calls and their
+	// results are appended to the messages of the prompt.
+	Prompt prompts.FormatPrompter
+	// Tools is a list of the tools the agent can use.
+	Tools []tools.Tool
+	// Output key is the key where the final output is placed.
+	OutputKey string
+	// CallbacksHandler is the handler for callbacks.
+	CallbacksHandler callbacks.Handler
+}
+
+var _ Agent = (*ToolCallingAgent)(nil)
+
+// NewToolCallingAgent creates a new ToolCallingAgent. The system message and
+// the extra messages of the prompt can be set with WithSystemMessage and
+// WithExtraMessages.
+func NewToolCallingAgent(llm llms.Model, tools []tools.Tool, opts ...Option) *ToolCallingAgent {
+	options := toolCallingDefaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &ToolCallingAgent{
+		LLM:              llm,
+		Prompt:           createToolCallingPrompt(options),
+		Tools:            tools,
+		OutputKey:        options.outputKey,
+		CallbacksHandler: options.callbacksHandler,
+	}
+}
+
+func (a *ToolCallingAgent) tools() []llms.Tool {
+	res := make([]llms.Tool, 0, len(a.Tools))
+	for _, tool := range a.Tools {
+		res = append(res, llms.Tool{
+			Type: "function",
+			Function: &llms.FunctionDefinition{
+				Name:        tool.Name(),
+				Description: tool.Description(),
+				Parameters:  tools.Parameters(tool),
+			},
+		})
+	}
+	return res
+}
+
+// tool returns the tool with the given name, or nil.
+func (a *ToolCallingAgent) tool(name string) tools.Tool {
+	for _, tool := range a.Tools {
+		if strings.EqualFold(tool.Name(), name) {
+			return tool
+		}
+	}
+	return nil
+}
+
+// Plan decides what actions to take or returns the final result of the input.
+func (a *ToolCallingAgent) Plan(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	return a.PlanWithOptions(ctx, intermediateSteps, inputs)
+}
+
+// PlanWithOptions is like Plan, using the given call options for the LLM call.
+func (a *ToolCallingAgent) PlanWithOptions(
+	ctx context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+	options ...chains.ChainCallOption,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	fullInputs := make(map[string]any, len(inputs))
+	for key, value := range inputs {
+		fullInputs[key] = value
+	}
+
+	prompt, err := a.Prompt.FormatPrompt(fullInputs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messages := make([]llms.MessageContent, 0, len(prompt.Messages())+2*len(intermediateSteps))
+	for _, msg := range prompt.Messages() {
+		messages = append(messages, chatMessageToContent(msg))
+	}
+	messages = append(messages, a.constructScratchPad(intermediateSteps)...)
+
+	callOpts := append(chains.GetLLMCallOptions(options...),
+		llms.WithTools(a.tools()),
+		llms.WithStreamingFunc(streamingFunc(ctx, a.CallbacksHandler, options)),
+	)
+
+	result, err := a.LLM.GenerateContent(ctx, messages, callOpts...)
+	recordUsage(ctx, result)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return a.ParseOutput(result)
+}
+
+// ParseOutput returns an action for each tool call of the response, or a
+// finish with the content of the response if there are no tool calls. The
+// choices of the response are merged, since some providers (e.g. anthropic)
+// return a choice for each block of content.
+func (a *ToolCallingAgent) ParseOutput(contentResp *llms.ContentResponse) (
+	[]schema.AgentAction, *schema.AgentFinish, error,
+) {
+	if contentResp == nil || len(contentResp.Choices) == 0 {
+		return nil, nil, fmt.Errorf("%w: no choices in response", ErrUnableToParseOutput)
+	}
+
+	var (
+		content   strings.Builder
+		toolCalls []llms.ToolCall
+	)
+	for _, choice := range contentResp.Choices {
+		content.WriteString(choice.Content)
+		for _, toolCall := range choice.ToolCalls {
+			if toolCall.FunctionCall != nil {
+				toolCalls = append(toolCalls, toolCall)
+			}
+		}
+	}
+
+	// finish
+	if len(toolCalls) == 0 {
+		return nil, &schema.AgentFinish{
+			ReturnValues: map[string]any{
+				a.OutputKey: content.String(),
+			},
+			Log: content.String(),
+		}, nil
+	}
+
+	// actions, all sharing the log of the turn, and the ID of its first call
+	// as turn ID so that the scratchpad can group them back in a single
+	// message.
+	var log strings.Builder
+	if content.Len() > 0 {
+		fmt.Fprintf(&log, "%s\n", content.String())
+	}
+	for _, toolCall := range toolCalls {
+		fmt.Fprintf(&log, "Invoking: %s with %s\n", toolCall.FunctionCall.Name, toolCall.FunctionCall.Arguments)
+	}
+
+	actions := make([]schema.AgentAction, 0, len(toolCalls))
+	for _, toolCall := range toolCalls {
+		actions = append(actions, schema.AgentAction{
+			Tool:      toolCall.FunctionCall.Name,
+			ToolInput: a.toolInput(toolCall.FunctionCall.Name, toolCall.FunctionCall.Arguments),
+			Log:       log.String(),
+			ToolID:    toolCall.ID,
+			TurnID:    toolCalls[0].ID,
+		})
+	}
+
+	return actions, nil, nil
+}
+
+func (a *ToolCallingAgent) GetInputKeys() []string {
+	return a.Prompt.GetInputVariables()
+}
+
+func (a *ToolCallingAgent) GetOutputKeys() []string {
+	return []string{a.OutputKey}
+}
+
+func (a *ToolCallingAgent) GetTools() []tools.Tool {
+	return a.Tools
+}
+
+func createToolCallingPrompt(opts Options) prompts.ChatPromptTemplate {
+	messageFormatters := []prompts.MessageFormatter{prompts.NewSystemMessagePromptTemplate(opts.systemMessage, nil)}
+	messageFormatters = append(messageFormatters, opts.extraMessages...)
+	messageFormatters = append(messageFormatters, prompts.NewHumanMessagePromptTemplate("{{.input}}", []string{"input"}))
+
+	return prompts.NewChatPromptTemplate(messageFormatters)
+}
+
+// constructScratchPad turns the steps into an AI message with the tool calls
+// of each turn, followed by a tool message with the result of each call.
+// Consecutive steps with the same turn ID come from the same turn.
+func (a *ToolCallingAgent) constructScratchPad(steps []schema.AgentStep) []llms.MessageContent {
+	messages := make([]llms.MessageContent, 0)
+	for start := 0; start < len(steps); {
+		if steps[start].Action.Tool == "" {
+			// Not a tool call, e.g. the observation of a parser error.
+			messages = append(messages, llms.TextParts(llms.ChatMessageTypeHuman, steps[start].Observation))
+			start++
+			continue
+		}
+
+		end := start + 1
+		for end < len(steps) && sameTurn(steps[start:end], steps[end]) {
+			end++
+		}
+
+		call := llms.MessageContent{Role: llms.ChatMessageTypeAI}
+		results := make([]llms.MessageContent, 0, end-start)
+		for _, step := range steps[start:end] {
+			call.Parts = append(call.Parts, llms.ToolCall{
+				ID:   step.Action.ToolID,
+				Type: "function",
+				FunctionCall: &llms.FunctionCall{
+					Name:      step.Action.Tool,
+					Arguments: a.toolArguments(step.Action.Tool, step.Action.ToolInput),
+				},
+			})
+			results = append(results, llms.MessageContent{
+				Role: llms.ChatMessageTypeTool,
+				Parts: []llms.ContentPart{llms.ToolCallResponse{
+					ToolCallID: step.Action.ToolID,
+					Name:       step.Action.Tool,
+					Content:    step.Observation,
+				}},
+			})
+		}
+		messages = append(messages, call)
+		messages = append(messages, results...)
+		start = end
+	}
+
+	return messages
+}
+
+// sameTurn reports whether the step belongs to the same turn as the previous
+// steps.
+func sameTurn(turn []schema.AgentStep, step schema.AgentStep) bool {
+	if step.Action.Tool == "" || step.Action.TurnID == "" || step.Action.TurnID != turn[0].Action.TurnID {
+		return false
+	}
+	for _, s := range turn {
+		if s.Action.ToolID == step.Action.ToolID {
+			return false
+		}
+	}
+	return true
+}
+
+// toolInput returns the input of a tool from the JSON arguments of a call.
+func (a *ToolCallingAgent) toolInput(name, arguments string) string {
+	tool := a.tool(name)
+	if tool == nil {
+		return arguments
+	}
+	return tools.InputFromArguments(tool, arguments)
+}
+
+// toolArguments returns the JSON arguments of a call from the input of a tool.
+func (a *ToolCallingAgent) toolArguments(name, input string) string {
+	tool := a.tool(name)
+	if tool == nil {
+		return input
+	}
+	return tools.ArgumentsFromInput(tool, input)
+}
+
+// chatMessageToContent converts a formatted chat message, e.g. from the
+// history of a conversation, to a message content.
+func chatMessageToContent(msg llms.ChatMessage) llms.MessageContent {
+	switch m := msg.(type) {
+	case llms.AIChatMessage:
+		mc := llms.MessageContent{Role: llms.ChatMessageTypeAI}
+		if m.Content != "" {
+			mc.Parts = append(mc.Parts, llms.TextContent{Text: m.Content})
+		}
+		for _, toolCall := range m.ToolCalls {
+			mc.Parts = append(mc.Parts, toolCall)
+		}
+		if len(mc.Parts) == 0 {
+			mc.Parts = append(mc.Parts, llms.TextContent{})
+		}
+		return mc
+	case llms.ToolChatMessage:
+		return llms.MessageContent{
+			Role: llms.ChatMessageTypeTool,
+			Parts: []llms.ContentPart{llms.ToolCallResponse{
+				ToolCallID: m.ID,
+				Content:    m.Content,
+			}},
+		}
+	default:
+		return llms.TextParts(msg.GetType(), msg.GetContent())
+	}
+}
@@ -0,0 +1,196 @@
+package agents_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// toolCallingLLM returns its responses in order, streaming their content, and
+// records the messages, tools and options of each call.
+type toolCallingLLM struct {
+	responses []*llms.ContentResponse
+	calls     [][]llms.MessageContent
+	tools     [][]llms.Tool
+	options   []llms.CallOptions
+}
+
+func (l *toolCallingLLM) GenerateContent(
+	ctx context.Context,
+	messages []llms.MessageContent,
+	options ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	opts := llms.CallOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	l.calls = append(l.calls, messages)
+	l.tools = append(l.tools, opts.Tools)
+	l.options = append(l.options, opts)
+
+	resp := l.responses[0]
+	l.responses = l.responses[1:]
+	if opts.StreamingFunc != nil {
+		for _, choice := range resp.Choices {
+			if choice.Content == "" {
+				continue
+			}
+			if err := opts.StreamingFunc(ctx, []byte(choice.Content)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return resp, nil
+}
+
+func (l *toolCallingLLM) Call(context.Context, string, ...llms.CallOption) (string, error) {
+	return "", nil
+}
+
+func toolCall(id, name, arguments string) llms.ToolCall {
+	return llms.ToolCall{
+		ID:           id,
+		Type:         "function",
+		FunctionCall: &llms.FunctionCall{Name: name, Arguments: arguments},
+	}
+}
+
+func TestToolCallingAgent(t *testing.T) {
+	t.Parallel()
+
+	llm := &toolCallingLLM{
+		responses: []*llms.ContentResponse{
+			// Anthropic style: a choice for the text and one for each tool call.
+			{Choices: []*llms.ContentChoice{
+				{Content: "Let me check."},
+				{ToolCalls: []llms.ToolCall{toolCall("call_1", "weather", `{"__arg1":"Paris"}`)}},
+				{ToolCalls: []llms.ToolCall{toolCall("call_2", "weather", `{"__arg1":"Tokyo"}`)}},
+			}},
+			// OpenAI style: all the tool calls in one choice.
+			{Choices: []*llms.ContentChoice{
+				{ToolCalls: []llms.ToolCall{toolCall("call_3", "weather", `{"__arg1":"Rome"}`)}},
+			}},
+			{Choices: []*llms.ContentChoice{{Content: "It is sunny everywhere."}}},
+		},
+	}
+	weather := funcTool{name: "weather", fn: func(_ context.Context, input string) (string, error) {
+		return "sunny in " + input, nil
+	}}
+
+	agent := agents.NewToolCallingAgent(llm, []tools.Tool{weather}, agents.WithSystemMessage("Be brief."))
+	executor := agents.NewExecutor(agent)
+
+	result, err := chains.Run(context.Background(), executor, "What's the weather?")
+	require.NoError(t, err)
+	assert.Equal(t, "It is sunny everywhere.", result)
+
+	require.Len(t, llm.calls, 3)
+	require.Len(t, llm.tools[0], 1)
+	assert.Equal(t, "weather", llm.tools[0][0].Function.Name)
+
+	first := llm.calls[0]
+	require.Len(t, first, 2)
+	assert.Equal(t, llms.TextParts(llms.ChatMessageTypeSystem, "Be brief."), first[0])
+	assert.Equal(t, llms.TextParts(llms.ChatMessageTypeHuman, "What's the weather?"), first[1])
+
+	// The calls of a turn are grouped in a single AI message, followed by a
+	// tool message for each result.
+	last := llm.calls[2]
+	require.Len(t, last, 7)
+	assert.Equal(t, llms.MessageContent{
+		Role: llms.ChatMessageTypeAI,
+		Parts: []llms.ContentPart{
+			toolCall("call_1", "weather", `{"__arg1":"Paris"}`),
+			toolCall("call_2", "weather", `{"__arg1":"Tokyo"}`),
+		},
+	}, last[2])
+	assert.Equal(t, llms.MessageContent{
+		Role: llms.ChatMessageTypeTool,
+		Parts: []llms.ContentPart{llms.ToolCallResponse{
+			ToolCallID: "call_2", Name: "weather", Content: "sunny in Tokyo",
+		}},
+	}, last[4])
+	assert.Equal(t, llms.MessageContent{
+		Role:  llms.ChatMessageTypeAI,
+		Parts: []llms.ContentPart{toolCall("call_3", "weather", `{"__arg1":"Rome"}`)},
+	}, last[5])
+	assert.Equal(t, llms.ChatMessageTypeTool, last[6].Role)
+}
+
+func TestToolCallingAgentParseOutput(t *testing.T) {
+	t.Parallel()
+
+	type lookupArgs struct {
+		Query string `json:"query"`
+	}
+	lookup, err := tools.NewTyped("lookup", "Looks up a query.", func(_ context.Context, args lookupArgs) (string, error) {
+		return args.Query, nil
+	})
+	require.NoError(t, err)
+	search := funcTool{name: "search"}
+
+	agent := agents.NewToolCallingAgent(&toolCallingLLM{}, []tools.Tool{search, lookup})
+
+	actions, finish, err := agent.ParseOutput(&llms.ContentResponse{Choices: []*llms.ContentChoice{{
+		Content: "Searching.",
+		ToolCalls: []llms.ToolCall{
+			toolCall("a", "search", `{"__arg1":"go"}`),
+			toolCall("b", "lookup", `{"query":"rust"}`),
+		},
+	}}})
+	require.NoError(t, err)
+	require.Nil(t, finish)
+	require.Len(t, actions, 2)
+	assert.Equal(t, "search", actions[0].Tool)
+	assert.Equal(t, "go", actions[0].ToolInput)
+	assert.Equal(t, "a", actions[0].ToolID)
+	assert.Equal(t, `{"query":"rust"}`, actions[1].ToolInput)
+	assert.Equal(t, actions[0].Log, actions[1].Log)
+	assert.Equal(t, "a", actions[1].TurnID)
+	assert.True(t, strings.HasPrefix(actions[0].Log, "Searching."))
+
+	_, _, err = agent.ParseOutput(&llms.ContentResponse{})
+	require.ErrorIs(t, err, agents.ErrUnableToParseOutput)
+}
+
+func TestToolCallingAgentIdenticalTurns(t *testing.T) {
+	t.Parallel()
+
+	// Two turns making the same call have the same log, but are still given
+	// back to the model as two turns.
+	llm := &toolCallingLLM{
+		responses: []*llms.ContentResponse{
+			{Choices: []*llms.ContentChoice{
+				{ToolCalls: []llms.ToolCall{toolCall("call_1", "weather", `{"__arg1":"Paris"}`)}},
+			}},
+			{Choices: []*llms.ContentChoice{
+				{ToolCalls: []llms.ToolCall{toolCall("call_2", "weather", `{"__arg1":"Paris"}`)}},
+			}},
+			{Choices: []*llms.ContentChoice{{Content: "It is sunny."}}},
+		},
+	}
+	weather := funcTool{name: "weather", fn: func(_ context.Context, input string) (string, error) {
+		return "sunny in " + input, nil
+	}}
+
+	executor := agents.NewExecutor(agents.NewToolCallingAgent(llm, []tools.Tool{weather}))
+	_, err := chains.Run(context.Background(), executor, "What's the weather?")
+	require.NoError(t, err)
+
+	last := llm.calls[2]
+	require.Len(t, last, 6)
+	for i, id := range []string{"call_1", "call_2"} {
+		assert.Equal(t, llms.MessageContent{
+			Role:  llms.ChatMessageTypeAI,
+			Parts: []llms.ContentPart{toolCall(id, "weather", `{"__arg1":"Paris"}`)},
+		}, last[2+2*i])
+		assert.Equal(t, llms.ChatMessageTypeTool, last[3+2*i].Role)
+	}
+}
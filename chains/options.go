@@ -161,6 +161,22 @@ func WithCallback(callbackHandler callbacks.Handler) ChainCallOption {
 	}
 }
 
+// GetLLMCallOptions returns the options of the LLM calls made with the given
+// chain call options, for chains and agents calling an LLM directly.
+func GetLLMCallOptions(options ...ChainCallOption) []llms.CallOption {
+	return getLLMCallOptions(options...)
+}
+
+// GetCallbackHandler returns the callback handler set with WithCallback in the
+// given chain call options, or nil.
+func GetCallbackHandler(options ...ChainCallOption) callbacks.Handler { //nolint:ireturn
+	opts := &chainCallOption{}
+	for _, option := range options {
+		option(opts)
+	}
+	return opts.CallbackHandler
+}
+
 func getLLMCallOptions(options ...ChainCallOption) []llms.CallOption { //nolint:cyclop
 	opts := &chainCallOption{}
 	for _, option := range options {
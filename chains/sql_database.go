@@ -46,6 +46,9 @@ type SQLDatabaseChain struct {
 	TopK      int
 	Database  *sqldatabase.SQLDatabase
 	OutputKey string
+	// TableSelector, if set, selects the tables described to the model when
+	// the inputs have no table names, instead of describing all the tables.
+	TableSelector sqldatabase.TableSelector
 }
 
 // NewSQLDatabaseChain creates a new SQLDatabaseChain.
@@ -67,7 +70,8 @@ func NewSQLDatabaseChain(llm llms.Model, topK int, database *sqldatabase.SQLData
 //
 //	"query" : key with the query to run.
 //	"table_names_to_use" (optionally): a slice string of the only table names
-//		to use(others will be ignored).
+//		to use(others will be ignored). Without it, the tables are selected by
+//		the TableSelector of the chain, if any.
 //
 // Outputs
 //
@@ -85,8 +89,11 @@ func (s SQLDatabaseChain) Call(ctx context.Context, inputs map[string]any, optio
 		if tables, ok = ts.([]string); !ok {
 			return nil, fmt.Errorf("%w: %w", ErrInvalidInputValues, ErrInputValuesWrongType)
 		}
-	} else {
-		tables = nil
+	} else if s.TableSelector != nil {
+		var err error
+		if tables, err = s.TableSelector.SelectTables(ctx, query); err != nil {
+			return nil, err
+		}
 	}
 
 	// Get tables infos
@@ -83,4 +83,23 @@ func (b *Bedrock) EmbedQuery(ctx context.Context, text string) ([]float32, error
 	return embeddings[0], nil
 }
 
-var _ embeddings.Embedder = &Bedrock{}
+// EmbedImages implements embeddings.MultimodalEmbedder
+// and generates embeddings for the supplied images.
+// The model must be a multimodal model, such as ModelTitanEmbedImageG1.
+func (b *Bedrock) EmbedImages(ctx context.Context, images []embeddings.Image) ([][]float32, error) {
+	provider := getProvider(b.ModelID)
+	if provider != "amazon" {
+		return nil, errors.New("unsupported image embedding provider: " + provider)
+	}
+
+	inputs := make([]embeddings.MultimodalInput, len(images))
+	for i := range images {
+		inputs[i] = embeddings.MultimodalInput{Image: &images[i]}
+	}
+	return FetchAmazonMultimodalEmbeddings(ctx, b.client, b.ModelID, inputs)
+}
+
+var (
+	_ embeddings.Embedder           = &Bedrock{}
+	_ embeddings.MultimodalEmbedder = &Bedrock{}
+)
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/embeddings/bedrock"
 )
 
@@ -34,3 +35,19 @@ func TestEmbedDocuments(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, embeddings, 2)
 }
+
+func TestEmbedImages(t *testing.T) {
+	t.Parallel()
+	if os.Getenv("TEST_AWS") != "true" {
+		t.Skip("Skipping test, requires AWS access")
+	}
+	model, err := bedrock.NewBedrock(bedrock.WithModel(bedrock.ModelTitanEmbedImageG1))
+	require.NoError(t, err)
+
+	vectors, err := model.EmbedImages(context.Background(), []embeddings.Image{
+		{URL: "https://github.com/tmc/langchaingo/blob/main/docs/static/img/parrot-icon.png?raw=true"},
+	})
+
+	require.NoError(t, err)
+	require.Len(t, vectors, 1)
+}
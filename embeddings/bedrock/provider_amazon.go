@@ -2,10 +2,13 @@ package bedrock
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/bedrockruntime"
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/internal/imageutil"
 )
 
 const (
@@ -17,12 +20,26 @@ const (
 		  Languages := []string{"English", "Arabic", "Chinese (Simplified)", "French", "German", "Hindi", "Japanese", "Spanish", "Czech", "Filipino", "Hebrew", "Italian", "Korean", "Portuguese", "Russian", "Swedish", "Turkish", "Chinese (Traditional)", "Dutch", "Kannada", "Malayalam", "Marathi", "Polish", "Tamil", "Telugu", ...}
 	*/
 	ModelTitanEmbedG1 = "amazon.titan-embed-text-v1"
+	/*
+		ModelTitanEmbedImageG1 is the model id for the amazon multimodal
+		embeddings, which embeds texts and images in the same vector space.
+
+		  MaxTokens := 128
+		  ModelDimensions := 1024
+		  MaxImageSize := "25 MB"
+	*/
+	ModelTitanEmbedImageG1 = "amazon.titan-embed-image-v1"
 )
 
 type amazonEmbeddingsInput struct {
 	InputText string `json:"inputText"`
 }
 
+type amazonMultimodalEmbeddingsInput struct {
+	InputText  string `json:"inputText,omitempty"`
+	InputImage string `json:"inputImage,omitempty"`
+}
+
 type amazonEmbeddingsOutput struct {
 	Embedding []float32 `json:"embedding"`
 }
@@ -64,3 +81,58 @@ func FetchAmazonTextEmbeddings(ctx context.Context,
 
 	return embeddings, nil
 }
+
+// FetchAmazonMultimodalEmbeddings embeds texts and images with an amazon
+// multimodal embeddings model. Image URLs are downloaded first.
+func FetchAmazonMultimodalEmbeddings(ctx context.Context,
+	client *bedrockruntime.Client,
+	modelID string,
+	inputs []embeddings.MultimodalInput,
+) ([][]float32, error) {
+	vectors := make([][]float32, 0, len(inputs))
+
+	for _, input := range inputs {
+		bodyStruct := amazonMultimodalEmbeddingsInput{
+			InputText: input.Text,
+		}
+		if input.Image != nil {
+			data := input.Image.Data
+			if len(data) == 0 {
+				if input.Image.URL == "" {
+					return nil, embeddings.ErrInvalidImage
+				}
+				_, downloaded, err := imageutil.DownloadImageData(input.Image.URL)
+				if err != nil {
+					return nil, err
+				}
+				data = downloaded
+			}
+			bodyStruct.InputImage = base64.StdEncoding.EncodeToString(data)
+		}
+
+		body, err := json.Marshal(bodyStruct)
+		if err != nil {
+			return nil, err
+		}
+		modelInput := &bedrockruntime.InvokeModelInput{
+			ModelId:     aws.String(modelID),
+			Accept:      aws.String("*/*"),
+			ContentType: aws.String("application/json"),
+			Body:        body,
+		}
+
+		result, err := client.InvokeModel(ctx, modelInput)
+		if err != nil {
+			return nil, err
+		}
+
+		var response amazonEmbeddingsOutput
+		err = json.Unmarshal(result.Body, &response)
+		if err != nil {
+			return nil, err
+		}
+		vectors = append(vectors, response.Embedding)
+	}
+
+	return vectors, nil
+}
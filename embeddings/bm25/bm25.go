@@ -0,0 +1,191 @@
+// Package bm25 provides a pure Go sparse embedder based on the Okapi BM25
+// ranking function.
+//
+// The encoder must be fitted on a corpus before use. Documents are encoded
+// with BM25 term-frequency saturation and length normalization, and queries
+// are encoded with the inverse document frequency of their terms, so that the
+// dot product between a query vector and a document vector is the BM25 score
+// of the document for the query.
+package bm25
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/tmc/langchaingo/embeddings"
+)
+
+// ErrNotFitted is returned when the encoder is used before being fitted on a
+// corpus.
+var ErrNotFitted = errors.New("bm25 encoder has not been fitted")
+
+// Stats are the corpus statistics an encoder is fitted with.
+type Stats struct {
+	// NumDocs is the number of documents in the corpus.
+	NumDocs int `json:"num_docs"`
+	// AvgDocLen is the average number of terms per document.
+	AvgDocLen float64 `json:"avg_doc_len"`
+	// DocFreq maps term indices to the number of documents containing them.
+	DocFreq map[uint32]int `json:"doc_freq"`
+}
+
+// Encoder is a BM25 sparse embedder.
+type Encoder struct {
+	K1        float64
+	B         float64
+	Tokenizer func(text string) []string
+
+	mu    sync.RWMutex
+	stats Stats
+}
+
+var _ embeddings.SparseEmbedder = &Encoder{}
+
+// New creates a new BM25 encoder. Unless statistics are given with
+// WithStats, Fit must be called before embedding.
+func New(opts ...Option) *Encoder {
+	return applyOptions(opts...)
+}
+
+// Fit computes the corpus statistics from the given texts, replacing any
+// previous statistics.
+func (e *Encoder) Fit(corpus []string) {
+	stats := Stats{
+		NumDocs: len(corpus),
+		DocFreq: make(map[uint32]int),
+	}
+
+	totalLen := 0
+	for _, text := range corpus {
+		tf := e.termFrequencies(text)
+		for idx := range tf {
+			stats.DocFreq[idx]++
+		}
+		totalLen += docLen(tf)
+	}
+	if stats.NumDocs > 0 {
+		stats.AvgDocLen = float64(totalLen) / float64(stats.NumDocs)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.stats = stats
+}
+
+// Stats returns the statistics the encoder has been fitted with.
+func (e *Encoder) Stats() Stats {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.stats
+}
+
+// EmbedDocumentsSparse returns a sparse vector for each text.
+func (e *Encoder) EmbedDocumentsSparse(_ context.Context, texts []string) ([]embeddings.SparseVector, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.stats.NumDocs == 0 {
+		return nil, ErrNotFitted
+	}
+
+	// A corpus of empty texts has no average length to normalize with.
+	avgDocLen := e.stats.AvgDocLen
+	if avgDocLen <= 0 {
+		avgDocLen = 1
+	}
+
+	vectors := make([]embeddings.SparseVector, 0, len(texts))
+	for _, text := range texts {
+		tf := e.termFrequencies(text)
+		norm := e.K1 * (1 - e.B + e.B*float64(docLen(tf))/avgDocLen)
+
+		weights := make(map[uint32]float64, len(tf))
+		for idx, freq := range tf {
+			f := float64(freq)
+			weights[idx] = f * (e.K1 + 1) / (f + norm)
+		}
+		vectors = append(vectors, toSparseVector(weights))
+	}
+
+	return vectors, nil
+}
+
+// EmbedQuerySparse embeds a single text.
+func (e *Encoder) EmbedQuerySparse(_ context.Context, text string) (embeddings.SparseVector, error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.stats.NumDocs == 0 {
+		return embeddings.SparseVector{}, ErrNotFitted
+	}
+
+	tf := e.termFrequencies(text)
+	weights := make(map[uint32]float64, len(tf))
+	for idx := range tf {
+		weights[idx] = e.idf(idx)
+	}
+
+	return toSparseVector(weights), nil
+}
+
+// idf returns the inverse document frequency of a term, using the
+// non-negative variant of the BM25 idf.
+func (e *Encoder) idf(idx uint32) float64 {
+	n := float64(e.stats.NumDocs)
+	df := float64(e.stats.DocFreq[idx])
+	return math.Log((n-df+0.5)/(df+0.5) + 1)
+}
+
+func (e *Encoder) termFrequencies(text string) map[uint32]int {
+	tf := make(map[uint32]int)
+	for _, term := range e.Tokenizer(text) {
+		tf[TermIndex(term)]++
+	}
+	return tf
+}
+
+// TermIndex returns the sparse vector index of a term. Indices are the
+// 32-bit FNV-1a hash of the term, so they are stable across encoders and
+// processes.
+func TermIndex(term string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(term))
+	return h.Sum32()
+}
+
+// DefaultTokenizer lowercases the text and splits it on anything that is not
+// a letter or a digit.
+func DefaultTokenizer(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func docLen(tf map[uint32]int) int {
+	n := 0
+	for _, freq := range tf {
+		n += freq
+	}
+	return n
+}
+
+func toSparseVector(weights map[uint32]float64) embeddings.SparseVector {
+	indices := make([]uint32, 0, len(weights))
+	for idx := range weights {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+
+	values := make([]float32, len(indices))
+	for i, idx := range indices {
+		values[i] = float32(weights[idx])
+	}
+
+	return embeddings.SparseVector{Indices: indices, Values: values}
+}
@@ -0,0 +1,92 @@
+package bm25
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoderNotFitted(t *testing.T) {
+	t.Parallel()
+
+	e := New()
+	_, err := e.EmbedQuerySparse(context.Background(), "hello")
+	require.ErrorIs(t, err, ErrNotFitted)
+	_, err = e.EmbedDocumentsSparse(context.Background(), []string{"hello"})
+	require.ErrorIs(t, err, ErrNotFitted)
+}
+
+func TestEncoderEmptyCorpus(t *testing.T) {
+	t.Parallel()
+
+	e := New()
+	e.Fit([]string{"", "  "})
+
+	docs, err := e.EmbedDocumentsSparse(context.Background(), []string{"hello hello world"})
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	for _, v := range docs[0].Values {
+		assert.False(t, math.IsNaN(float64(v)) || math.IsInf(float64(v), 0))
+	}
+}
+
+func TestEncoderRanking(t *testing.T) {
+	t.Parallel()
+
+	corpus := []string{
+		"The quick brown fox jumps over the lazy dog",
+		"Tokyo is the capital of Japan",
+		"Potatoes are a starchy root vegetable",
+		"The capital of France is Paris",
+	}
+
+	e := New()
+	e.Fit(corpus)
+
+	docs, err := e.EmbedDocumentsSparse(context.Background(), corpus)
+	require.NoError(t, err)
+	require.Len(t, docs, len(corpus))
+	for _, doc := range docs {
+		require.NoError(t, doc.Validate())
+	}
+
+	query, err := e.EmbedQuerySparse(context.Background(), "What is the capital of Japan?")
+	require.NoError(t, err)
+
+	best, bestScore := -1, float32(0)
+	for i, doc := range docs {
+		if score := query.Dot(doc); score > bestScore {
+			best, bestScore = i, score
+		}
+	}
+	assert.Equal(t, 1, best)
+	assert.Zero(t, query.Dot(docs[2]))
+}
+
+func TestEncoderStats(t *testing.T) {
+	t.Parallel()
+
+	e := New()
+	e.Fit([]string{"a b", "b c d"})
+
+	stats := e.Stats()
+	assert.Equal(t, 2, stats.NumDocs)
+	assert.InDelta(t, 2.5, stats.AvgDocLen, 1e-9)
+	assert.Equal(t, 2, stats.DocFreq[TermIndex("b")])
+
+	restored := New(WithStats(stats))
+	want, err := e.EmbedQuerySparse(context.Background(), "b d")
+	require.NoError(t, err)
+	got, err := restored.EmbedQuerySparse(context.Background(), "b d")
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestDefaultTokenizer(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"hello", "world", "42"}, DefaultTokenizer("Hello, World! 42"))
+}
@@ -0,0 +1,57 @@
+package bm25
+
+const (
+	_defaultK1 = 1.2
+	_defaultB  = 0.75
+)
+
+// Option is a function type that can be used to modify the encoder.
+type Option func(e *Encoder)
+
+// WithK1 is an option for setting the term frequency saturation parameter.
+// Default is 1.2.
+func WithK1(k1 float64) Option {
+	return func(e *Encoder) {
+		e.K1 = k1
+	}
+}
+
+// WithB is an option for setting the document length normalization
+// parameter. It must be between 0 and 1. Default is 0.75.
+func WithB(b float64) Option {
+	return func(e *Encoder) {
+		e.B = b
+	}
+}
+
+// WithTokenizer is an option for providing the function used to split texts
+// into terms. The default tokenizer lowercases the text and splits it on
+// anything that is not a letter or a digit.
+func WithTokenizer(tokenizer func(text string) []string) Option {
+	return func(e *Encoder) {
+		e.Tokenizer = tokenizer
+	}
+}
+
+// WithStats is an option for providing corpus statistics obtained from a
+// previous call to Fit, so that the encoder can be used without fitting it
+// again.
+func WithStats(stats Stats) Option {
+	return func(e *Encoder) {
+		e.stats = stats
+	}
+}
+
+func applyOptions(opts ...Option) *Encoder {
+	e := &Encoder{
+		K1:        _defaultK1,
+		B:         _defaultB,
+		Tokenizer: DefaultTokenizer,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	return e
+}
@@ -8,6 +8,13 @@ The main components of this package are:
     from texts, with optional batching.
   - [NewEmbedder] creates implementations of [Embedder] from provider LLM
     (or Chat) clients.
+  - [SparseEmbedder] interface: a common interface for creating sparse
+    vector embeddings, used for hybrid retrieval. See the bm25 subpackage
+    for a pure Go implementation. The pinecone and qdrant vector stores
+    accept a sparse embedder; weaviate runs its own hybrid search instead.
+  - [MultimodalEmbedder] interface: embeds texts and images in the same
+    vector space. [NewMultimodalEmbedder] creates implementations from
+    provider clients.
 
 See the package example below.
 */
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync/atomic"
 
 	"github.com/tmc/langchaingo/internal/sliceutil"
 )
@@ -36,6 +37,22 @@ type EmbedderClient interface {
 	CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error)
 }
 
+// DimensionsEmbedderClient is implemented by EmbedderClients whose provider can
+// natively return embeddings with a reduced number of dimensions, such as
+// models trained with Matryoshka representation learning.
+type DimensionsEmbedderClient interface {
+	CreateEmbeddingWithDimensions(ctx context.Context, texts []string, dimensions int) ([][]float32, error)
+}
+
+// Dimensioner is implemented by embedders that know the number of dimensions
+// of the vectors they create. Vector stores use it to validate their
+// configuration before storing any vector.
+type Dimensioner interface {
+	// EmbeddingDimensions returns the number of dimensions of the created
+	// vectors, or 0 if it is not known yet.
+	EmbeddingDimensions() int
+}
+
 // EmbedderClientFunc is an adapter to allow the use of ordinary functions as Embedder Clients. If
 // `f` is a function with the appropriate signature, `EmbedderClientFunc(f)` is an `EmbedderClient`
 // that calls `f`.
@@ -50,15 +67,27 @@ type EmbedderImpl struct {
 
 	StripNewLines bool
 	BatchSize     int
+	// Dimensions is the number of dimensions of the created vectors. If the
+	// client implements DimensionsEmbedderClient the provider is asked for
+	// vectors of this size, otherwise the vectors are truncated and
+	// renormalized. Zero keeps the size returned by the provider.
+	Dimensions int
+
+	observedDimensions atomic.Int64
 }
 
+var (
+	_ Embedder    = &EmbedderImpl{}
+	_ Dimensioner = &EmbedderImpl{}
+)
+
 // EmbedQuery embeds a single text.
 func (ei *EmbedderImpl) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
 	if ei.StripNewLines {
 		text = strings.ReplaceAll(text, "\n", " ")
 	}
 
-	emb, err := ei.client.CreateEmbedding(ctx, []string{text})
+	emb, err := ei.createEmbedding(ctx, []string{text})
 	if err != nil {
 		return nil, fmt.Errorf("error embedding query: %w", err)
 	}
@@ -69,7 +98,47 @@ func (ei *EmbedderImpl) EmbedQuery(ctx context.Context, text string) ([]float32,
 // EmbedDocuments creates one vector embedding for each of the texts.
 func (ei *EmbedderImpl) EmbedDocuments(ctx context.Context, texts []string) ([][]float32, error) {
 	texts = MaybeRemoveNewLines(texts, ei.StripNewLines)
-	return BatchedEmbed(ctx, ei.client, texts, ei.BatchSize)
+	return BatchedEmbed(ctx, EmbedderClientFunc(ei.createEmbedding), texts, ei.BatchSize)
+}
+
+// EmbeddingDimensions returns the configured number of dimensions, or the
+// number of dimensions of the last vectors returned by the client if none was
+// configured.
+func (ei *EmbedderImpl) EmbeddingDimensions() int {
+	if ei.Dimensions > 0 {
+		return ei.Dimensions
+	}
+	return int(ei.observedDimensions.Load())
+}
+
+func (ei *EmbedderImpl) createEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
+	var (
+		emb [][]float32
+		err error
+	)
+
+	if dimClient, ok := ei.client.(DimensionsEmbedderClient); ok && ei.Dimensions > 0 {
+		emb, err = dimClient.CreateEmbeddingWithDimensions(ctx, texts, ei.Dimensions)
+	} else {
+		emb, err = ei.client.CreateEmbedding(ctx, texts)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ei.Dimensions > 0 {
+		for i := range emb {
+			if emb[i], err = TruncateVector(emb[i], ei.Dimensions); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if len(emb) > 0 {
+		ei.observedDimensions.Store(int64(len(emb[0])))
+	}
+
+	return emb, nil
 }
 
 func MaybeRemoveNewLines(texts []string, removeNewLines bool) []string {
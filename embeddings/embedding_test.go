@@ -1,9 +1,11 @@
 package embeddings
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestBatchTexts(t *testing.T) {
@@ -60,3 +62,59 @@ func TestBatchTexts(t *testing.T) {
 		assert.Equal(t, tc.expected, BatchTexts(tc.texts, tc.batchSize))
 	}
 }
+
+type dimensionsClient struct {
+	requested int
+}
+
+func (c *dimensionsClient) CreateEmbedding(_ context.Context, texts []string) ([][]float32, error) {
+	return c.CreateEmbeddingWithDimensions(context.Background(), texts, 4)
+}
+
+func (c *dimensionsClient) CreateEmbeddingWithDimensions(_ context.Context, texts []string, dimensions int) ([][]float32, error) { //nolint:lll
+	c.requested = dimensions
+	emb := make([][]float32, len(texts))
+	for i := range texts {
+		emb[i] = make([]float32, dimensions)
+		emb[i][0] = 1
+	}
+	return emb, nil
+}
+
+func TestEmbedderDimensions(t *testing.T) {
+	t.Parallel()
+
+	// Clients without native support get truncated and renormalized vectors.
+	e, err := NewEmbedder(EmbedderClientFunc(func(_ context.Context, texts []string) ([][]float32, error) {
+		emb := make([][]float32, len(texts))
+		for i := range texts {
+			emb[i] = []float32{3, 4, 12, 0}
+		}
+		return emb, nil
+	}), WithDimensions(2))
+	require.NoError(t, err)
+
+	docs, err := e.EmbedDocuments(context.Background(), []string{"foo", "bar"})
+	require.NoError(t, err)
+	require.Len(t, docs, 2)
+	assert.InDeltaSlice(t, []float32{0.6, 0.8}, docs[1], 1e-6)
+	assert.Equal(t, 2, e.EmbeddingDimensions())
+
+	// Clients with native support are asked for the dimensions.
+	client := &dimensionsClient{}
+	e, err = NewEmbedder(client, WithDimensions(3))
+	require.NoError(t, err)
+
+	query, err := e.EmbedQuery(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.Len(t, query, 3)
+	assert.Equal(t, 3, client.requested)
+
+	// Without a configured size the observed size is reported.
+	e, err = NewEmbedder(&dimensionsClient{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, e.EmbeddingDimensions())
+	_, err = e.EmbedQuery(context.Background(), "foo")
+	require.NoError(t, err)
+	assert.Equal(t, 4, e.EmbeddingDimensions())
+}
@@ -3,8 +3,10 @@ package jina
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"strings"
@@ -19,11 +21,13 @@ type Jina struct {
 	BatchSize     int
 	APIBaseURL    string
 	APIKey        string
+	Dimensions    int
 }
 
 type EmbeddingRequest struct {
-	Input []string `json:"input"`
-	Model string   `json:"model"`
+	Input      []string `json:"input"`
+	Model      string   `json:"model"`
+	Dimensions int      `json:"dimensions,omitempty"`
 }
 
 type EmbeddingResponse struct {
@@ -40,7 +44,20 @@ type EmbeddingResponse struct {
 	} `json:"data"`
 }
 
-var _ embeddings.Embedder = &Jina{}
+// MultimodalEmbeddingRequest is the request body used to embed a mix of
+// texts and images with CLIP models.
+type MultimodalEmbeddingRequest struct {
+	Input      []map[string]string `json:"input"`
+	Model      string              `json:"model"`
+	Dimensions int                 `json:"dimensions,omitempty"`
+}
+
+var (
+	_ embeddings.Embedder                 = &Jina{}
+	_ embeddings.Dimensioner              = &Jina{}
+	_ embeddings.MultimodalEmbedder       = &Jina{}
+	_ embeddings.MultimodalEmbedderClient = &Jina{}
+)
 
 func NewJina(opts ...Option) (*Jina, error) {
 	v := applyOptions(opts...)
@@ -79,12 +96,74 @@ func (j *Jina) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
 	return emb[0], nil
 }
 
+// EmbeddingDimensions returns the number of dimensions set with
+// WithDimensions, or 0 if the model default is used.
+func (j *Jina) EmbeddingDimensions() int {
+	return j.Dimensions
+}
+
+// EmbedImages creates one vector embedding for each of the images. The model
+// must be a CLIP model, such as ClipModel, for the vectors to be in the same
+// space as the text embeddings.
+func (j *Jina) EmbedImages(ctx context.Context, images []embeddings.Image) ([][]float32, error) {
+	if j.BatchSize < 1 {
+		return nil, fmt.Errorf("%w: %d", embeddings.ErrInvalidBatchSize, j.BatchSize)
+	}
+	emb := make([][]float32, 0, len(images))
+	for start := 0; start < len(images); start += j.BatchSize {
+		end := min(start+j.BatchSize, len(images))
+
+		inputs := make([]embeddings.MultimodalInput, 0, end-start)
+		for i := start; i < end; i++ {
+			inputs = append(inputs, embeddings.MultimodalInput{Image: &images[i]})
+		}
+
+		curBatchEmbeddings, err := j.CreateMultimodalEmbedding(ctx, inputs)
+		if err != nil {
+			return nil, err
+		}
+		emb = append(emb, curBatchEmbeddings...)
+	}
+
+	return emb, nil
+}
+
 // CreateEmbedding sends texts to the Jina API and retrieves their embeddings.
 func (j *Jina) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
-	requestBody := EmbeddingRequest{
-		Input: texts,
-		Model: j.Model,
+	return j.request(ctx, EmbeddingRequest{
+		Input:      texts,
+		Model:      j.Model,
+		Dimensions: j.Dimensions,
+	})
+}
+
+// CreateMultimodalEmbedding sends texts and images to the Jina API and
+// retrieves their embeddings. Images are sent as URLs, or base64 encoded.
+func (j *Jina) CreateMultimodalEmbedding(ctx context.Context, inputs []embeddings.MultimodalInput) ([][]float32, error) { //nolint:lll
+	requestInputs := make([]map[string]string, 0, len(inputs))
+	for _, input := range inputs {
+		switch {
+		case input.Image == nil:
+			requestInputs = append(requestInputs, map[string]string{"text": input.Text})
+		case len(input.Image.Data) > 0:
+			requestInputs = append(requestInputs, map[string]string{
+				"image": base64.StdEncoding.EncodeToString(input.Image.Data),
+			})
+		case input.Image.URL != "":
+			requestInputs = append(requestInputs, map[string]string{"image": input.Image.URL})
+		default:
+			return nil, embeddings.ErrInvalidImage
+		}
 	}
+
+	return j.request(ctx, MultimodalEmbeddingRequest{
+		Input:      requestInputs,
+		Model:      j.Model,
+		Dimensions: j.Dimensions,
+	})
+}
+
+func (j *Jina) request(ctx context.Context, requestBody any) ([][]float32, error) {
 	jsonData, err := json.Marshal(requestBody)
 	if err != nil {
 		return nil, err
@@ -2,11 +2,16 @@ package jina
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/embeddings"
 )
 
 func TestJinaEmbeddings(t *testing.T) {
@@ -25,3 +30,33 @@ func TestJinaEmbeddings(t *testing.T) {
 	require.NoError(t, err)
 	assert.Len(t, embeddings, 3)
 }
+
+func TestJinaEmbedImages(t *testing.T) {
+	t.Parallel()
+
+	var request MultimodalEmbeddingRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		fmt.Fprint(w, `{"data":[{"embedding":[0.1,0.2]},{"embedding":[0.3,0.4]}]}`)
+	}))
+	t.Cleanup(server.Close)
+
+	j, err := NewJina(WithModel(ClipModel), WithAPIBaseURL(server.URL), WithAPIKey("test"))
+	require.NoError(t, err)
+
+	emb, err := j.EmbedImages(context.Background(), []embeddings.Image{
+		{URL: "https://example.com/cat.png"},
+		{Data: []byte("png"), MIMEType: "image/png"},
+	})
+	require.NoError(t, err)
+	assert.Len(t, emb, 2)
+	assert.Equal(t, ClipModel, request.Model)
+	assert.Equal(t, []map[string]string{
+		{"image": "https://example.com/cat.png"},
+		{"image": "cG5n"},
+	}, request.Input)
+
+	j = &Jina{Model: "jina-clip-v2", APIBaseURL: server.URL}
+	_, err = j.EmbedImages(context.Background(), []embeddings.Image{{URL: "https://example.com/cat.png"}})
+	require.ErrorIs(t, err, embeddings.ErrInvalidBatchSize)
+}
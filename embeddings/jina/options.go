@@ -11,6 +11,7 @@ const (
 	SmallModel            = "jina-embeddings-v2-small-en"
 	BaseModel             = "jina-embeddings-v2-base-en"
 	LargeModel            = "jina-embeddings-v2-large-en"
+	ClipModel             = "jina-clip-v1"
 	APIBaseURL            = "https://api.jina.ai/v1/embeddings"
 )
 
@@ -52,11 +53,21 @@ func WithAPIKey(apiKey string) Option {
 	}
 }
 
+// WithDimensions is an option for specifying the number of dimensions of the
+// created vectors. Only supported by models trained with Matryoshka
+// representation learning, such as jina-embeddings-v3.
+func WithDimensions(dimensions int) Option {
+	return func(p *Jina) {
+		p.Dimensions = dimensions
+	}
+}
+
 func applyOptions(opts ...Option) *Jina {
 	_models := map[string]int{
 		"jina-embeddings-v2-small-en": 512,
 		"jina-embeddings-v2-base-en":  768,
 		"jina-embeddings-v2-large-en": 1024,
+		"jina-clip-v1":                768,
 	}
 
 	o := &Jina{
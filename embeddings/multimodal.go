@@ -0,0 +1,123 @@
+package embeddings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidImage is returned when an image has neither data nor a URL.
+	ErrInvalidImage = errors.New("image must have data or a URL")
+	// ErrInvalidBatchSize is returned when embedding with a batch size lower
+	// than 1.
+	ErrInvalidBatchSize = errors.New("batch size must be at least 1")
+)
+
+// Image is an image to embed, given either as raw bytes or as a URL.
+type Image struct {
+	// Data is the raw image. Takes precedence over URL.
+	Data []byte
+	// MIMEType is the MIME type of Data, e.g. "image/png". Optional.
+	MIMEType string
+	// URL is the location of the image. Providers support different schemes,
+	// e.g. "https://" or "gs://".
+	URL string
+}
+
+// Validate checks that the image has data or a URL.
+func (i Image) Validate() error {
+	if len(i.Data) == 0 && i.URL == "" {
+		return ErrInvalidImage
+	}
+	return nil
+}
+
+// MultimodalInput is a single input of a multimodal embedding request. Exactly
+// one of Text or Image is set.
+type MultimodalInput struct {
+	Text  string
+	Image *Image
+}
+
+// MultimodalEmbedder is the interface for creating vector embeddings from
+// texts and images in the same vector space.
+type MultimodalEmbedder interface {
+	Embedder
+	// EmbedImages returns a vector for each image.
+	EmbedImages(ctx context.Context, images []Image) ([][]float32, error)
+}
+
+// MultimodalEmbedderClient is the interface LLM clients implement for
+// multimodal embeddings.
+type MultimodalEmbedderClient interface {
+	CreateMultimodalEmbedding(ctx context.Context, inputs []MultimodalInput) ([][]float32, error)
+}
+
+// MultimodalEmbedderImpl is a MultimodalEmbedder backed by a
+// MultimodalEmbedderClient. Texts and images are embedded with the same
+// client, so their vectors can be compared.
+type MultimodalEmbedderImpl struct {
+	*EmbedderImpl
+
+	client MultimodalEmbedderClient
+}
+
+var _ MultimodalEmbedder = &MultimodalEmbedderImpl{}
+
+// NewMultimodalEmbedder creates a new MultimodalEmbedder from the given
+// MultimodalEmbedderClient. The options are the same as for NewEmbedder.
+func NewMultimodalEmbedder(client MultimodalEmbedderClient, opts ...Option) (*MultimodalEmbedderImpl, error) {
+	textClient := EmbedderClientFunc(func(ctx context.Context, texts []string) ([][]float32, error) {
+		inputs := make([]MultimodalInput, len(texts))
+		for i, text := range texts {
+			inputs[i] = MultimodalInput{Text: text}
+		}
+		return client.CreateMultimodalEmbedding(ctx, inputs)
+	})
+
+	e, err := NewEmbedder(textClient, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MultimodalEmbedderImpl{
+		EmbedderImpl: e,
+		client:       client,
+	}, nil
+}
+
+// EmbedImages creates one vector embedding for each of the images.
+func (ei *MultimodalEmbedderImpl) EmbedImages(ctx context.Context, images []Image) ([][]float32, error) {
+	if ei.BatchSize < 1 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidBatchSize, ei.BatchSize)
+	}
+	emb := make([][]float32, 0, len(images))
+	for start := 0; start < len(images); start += ei.BatchSize {
+		end := min(start+ei.BatchSize, len(images))
+
+		inputs := make([]MultimodalInput, 0, end-start)
+		for i := start; i < end; i++ {
+			if err := images[i].Validate(); err != nil {
+				return nil, err
+			}
+			inputs = append(inputs, MultimodalInput{Image: &images[i]})
+		}
+
+		batch, err := ei.client.CreateMultimodalEmbedding(ctx, inputs)
+		if err != nil {
+			return nil, fmt.Errorf("error embedding images: %w", err)
+		}
+
+		if ei.Dimensions > 0 {
+			for i := range batch {
+				if batch[i], err = TruncateVector(batch[i], ei.Dimensions); err != nil {
+					return nil, err
+				}
+			}
+		}
+		emb = append(emb, batch...)
+	}
+
+	return emb, nil
+}
@@ -0,0 +1,50 @@
+package embeddings
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type multimodalClient struct{}
+
+func (multimodalClient) CreateMultimodalEmbedding(_ context.Context, inputs []MultimodalInput) ([][]float32, error) { //nolint:lll
+	emb := make([][]float32, len(inputs))
+	for i, input := range inputs {
+		if input.Image != nil {
+			emb[i] = []float32{0, 1}
+		} else {
+			emb[i] = []float32{1, 0}
+		}
+	}
+	return emb, nil
+}
+
+func TestMultimodalEmbedder(t *testing.T) {
+	t.Parallel()
+
+	e, err := NewMultimodalEmbedder(multimodalClient{}, WithBatchSize(2))
+	require.NoError(t, err)
+
+	query, err := e.EmbedQuery(context.Background(), "a photo of a cat")
+	require.NoError(t, err)
+	assert.Equal(t, []float32{1, 0}, query)
+
+	images, err := e.EmbedImages(context.Background(), []Image{
+		{URL: "https://example.com/cat.png"},
+		{Data: []byte("png")},
+		{URL: "gs://bucket/dog.png"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{0, 1}, {0, 1}, {0, 1}}, images)
+
+	_, err = e.EmbedImages(context.Background(), []Image{{}})
+	require.ErrorIs(t, err, ErrInvalidImage)
+
+	e, err = NewMultimodalEmbedder(multimodalClient{}, WithBatchSize(0))
+	require.NoError(t, err)
+	_, err = e.EmbedImages(context.Background(), []Image{{URL: "https://example.com/cat.png"}})
+	require.ErrorIs(t, err, ErrInvalidBatchSize)
+}
@@ -20,3 +20,14 @@ func WithBatchSize(batchSize int) Option {
 		p.BatchSize = batchSize
 	}
 }
+
+// WithDimensions is an option for specifying the number of dimensions of the
+// created vectors. Providers that support reduced dimensions natively are
+// asked for vectors of this size, the others are truncated and renormalized,
+// which is only meaningful for models trained with Matryoshka representation
+// learning.
+func WithDimensions(dimensions int) Option {
+	return func(p *EmbedderImpl) {
+		p.Dimensions = dimensions
+	}
+}
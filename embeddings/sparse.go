@@ -0,0 +1,54 @@
+package embeddings
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrSparseVectorMalformed is returned when the indices and values of a
+// sparse vector do not have the same length.
+var ErrSparseVectorMalformed = errors.New("sparse vector indices and values have different lengths")
+
+// SparseVector is a sparse vector embedding. Only the non-zero dimensions are
+// stored: Values[i] is the weight of the dimension Indices[i].
+type SparseVector struct {
+	Indices []uint32  `json:"indices"`
+	Values  []float32 `json:"values"`
+}
+
+// Len returns the number of non-zero dimensions of the vector.
+func (v SparseVector) Len() int {
+	return len(v.Indices)
+}
+
+// Validate checks that the vector is well formed.
+func (v SparseVector) Validate() error {
+	if len(v.Indices) != len(v.Values) {
+		return ErrSparseVectorMalformed
+	}
+	return nil
+}
+
+// Dot returns the dot product of two sparse vectors.
+func (v SparseVector) Dot(other SparseVector) float32 {
+	weights := make(map[uint32]float32, len(other.Indices))
+	for i, idx := range other.Indices {
+		weights[idx] += other.Values[i]
+	}
+
+	var sum float32
+	for i, idx := range v.Indices {
+		sum += v.Values[i] * weights[idx]
+	}
+	return sum
+}
+
+// SparseEmbedder is the interface for creating sparse vector embeddings from
+// texts. Sparse embeddings are typically used together with dense embeddings
+// to do hybrid (lexical and semantic) retrieval.
+type SparseEmbedder interface {
+	// EmbedDocumentsSparse returns a sparse vector for each text.
+	EmbedDocumentsSparse(ctx context.Context, texts []string) ([]SparseVector, error)
+	// EmbedQuerySparse embeds a single text.
+	EmbedQuerySparse(ctx context.Context, text string) (SparseVector, error)
+}
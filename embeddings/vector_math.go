@@ -2,6 +2,7 @@ package embeddings
 
 import (
 	"errors"
+	"fmt"
 	"math"
 )
 
@@ -12,8 +13,42 @@ var (
 	// ErrAllTextsLenZero is returned if all texts to be embedded has the combined
 	// length of zero.
 	ErrAllTextsLenZero = errors.New("all texts have length 0")
+	// ErrDimensionsTooLarge is returned when truncating a vector to more
+	// dimensions than it has.
+	ErrDimensionsTooLarge = errors.New("requested dimensions larger than vector size")
+	// ErrNegativeDimensions is returned when truncating a vector to a
+	// negative number of dimensions.
+	ErrNegativeDimensions = errors.New("requested dimensions are negative")
 )
 
+// TruncateVector keeps the first dimensions of a vector and renormalizes it to
+// unit length. Vectors that already have the requested number of dimensions
+// are returned unchanged.
+func TruncateVector(vector []float32, dimensions int) ([]float32, error) {
+	if dimensions < 0 {
+		return nil, fmt.Errorf("%w: %d", ErrNegativeDimensions, dimensions)
+	}
+	if dimensions > len(vector) {
+		return nil, fmt.Errorf("%w: %d > %d", ErrDimensionsTooLarge, dimensions, len(vector))
+	}
+	if dimensions == len(vector) {
+		return vector, nil
+	}
+
+	truncated := make([]float32, dimensions)
+	copy(truncated, vector)
+
+	norm := getNorm(truncated)
+	if norm == 0 {
+		return truncated, nil
+	}
+	for i := range truncated {
+		truncated[i] /= norm
+	}
+
+	return truncated, nil
+}
+
 func CombineVectors(vectors [][]float32, weights []int) ([]float32, error) {
 	average, err := getAverage(vectors, weights)
 	if err != nil {
@@ -82,3 +82,21 @@ func TestGetNorm(t *testing.T) {
 		assert.InEpsilon(t, tc.expected, getNorm(tc.vector), 0.0001)
 	}
 }
+
+func TestTruncateVector(t *testing.T) {
+	t.Parallel()
+
+	truncated, err := TruncateVector([]float32{3, 4, 12}, 2)
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float32{0.6, 0.8}, truncated, 1e-6)
+
+	unchanged, err := TruncateVector([]float32{1, 2}, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []float32{1, 2}, unchanged)
+
+	_, err = TruncateVector([]float32{1, 2}, 3)
+	require.ErrorIs(t, err, ErrDimensionsTooLarge)
+
+	_, err = TruncateVector([]float32{1, 2}, -1)
+	require.ErrorIs(t, err, ErrNegativeDimensions)
+}
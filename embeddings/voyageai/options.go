@@ -51,6 +51,15 @@ func WithBatchSize(batchSize int) Option {
 	}
 }
 
+// WithDimensions is an option for specifying the number of dimensions of the
+// created vectors. Only supported by models with flexible output dimensions,
+// such as voyage-3-large.
+func WithDimensions(dimensions int) Option {
+	return func(v *VoyageAI) {
+		v.Dimensions = dimensions
+	}
+}
+
 func applyOptions(opts ...Option) (*VoyageAI, error) {
 	o := &VoyageAI{
 		baseURL:       _defaultBaseURL,
@@ -10,7 +10,10 @@ import (
 	"github.com/tmc/langchaingo/embeddings"
 )
 
-var _ embeddings.Embedder = &VoyageAI{}
+var (
+	_ embeddings.Embedder    = &VoyageAI{}
+	_ embeddings.Dimensioner = &VoyageAI{}
+)
 
 // VoyageAI is the embedder using the VoyageAI api to create embeddings.
 type VoyageAI struct {
@@ -20,6 +23,7 @@ type VoyageAI struct {
 	Model         string
 	StripNewLines bool
 	BatchSize     int
+	Dimensions    int
 }
 
 // NewVoyageAI returns a new embedder that uses the VoyageAI api.
@@ -39,9 +43,10 @@ type embeddingResponse struct {
 }
 
 type embedDocumentsRequest struct {
-	Model     string   `json:"model"`
-	Input     []string `json:"input"`
-	InputType string   `json:"input_type"`
+	Model           string   `json:"model"`
+	Input           []string `json:"input"`
+	InputType       string   `json:"input_type"`
+	OutputDimension int      `json:"output_dimension,omitempty"`
 }
 
 // EmbedDocuments implements the `embeddings.Embedder` and creates an embedding for each of the texts.
@@ -54,9 +59,10 @@ func (v *VoyageAI) EmbedDocuments(ctx context.Context, texts []string) ([][]floa
 	embeddings := make([][]float32, 0, len(texts))
 	for _, batch := range batchedTexts {
 		req := embedDocumentsRequest{
-			Model:     v.Model,
-			Input:     batch,
-			InputType: "document",
+			Model:           v.Model,
+			Input:           batch,
+			InputType:       "document",
+			OutputDimension: v.Dimensions,
 		}
 
 		resp, err := v.request(ctx, "/embeddings", req)
@@ -82,17 +88,19 @@ func (v *VoyageAI) EmbedDocuments(ctx context.Context, texts []string) ([][]floa
 }
 
 type embedQueryRequest struct {
-	Model     string `json:"model"`
-	Input     string `json:"input"`
-	InputType string `json:"input_type"`
+	Model           string `json:"model"`
+	Input           string `json:"input"`
+	InputType       string `json:"input_type"`
+	OutputDimension int    `json:"output_dimension,omitempty"`
 }
 
 // EmbedQuery implements the `embeddings.Embedder` and creates an embedding for the query text.
 func (v *VoyageAI) EmbedQuery(ctx context.Context, text string) ([]float32, error) {
 	req := embedQueryRequest{
-		Model:     v.Model,
-		Input:     text,
-		InputType: "query",
+		Model:           v.Model,
+		Input:           text,
+		InputType:       "query",
+		OutputDimension: v.Dimensions,
 	}
 	resp, err := v.request(ctx, "/embeddings", req)
 	if err != nil {
@@ -111,6 +119,12 @@ func (v *VoyageAI) EmbedQuery(ctx context.Context, text string) ([]float32, erro
 	return embeddingResp.Data[0].Embedding, nil
 }
 
+// EmbeddingDimensions returns the number of dimensions set with
+// WithDimensions, or 0 if the model default is used.
+func (v *VoyageAI) EmbeddingDimensions() int {
+	return v.Dimensions
+}
+
 func (v *VoyageAI) request(ctx context.Context, path string, body any) (*http.Response, error) {
 	reqBody, err := json.Marshal(body)
 	if err != nil {
@@ -19,7 +19,6 @@ require (
 
 require (
 	cloud.google.com/go v0.116.0 // indirect
-	cloud.google.com/go/ai v0.7.0 // indirect
 	cloud.google.com/go/alloydb v1.13.0 // indirect
 	cloud.google.com/go/auth v0.11.0 // indirect
 	cloud.google.com/go/auth/oauth2adapt v0.2.6 // indirect
@@ -183,6 +182,7 @@ require (
 )
 
 require (
+	cloud.google.com/go/ai v0.7.0
 	cloud.google.com/go/aiplatform v1.69.0
 	cloud.google.com/go/alloydbconn v1.13.1
 	cloud.google.com/go/cloudsqlconn v1.13.2
@@ -207,6 +207,7 @@ require (
 	github.com/google/go-cmp v0.6.0
 	github.com/jackc/pgx/v5 v5.7.1
 	github.com/ledongthuc/pdf v0.0.0-20220302134840-0c2507a12d80
+	github.com/marcboeker/go-duckdb v1.5.6
 	github.com/mattn/go-sqlite3 v1.14.17
 	github.com/metaphorsystems/metaphor-go v0.0.0-20230816231421-43794c04824e
 	github.com/microcosm-cc/bluemonday v1.0.26
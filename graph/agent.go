@@ -0,0 +1,96 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+const (
+	// AgentNode is the name of the node planning with the agent in the graph
+	// built by NewAgentGraph.
+	AgentNode = "agent"
+	// ToolsNode is the name of the node running the actions of the agent in the
+	// graph built by NewAgentGraph.
+	ToolsNode = "tools"
+)
+
+// AgentState is the state of the graph built by NewAgentGraph.
+type AgentState struct {
+	// Inputs are the inputs of the agent.
+	Inputs map[string]string `json:"inputs"`
+	// Steps are the steps taken so far.
+	Steps []schema.AgentStep `json:"steps"`
+	// Actions are the actions of the last plan, until they are run.
+	Actions []schema.AgentAction `json:"actions,omitempty"`
+	// Finish is the finish of the agent, once it has finished.
+	Finish *schema.AgentFinish `json:"finish,omitempty"`
+}
+
+// NewAgentGraph returns a graph running an agent like agents.Executor: the
+// AgentNode plans the next actions, which the ToolsNode runs before planning
+// again, until the agent finishes. Each iteration of the agent takes two steps
+// of the recursion limit. The graph uses the Replace reducer, and can be
+// extended before being compiled, e.g. by replacing the edge from the
+// ToolsNode to the AgentNode.
+func NewAgentGraph(agent agents.Agent, opts ...Option[AgentState]) *Graph[AgentState] {
+	g := New(opts...)
+	g.AddNode(AgentNode, agentNode(agent))
+	g.AddNode(ToolsNode, toolsNode(agent.GetTools()))
+	g.AddEdge(Start, AgentNode)
+	g.AddConditionalEdge(AgentNode, func(_ context.Context, state AgentState) (string, error) {
+		if state.Finish != nil {
+			return End, nil
+		}
+		return ToolsNode, nil
+	})
+	g.AddEdge(ToolsNode, AgentNode)
+	return g
+}
+
+func agentNode(agent agents.Agent) NodeFunc[AgentState] {
+	return func(ctx context.Context, state AgentState) (AgentState, error) {
+		actions, finish, err := agent.Plan(ctx, state.Steps, state.Inputs)
+		if err != nil {
+			return state, err
+		}
+		if len(actions) == 0 && finish == nil {
+			return state, agents.ErrAgentNoReturn
+		}
+
+		state.Actions = actions
+		state.Finish = finish
+		return state, nil
+	}
+}
+
+func toolsNode(agentTools []tools.Tool) NodeFunc[AgentState] {
+	return func(ctx context.Context, state AgentState) (AgentState, error) {
+		steps := make([]schema.AgentStep, 0, len(state.Steps)+len(state.Actions))
+		steps = append(steps, state.Steps...)
+		for _, action := range state.Actions {
+			observation, err := callTool(ctx, agentTools, action)
+			if err != nil {
+				return state, err
+			}
+			steps = append(steps, schema.AgentStep{Action: action, Observation: observation})
+		}
+
+		state.Steps = steps
+		state.Actions = nil
+		return state, nil
+	}
+}
+
+func callTool(ctx context.Context, agentTools []tools.Tool, action schema.AgentAction) (string, error) {
+	for _, tool := range agentTools {
+		if strings.EqualFold(tool.Name(), action.Tool) {
+			return tool.Call(ctx, action.ToolInput)
+		}
+	}
+	return fmt.Sprintf("%s is not a valid tool, try another one", action.Tool), nil
+}
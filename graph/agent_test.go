@@ -0,0 +1,59 @@
+package graph_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/graph"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// searchAgent searches its input, then finishes with the observation.
+type searchAgent struct {
+	tools []tools.Tool
+}
+
+func (a *searchAgent) Plan(
+	_ context.Context,
+	intermediateSteps []schema.AgentStep,
+	inputs map[string]string,
+) ([]schema.AgentAction, *schema.AgentFinish, error) {
+	if len(intermediateSteps) == 0 {
+		return []schema.AgentAction{{Tool: "search", ToolInput: inputs["input"]}}, nil, nil
+	}
+	return nil, &schema.AgentFinish{
+		ReturnValues: map[string]any{"output": intermediateSteps[0].Observation},
+	}, nil
+}
+
+func (a *searchAgent) GetInputKeys() []string  { return []string{"input"} }
+func (a *searchAgent) GetOutputKeys() []string { return []string{"output"} }
+func (a *searchAgent) GetTools() []tools.Tool  { return a.tools }
+
+type upperTool struct{}
+
+func (upperTool) Name() string        { return "search" }
+func (upperTool) Description() string { return "search" }
+func (upperTool) Call(_ context.Context, input string) (string, error) {
+	return strings.ToUpper(input), nil
+}
+
+func TestAgentGraph(t *testing.T) {
+	t.Parallel()
+
+	g := graph.NewAgentGraph(&searchAgent{tools: []tools.Tool{upperTool{}}})
+	r, err := g.Compile()
+	require.NoError(t, err)
+
+	state, err := r.Invoke(context.Background(), graph.AgentState{
+		Inputs: map[string]string{"input": "golang"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, state.Finish)
+	require.Equal(t, "GOLANG", state.Finish.ReturnValues["output"])
+	require.Len(t, state.Steps, 1)
+	require.Empty(t, state.Actions)
+}
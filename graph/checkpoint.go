@@ -0,0 +1,93 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrCheckpointNotFound is returned when resuming a thread without checkpoints.
+	ErrCheckpointNotFound = errors.New("checkpoint not found")
+	// ErrNoCheckpointer is returned when resuming a thread of a graph without a
+	// checkpointer.
+	ErrNoCheckpointer = errors.New("graph has no checkpointer")
+)
+
+// Checkpoint is the state of a run of a graph, saved after each step.
+type Checkpoint[S any] struct {
+	ThreadID string `json:"thread_id"`
+	// Step is the number of steps of the run so far.
+	Step int `json:"step"`
+	// State is the state after the step.
+	State S `json:"state"`
+	// Next are the nodes of the next step. The run is finished if it is empty.
+	Next []string `json:"next,omitempty"`
+	// Joined are, for each join edge of the graph, the sources that have run
+	// since the target of the edge last ran.
+	Joined [][]string `json:"joined,omitempty"`
+	// CreatedAt is the time the checkpoint was saved.
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Finished reports whether the run finished at this checkpoint.
+func (c Checkpoint[S]) Finished() bool {
+	return len(c.Next) == 0
+}
+
+// Checkpointer persists the checkpoints of the runs of a graph.
+type Checkpointer[S any] interface {
+	// Put saves a checkpoint, setting its creation time.
+	Put(ctx context.Context, checkpoint *Checkpoint[S]) error
+	// Latest returns the most recent checkpoint of a thread, or
+	// ErrCheckpointNotFound.
+	Latest(ctx context.Context, threadID string) (*Checkpoint[S], error)
+}
+
+// MemoryCheckpointer is a Checkpointer keeping the checkpoints in memory. The
+// states are stored as they are, so nodes must not modify them in place.
+type MemoryCheckpointer[S any] struct {
+	mu      sync.Mutex
+	threads map[string][]Checkpoint[S]
+}
+
+var _ Checkpointer[any] = (*MemoryCheckpointer[any])(nil)
+
+// NewMemoryCheckpointer creates a new MemoryCheckpointer.
+func NewMemoryCheckpointer[S any]() *MemoryCheckpointer[S] {
+	return &MemoryCheckpointer[S]{
+		threads: make(map[string][]Checkpoint[S]),
+	}
+}
+
+// Put saves a checkpoint.
+func (c *MemoryCheckpointer[S]) Put(_ context.Context, checkpoint *Checkpoint[S]) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	checkpoint.CreatedAt = time.Now()
+	c.threads[checkpoint.ThreadID] = append(c.threads[checkpoint.ThreadID], *checkpoint)
+	return nil
+}
+
+// Latest returns the most recent checkpoint of a thread.
+func (c *MemoryCheckpointer[S]) Latest(_ context.Context, threadID string) (*Checkpoint[S], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	thread := c.threads[threadID]
+	if len(thread) == 0 {
+		return nil, ErrCheckpointNotFound
+	}
+	checkpoint := thread[len(thread)-1]
+	return &checkpoint, nil
+}
+
+// List returns the checkpoints of a thread, oldest first.
+func (c *MemoryCheckpointer[S]) List(_ context.Context, threadID string) ([]Checkpoint[S], error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return append([]Checkpoint[S]{}, c.threads[threadID]...), nil
+}
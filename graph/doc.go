@@ -0,0 +1,22 @@
+// Package graph contains a workflow engine running state graphs: the nodes of
+// a graph are functions updating a typed state, and edges, possibly
+// conditional, decide which nodes run next. Graphs can branch, loop and run
+// nodes in parallel, which chains and the agent executor cannot.
+//
+// A graph is built with New, AddNode and the Add...Edge methods, then
+// compiled into a Runnable. Runs are made of steps: all the nodes of a step
+// run in parallel on the state at the start of the step, and their updates
+// are merged into the state with the reducer of the graph, in the order the
+// nodes were added. Graphs running several nodes in a step need a reducer, set
+// with WithReducer. The nodes of the next step are the targets of the edges of
+// the nodes that ran, and the run ends when there are none left, e.g. when all
+// the edges lead to End.
+//
+// ChainNode and LLMNode make nodes from chains and models, and NewAgentGraph
+// builds a graph running an agent with its tools, which can be extended with
+// other nodes.
+//
+// With a Checkpointer, the state of a run with a thread ID is saved after each
+// step, and the run can be resumed from its last checkpoint. Runnable.Stream
+// sends the update of each node as the run progresses.
+package graph
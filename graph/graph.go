@@ -0,0 +1,188 @@
+package graph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	// Start is the virtual node runs start from. Edges from Start lead to the
+	// nodes of the first step.
+	Start = "__start__"
+	// End is the virtual node ending the branches of a run.
+	End = "__end__"
+)
+
+const _defaultRecursionLimit = 25
+
+var (
+	// ErrNoEntryPoint is returned when compiling a graph without edges from Start.
+	ErrNoEntryPoint = errors.New("graph has no entry point")
+	// ErrInvalidNodeName is returned when adding a node with an empty or reserved name.
+	ErrInvalidNodeName = errors.New("invalid node name")
+	// ErrDuplicateNode is returned when adding two nodes with the same name.
+	ErrDuplicateNode = errors.New("duplicate node")
+	// ErrDuplicateConditionalEdge is returned when adding two conditional edges
+	// from the same node.
+	ErrDuplicateConditionalEdge = errors.New("duplicate conditional edge")
+	// ErrUnknownNode is returned for edges, or routes, leading to a node not in the graph.
+	ErrUnknownNode = errors.New("unknown node")
+	// ErrNoOutgoingEdge is returned when compiling a graph with a node without outgoing
+	// edges. Nodes ending the run need an edge to End.
+	ErrNoOutgoingEdge = errors.New("node has no outgoing edge")
+	// ErrRecursionLimit is returned when a run does not end within the recursion limit
+	// of the graph.
+	ErrRecursionLimit = errors.New("graph recursion limit reached")
+	// ErrParallelUpdates is returned when several nodes run in the same step of
+	// a graph without a reducer: with Replace, all their updates but the last
+	// one would be lost.
+	ErrParallelUpdates = errors.New("parallel updates need a reducer")
+)
+
+// NodeFunc is the function of a node. It receives the state at the start of
+// the step and returns its update, merged into the state with the reducer of
+// the graph. The state must not be modified in place.
+type NodeFunc[S any] func(ctx context.Context, state S) (S, error)
+
+// RouteFunc is the function of a conditional edge. It receives the state once
+// the updates of the step are merged and returns the name of the next node, or
+// End.
+type RouteFunc[S any] func(ctx context.Context, state S) (string, error)
+
+type node[S any] struct {
+	name string
+	fn   NodeFunc[S]
+}
+
+// join is an edge leading to a node once all its sources have run.
+type join struct {
+	from []string
+	to   string
+}
+
+// Graph is a state graph being built. It is compiled into a Runnable to be run.
+type Graph[S any] struct {
+	nodes  []node[S]
+	edges  map[string][]string
+	routes map[string]RouteFunc[S]
+	joins  []join
+	errs   []error
+
+	reducer        Reducer[S] // Nil for Replace, set with WithReducer.
+	recursionLimit int
+	checkpointer   Checkpointer[S]
+}
+
+// New creates a new empty graph.
+func New[S any](opts ...Option[S]) *Graph[S] {
+	g := &Graph[S]{
+		edges:          make(map[string][]string),
+		routes:         make(map[string]RouteFunc[S]),
+		recursionLimit: _defaultRecursionLimit,
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// AddNode adds a node to the graph.
+func (g *Graph[S]) AddNode(name string, fn NodeFunc[S]) {
+	switch {
+	case name == "" || name == Start || name == End:
+		g.errs = append(g.errs, fmt.Errorf("%w: %q", ErrInvalidNodeName, name))
+	case g.hasNode(name):
+		g.errs = append(g.errs, fmt.Errorf("%w: %s", ErrDuplicateNode, name))
+	default:
+		g.nodes = append(g.nodes, node[S]{name: name, fn: fn})
+	}
+}
+
+// AddEdge adds an edge running the node to after the node from. A node with
+// several edges leads to several nodes running in parallel in the next step.
+func (g *Graph[S]) AddEdge(from, to string) {
+	g.edges[from] = append(g.edges[from], to)
+}
+
+// AddConditionalEdge adds an edge running the node returned by route after the
+// node from. A node has at most one conditional edge.
+func (g *Graph[S]) AddConditionalEdge(from string, route RouteFunc[S]) {
+	if _, ok := g.routes[from]; ok {
+		g.errs = append(g.errs, fmt.Errorf("%w: from %s", ErrDuplicateConditionalEdge, from))
+		return
+	}
+	g.routes[from] = route
+}
+
+// AddJoinEdge adds an edge running the node to once all the nodes from have run
+// since it last ran, e.g. to merge branches of different lengths.
+func (g *Graph[S]) AddJoinEdge(from []string, to string) {
+	g.joins = append(g.joins, join{from: from, to: to})
+}
+
+// Compile checks the graph and returns a Runnable running it.
+func (g *Graph[S]) Compile() (*Runnable[S], error) {
+	errs := append([]error{}, g.errs...)
+
+	checkTarget := func(from, to string) {
+		if to != End && !g.hasNode(to) {
+			errs = append(errs, fmt.Errorf("%w: %s, in edge from %s", ErrUnknownNode, to, from))
+		}
+	}
+	checkSource := func(from string) {
+		if from != Start && !g.hasNode(from) {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrUnknownNode, from))
+		}
+	}
+
+	for from, targets := range g.edges {
+		checkSource(from)
+		for _, to := range targets {
+			checkTarget(from, to)
+		}
+	}
+	for from := range g.routes {
+		checkSource(from)
+	}
+	outgoingJoins := make(map[string]bool)
+	for _, j := range g.joins {
+		for _, from := range j.from {
+			checkSource(from)
+			checkTarget(from, j.to)
+			outgoingJoins[from] = true
+		}
+	}
+
+	if len(g.edges[Start]) == 0 && g.routes[Start] == nil {
+		errs = append(errs, ErrNoEntryPoint)
+	}
+	for _, n := range g.nodes {
+		if len(g.edges[n.name]) == 0 && g.routes[n.name] == nil && !outgoingJoins[n.name] {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrNoOutgoingEdge, n.name))
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return &Runnable[S]{
+		nodes:          g.nodes,
+		edges:          g.edges,
+		routes:         g.routes,
+		joins:          g.joins,
+		reducer:        g.reducer,
+		recursionLimit: g.recursionLimit,
+		checkpointer:   g.checkpointer,
+	}, nil
+}
+
+func (g *Graph[S]) hasNode(name string) bool {
+	for _, n := range g.nodes {
+		if n.name == name {
+			return true
+		}
+	}
+	return false
+}
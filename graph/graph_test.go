@@ -0,0 +1,270 @@
+package graph_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/graph"
+	"github.com/tmc/langchaingo/llms"
+)
+
+type ragState struct {
+	Query     string
+	Documents []string
+	Rewrites  int
+	Log       []string
+}
+
+func ragReducer(state, update ragState) ragState {
+	update.Log = graph.Append(state.Log, update.Log)
+	return update
+}
+
+func TestGraphConditionalLoop(t *testing.T) {
+	t.Parallel()
+
+	g := graph.New(graph.WithReducer(ragReducer))
+	g.AddNode("retrieve", func(_ context.Context, s ragState) (ragState, error) {
+		s.Documents = nil
+		if strings.Contains(s.Query, "golang") {
+			s.Documents = []string{"Go is a programming language."}
+		}
+		s.Log = []string{"retrieve " + s.Query}
+		return s, nil
+	})
+	g.AddNode("rewrite", func(_ context.Context, s ragState) (ragState, error) {
+		s.Query = strings.ReplaceAll(s.Query, "go", "golang")
+		s.Rewrites++
+		s.Log = []string{"rewrite"}
+		return s, nil
+	})
+	g.AddEdge(graph.Start, "retrieve")
+	g.AddConditionalEdge("retrieve", func(_ context.Context, s ragState) (string, error) {
+		if len(s.Documents) > 0 || s.Rewrites > 0 {
+			return graph.End, nil
+		}
+		return "rewrite", nil
+	})
+	g.AddEdge("rewrite", "retrieve")
+
+	r, err := g.Compile()
+	require.NoError(t, err)
+
+	state, err := r.Invoke(context.Background(), ragState{Query: "what is go"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"Go is a programming language."}, state.Documents)
+	require.Equal(t, []string{"retrieve what is go", "rewrite", "retrieve what is golang"}, state.Log)
+}
+
+func TestGraphFanOutFanIn(t *testing.T) {
+	t.Parallel()
+
+	g := graph.New(graph.WithReducer(graph.MapReducer(map[string]func(current, update any) any{
+		"results": func(current, update any) any {
+			c, _ := current.([]string)
+			u, _ := update.([]string)
+			return graph.Append(c, u)
+		},
+	})))
+	node := func(result string) graph.NodeFunc[map[string]any] {
+		return func(_ context.Context, _ map[string]any) (map[string]any, error) {
+			return map[string]any{"results": []string{result}}, nil
+		}
+	}
+	g.AddNode("web", node("web"))
+	g.AddNode("docs", node("docs"))
+	g.AddNode("docs_rerank", node("docs_rerank"))
+	g.AddNode("summarize", func(_ context.Context, s map[string]any) (map[string]any, error) {
+		results, _ := s["results"].([]string)
+		return map[string]any{"summary": strings.Join(results, ",")}, nil
+	})
+	g.AddEdge(graph.Start, "web")
+	g.AddEdge(graph.Start, "docs")
+	g.AddEdge("docs", "docs_rerank")
+	// The branches have different lengths: summarize waits for both.
+	g.AddJoinEdge([]string{"web", "docs_rerank"}, "summarize")
+	g.AddEdge("summarize", graph.End)
+
+	r, err := g.Compile()
+	require.NoError(t, err)
+
+	var updates []string
+	var final map[string]any
+	for event := range r.Stream(context.Background(), map[string]any{}) {
+		switch event.Type {
+		case graph.EventNodeUpdate:
+			updates = append(updates, event.Node)
+		case graph.EventDone:
+			final = event.State
+		case graph.EventError:
+			require.NoError(t, event.Err)
+		}
+	}
+
+	require.Equal(t, []string{"web", "docs", "docs_rerank", "summarize"}, updates)
+	require.Equal(t, "web,docs,docs_rerank", final["summary"])
+}
+
+func TestGraphFanOutWithoutReducer(t *testing.T) {
+	t.Parallel()
+
+	ran := 0
+	add := func(n int) graph.NodeFunc[int] {
+		return func(_ context.Context, state int) (int, error) {
+			ran++
+			return state + n, nil
+		}
+	}
+	g := graph.New[int]()
+	g.AddNode("a", add(1))
+	g.AddNode("b", add(2))
+	g.AddEdge(graph.Start, "a")
+	g.AddEdge(graph.Start, "b")
+	g.AddEdge("a", graph.End)
+	g.AddEdge("b", graph.End)
+	r, err := g.Compile()
+	require.NoError(t, err)
+
+	_, err = r.Invoke(context.Background(), 0)
+	require.ErrorIs(t, err, graph.ErrParallelUpdates)
+	require.ErrorContains(t, err, "a, b run in step 1")
+	require.Zero(t, ran)
+}
+
+func TestGraphRecursionLimit(t *testing.T) {
+	t.Parallel()
+
+	g := graph.New(graph.WithRecursionLimit[int](5))
+	g.AddNode("inc", func(_ context.Context, n int) (int, error) {
+		return n + 1, nil
+	})
+	g.AddEdge(graph.Start, "inc")
+	g.AddEdge("inc", "inc")
+
+	r, err := g.Compile()
+	require.NoError(t, err)
+
+	n, err := r.Invoke(context.Background(), 0)
+	require.ErrorIs(t, err, graph.ErrRecursionLimit)
+	require.Equal(t, 5, n)
+}
+
+func TestGraphCompileErrors(t *testing.T) {
+	t.Parallel()
+
+	noop := func(_ context.Context, n int) (int, error) { return n, nil }
+
+	g := graph.New[int]()
+	g.AddNode("a", noop)
+	g.AddNode("a", noop)
+	g.AddNode(graph.End, noop)
+	g.AddNode("b", noop)
+	g.AddEdge("a", "c")
+	_, err := g.Compile()
+	require.ErrorIs(t, err, graph.ErrDuplicateNode)
+	require.ErrorIs(t, err, graph.ErrInvalidNodeName)
+	require.ErrorIs(t, err, graph.ErrUnknownNode)
+	require.ErrorIs(t, err, graph.ErrNoEntryPoint)
+	require.ErrorIs(t, err, graph.ErrNoOutgoingEdge)
+
+	route := func(_ context.Context, _ int) (string, error) { return graph.End, nil }
+	g = graph.New[int]()
+	g.AddNode("a", noop)
+	g.AddEdge(graph.Start, "a")
+	g.AddConditionalEdge("a", route)
+	g.AddConditionalEdge("a", route)
+	_, err = g.Compile()
+	require.ErrorIs(t, err, graph.ErrDuplicateConditionalEdge)
+}
+
+func TestGraphCheckpointResume(t *testing.T) {
+	t.Parallel()
+
+	errFlaky := errors.New("flaky")
+	failed := false
+	calls := 0
+
+	checkpointer := graph.NewMemoryCheckpointer[[]string]()
+	g := graph.New(
+		graph.WithReducer(graph.Append[string]),
+		graph.WithCheckpointer[[]string](checkpointer),
+	)
+	g.AddNode("first", func(_ context.Context, _ []string) ([]string, error) {
+		calls++
+		return []string{"first"}, nil
+	})
+	g.AddNode("second", func(_ context.Context, _ []string) ([]string, error) {
+		if !failed {
+			failed = true
+			return nil, errFlaky
+		}
+		return []string{"second"}, nil
+	})
+	g.AddEdge(graph.Start, "first")
+	g.AddEdge("first", "second")
+	g.AddEdge("second", graph.End)
+
+	r, err := g.Compile()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	_, err = r.Invoke(ctx, nil, graph.WithThreadID("thread-1"))
+	require.ErrorIs(t, err, errFlaky)
+
+	latest, err := checkpointer.Latest(ctx, "thread-1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"second"}, latest.Next)
+
+	state, err := r.Resume(ctx, "thread-1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "second"}, state)
+	require.Equal(t, 1, calls)
+
+	latest, err = checkpointer.Latest(ctx, "thread-1")
+	require.NoError(t, err)
+	require.True(t, latest.Finished())
+
+	_, err = r.Resume(ctx, "thread-2")
+	require.ErrorIs(t, err, graph.ErrCheckpointNotFound)
+}
+
+type fakeLLM struct{}
+
+func (fakeLLM) GenerateContent(
+	_ context.Context,
+	messages []llms.MessageContent,
+	_ ...llms.CallOption,
+) (*llms.ContentResponse, error) {
+	text, _ := messages[0].Parts[0].(llms.TextContent)
+	return &llms.ContentResponse{Choices: []*llms.ContentChoice{{Content: strings.ToUpper(text.Text)}}}, nil
+}
+
+func (fakeLLM) Call(context.Context, string, ...llms.CallOption) (string, error) {
+	return "", nil
+}
+
+func TestLLMNode(t *testing.T) {
+	t.Parallel()
+
+	g := graph.New[string]()
+	g.AddNode("shout", graph.LLMNode(fakeLLM{},
+		func(s string) []llms.MessageContent {
+			return []llms.MessageContent{llms.TextParts(llms.ChatMessageTypeHuman, s)}
+		},
+		func(_ string, resp *llms.ContentResponse) (string, error) {
+			return resp.Choices[0].Content, nil
+		},
+	))
+	g.AddEdge(graph.Start, "shout")
+	g.AddEdge("shout", graph.End)
+
+	r, err := g.Compile()
+	require.NoError(t, err)
+
+	state, err := r.Invoke(context.Background(), "hello")
+	require.NoError(t, err)
+	require.Equal(t, "HELLO", state)
+}
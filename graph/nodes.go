@@ -0,0 +1,44 @@
+package graph
+
+import (
+	"context"
+
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms"
+)
+
+// ChainNode returns a node calling a chain with chains.Call. The inputs of the
+// chain are built from the state with inputs, and its outputs are turned into
+// the update of the node with update.
+func ChainNode[S any](
+	chain chains.Chain,
+	inputs func(state S) map[string]any,
+	update func(state S, outputs map[string]any) (S, error),
+	options ...chains.ChainCallOption,
+) NodeFunc[S] {
+	return func(ctx context.Context, state S) (S, error) {
+		outputs, err := chains.Call(ctx, chain, inputs(state), options...)
+		if err != nil {
+			return state, err
+		}
+		return update(state, outputs)
+	}
+}
+
+// LLMNode returns a node calling a model. The messages sent to the model are
+// built from the state with messages, and its response is turned into the
+// update of the node with update.
+func LLMNode[S any](
+	model llms.Model,
+	messages func(state S) []llms.MessageContent,
+	update func(state S, resp *llms.ContentResponse) (S, error),
+	options ...llms.CallOption,
+) NodeFunc[S] {
+	return func(ctx context.Context, state S) (S, error) {
+		resp, err := model.GenerateContent(ctx, messages(state), options...)
+		if err != nil {
+			return state, err
+		}
+		return update(state, resp)
+	}
+}
@@ -0,0 +1,43 @@
+package graph
+
+// Option is a function for configuring a graph.
+type Option[S any] func(*Graph[S])
+
+// WithReducer sets the function merging the updates of the nodes into the
+// state. Defaults to Replace, and is required for graphs running several nodes
+// in the same step.
+func WithReducer[S any](reducer Reducer[S]) Option[S] {
+	return func(g *Graph[S]) {
+		g.reducer = reducer
+	}
+}
+
+// WithRecursionLimit sets the maximum number of steps of a run. Defaults to 25.
+func WithRecursionLimit[S any](limit int) Option[S] {
+	return func(g *Graph[S]) {
+		g.recursionLimit = limit
+	}
+}
+
+// WithCheckpointer sets the checkpointer saving the state of the runs with a
+// thread ID after each step.
+func WithCheckpointer[S any](checkpointer Checkpointer[S]) Option[S] {
+	return func(g *Graph[S]) {
+		g.checkpointer = checkpointer
+	}
+}
+
+// RunOption is a function for configuring a run of a graph.
+type RunOption func(*runOptions)
+
+type runOptions struct {
+	threadID string
+}
+
+// WithThreadID makes the run save its checkpoints under the given thread ID,
+// so that it can be resumed with Runnable.Resume.
+func WithThreadID(threadID string) RunOption {
+	return func(o *runOptions) {
+		o.threadID = threadID
+	}
+}
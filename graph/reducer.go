@@ -0,0 +1,39 @@
+package graph
+
+// Reducer merges the update of a node into the state.
+type Reducer[S any] func(state, update S) S
+
+// Replace is the default reducer: the update of a node replaces the state, so
+// nodes return the whole new state.
+func Replace[S any](_, update S) S {
+	return update
+}
+
+// Append returns the concatenation of two slices, without modifying them. It
+// is a building block for reducers of states with fields accumulating values,
+// e.g. messages.
+func Append[T any](current, update []T) []T {
+	res := make([]T, 0, len(current)+len(update))
+	res = append(res, current...)
+	return append(res, update...)
+}
+
+// MapReducer returns a reducer for map states. The keys of an update replace
+// the keys of the state, or are merged with the function of the key in
+// reducers if it has one.
+func MapReducer(reducers map[string]func(current, update any) any) Reducer[map[string]any] {
+	return func(state, update map[string]any) map[string]any {
+		res := make(map[string]any, len(state)+len(update))
+		for key, value := range state {
+			res[key] = value
+		}
+		for key, value := range update {
+			current, ok := res[key]
+			if reduce := reducers[key]; ok && reduce != nil {
+				value = reduce(current, value)
+			}
+			res[key] = value
+		}
+		return res
+	}
+}
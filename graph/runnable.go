@@ -0,0 +1,331 @@
+package graph
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Runnable is a compiled graph. It is safe for concurrent use if its nodes
+// are.
+type Runnable[S any] struct {
+	nodes  []node[S]
+	edges  map[string][]string
+	routes map[string]RouteFunc[S]
+	joins  []join
+
+	reducer        Reducer[S]
+	recursionLimit int
+	checkpointer   Checkpointer[S]
+}
+
+// EventType is the type of an event of a streamed run.
+type EventType string
+
+const (
+	// EventNodeUpdate is sent once the update of a node is merged into the state.
+	EventNodeUpdate EventType = "node_update"
+	// EventDone is sent with the final state of a successful run. It is the
+	// last event of the run.
+	EventDone EventType = "done"
+	// EventError is sent if the run fails. It is the last event of the run.
+	EventError EventType = "error"
+)
+
+// Event is an event of a run streamed with Runnable.Stream.
+type Event[S any] struct {
+	Type EventType
+	// Step is the step of the run the event belongs to.
+	Step int
+	// Node is the node of an EventNodeUpdate.
+	Node string
+	// Update is the update of the node of an EventNodeUpdate.
+	Update S
+	// State is the state after the update for an EventNodeUpdate, or the
+	// final state for an EventDone.
+	State S
+	// Err is the error of an EventError.
+	Err error
+}
+
+// runState is the state of a run, saved in its checkpoints.
+type runState[S any] struct {
+	threadID string
+	step     int
+	state    S
+	next     []string
+	joined   [][]string
+}
+
+// Invoke runs the graph from the input state and returns the final state.
+func (r *Runnable[S]) Invoke(ctx context.Context, input S, opts ...RunOption) (S, error) {
+	return r.invoke(ctx, input, nil, opts...)
+}
+
+// Stream runs the graph like Invoke, sending the events of the run to the
+// returned channel as they happen. The channel is closed after the done or
+// error event. The caller must read the channel until it is closed, or cancel
+// the context.
+func (r *Runnable[S]) Stream(ctx context.Context, input S, opts ...RunOption) <-chan Event[S] {
+	return r.stream(ctx, func(emit func(Event[S])) (S, error) {
+		return r.invoke(ctx, input, emit, opts...)
+	})
+}
+
+// Resume continues the run of a thread from its latest checkpoint, e.g. after
+// a node failed, and returns the final state. The state of a finished run is
+// returned as is.
+func (r *Runnable[S]) Resume(ctx context.Context, threadID string) (S, error) {
+	return r.resume(ctx, threadID, nil)
+}
+
+// ResumeStream continues the run of a thread like Resume, sending the events
+// of the run to the returned channel like Stream.
+func (r *Runnable[S]) ResumeStream(ctx context.Context, threadID string) <-chan Event[S] {
+	return r.stream(ctx, func(emit func(Event[S])) (S, error) {
+		return r.resume(ctx, threadID, emit)
+	})
+}
+
+func (r *Runnable[S]) invoke(ctx context.Context, input S, emit func(Event[S]), opts ...RunOption) (S, error) {
+	var options runOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	rs := &runState[S]{
+		threadID: options.threadID,
+		state:    input,
+		joined:   make([][]string, len(r.joins)),
+	}
+	next, err := r.nextNodes(ctx, rs, []string{Start})
+	if err != nil {
+		return rs.state, err
+	}
+	rs.next = next
+	if err := r.checkpoint(ctx, rs); err != nil {
+		return rs.state, err
+	}
+
+	return r.run(ctx, rs, emit)
+}
+
+func (r *Runnable[S]) resume(ctx context.Context, threadID string, emit func(Event[S])) (S, error) {
+	var zero S
+	if r.checkpointer == nil {
+		return zero, ErrNoCheckpointer
+	}
+	checkpoint, err := r.checkpointer.Latest(ctx, threadID)
+	if err != nil {
+		return zero, err
+	}
+
+	rs := &runState[S]{
+		threadID: threadID,
+		step:     checkpoint.Step,
+		state:    checkpoint.State,
+		next:     checkpoint.Next,
+		joined:   make([][]string, len(r.joins)),
+	}
+	if len(checkpoint.Joined) == len(r.joins) {
+		for i, names := range checkpoint.Joined {
+			rs.joined[i] = slices.Clone(names)
+		}
+	}
+	return r.run(ctx, rs, emit)
+}
+
+func (r *Runnable[S]) stream(ctx context.Context, run func(emit func(Event[S])) (S, error)) <-chan Event[S] {
+	events := make(chan Event[S])
+	send := func(event Event[S]) {
+		select {
+		case events <- event:
+		case <-ctx.Done():
+		}
+	}
+
+	go func() {
+		defer close(events)
+
+		state, err := run(send)
+		if err != nil {
+			send(Event[S]{Type: EventError, State: state, Err: err})
+			return
+		}
+		send(Event[S]{Type: EventDone, State: state})
+	}()
+	return events
+}
+
+// run runs the steps of the run until no nodes are left.
+func (r *Runnable[S]) run(ctx context.Context, rs *runState[S], emit func(Event[S])) (S, error) {
+	for len(rs.next) > 0 {
+		if rs.step >= r.recursionLimit {
+			return rs.state, fmt.Errorf("%w: %d steps", ErrRecursionLimit, r.recursionLimit)
+		}
+		rs.step++
+
+		reducer := r.reducer
+		if reducer == nil {
+			if len(rs.next) > 1 {
+				return rs.state, fmt.Errorf("%w: %s run in step %d, set one with WithReducer",
+					ErrParallelUpdates, strings.Join(rs.next, ", "), rs.step)
+			}
+			reducer = Replace[S]
+		}
+
+		updates, err := r.runNodes(ctx, rs.next, rs.state)
+		if err != nil {
+			return rs.state, err
+		}
+		for i, name := range rs.next {
+			rs.state = reducer(rs.state, updates[i])
+			if emit != nil {
+				emit(Event[S]{Type: EventNodeUpdate, Step: rs.step, Node: name, Update: updates[i], State: rs.state})
+			}
+		}
+
+		next, err := r.nextNodes(ctx, rs, rs.next)
+		if err != nil {
+			return rs.state, err
+		}
+		rs.next = next
+
+		if err := r.checkpoint(ctx, rs); err != nil {
+			return rs.state, err
+		}
+	}
+
+	return rs.state, nil
+}
+
+// runNodes runs the nodes of a step in parallel and returns their updates in
+// the order of the nodes. If a node fails, the context of the other nodes is
+// canceled and the first error is returned.
+func (r *Runnable[S]) runNodes(ctx context.Context, names []string, state S) ([]S, error) {
+	updates := make([]S, len(names))
+	if len(names) == 1 {
+		update, err := r.runNode(ctx, names[0], state)
+		updates[0] = update
+		return updates, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+
+			update, err := r.runNode(ctx, name, state)
+			if err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+				return
+			}
+			updates[i] = update
+		}(i, name)
+	}
+	wg.Wait()
+
+	return updates, firstErr
+}
+
+func (r *Runnable[S]) runNode(ctx context.Context, name string, state S) (S, error) {
+	for _, n := range r.nodes {
+		if n.name == name {
+			update, err := n.fn(ctx, state)
+			if err != nil {
+				return update, fmt.Errorf("node %s: %w", name, err)
+			}
+			return update, nil
+		}
+	}
+	return state, fmt.Errorf("%w: %s", ErrUnknownNode, name)
+}
+
+// nextNodes returns the nodes to run after the given ones, in the order the
+// nodes were added to the graph.
+func (r *Runnable[S]) nextNodes(ctx context.Context, rs *runState[S], ran []string) ([]string, error) {
+	targets := make(map[string]bool)
+	for _, name := range ran {
+		for _, to := range r.edges[name] {
+			targets[to] = true
+		}
+
+		if route := r.routes[name]; route != nil {
+			to, err := route(ctx, rs.state)
+			if err != nil {
+				return nil, fmt.Errorf("route from %s: %w", name, err)
+			}
+			if to != End && !r.hasNode(to) {
+				return nil, fmt.Errorf("%w: %s, routed from %s", ErrUnknownNode, to, name)
+			}
+			targets[to] = true
+		}
+
+		for i, j := range r.joins {
+			if slices.Contains(j.from, name) && !slices.Contains(rs.joined[i], name) {
+				rs.joined[i] = append(rs.joined[i], name)
+			}
+		}
+	}
+
+	for i, j := range r.joins {
+		if len(rs.joined[i]) == len(j.from) {
+			rs.joined[i] = nil
+			targets[j.to] = true
+		}
+	}
+
+	next := make([]string, 0, len(targets))
+	for _, n := range r.nodes {
+		if targets[n.name] {
+			next = append(next, n.name)
+		}
+	}
+	return next, nil
+}
+
+// checkpoint saves the state of the run, if the graph has a checkpointer and
+// the run a thread ID.
+func (r *Runnable[S]) checkpoint(ctx context.Context, rs *runState[S]) error {
+	if r.checkpointer == nil || rs.threadID == "" {
+		return nil
+	}
+
+	joined := make([][]string, len(rs.joined))
+	for i, names := range rs.joined {
+		joined[i] = slices.Clone(names)
+	}
+	err := r.checkpointer.Put(ctx, &Checkpoint[S]{
+		ThreadID: rs.threadID,
+		Step:     rs.step,
+		State:    rs.state,
+		Next:     slices.Clone(rs.next),
+		Joined:   joined,
+	})
+	if err != nil {
+		return fmt.Errorf("saving checkpoint: %w", err)
+	}
+	return nil
+}
+
+func (r *Runnable[S]) hasNode(name string) bool {
+	for _, n := range r.nodes {
+		if n.name == name {
+			return true
+		}
+	}
+	return false
+}
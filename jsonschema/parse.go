@@ -0,0 +1,112 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+// Parse parses a JSON schema, keeping the keywords a Definition can describe.
+// See FromMap.
+func Parse(data []byte) (*Definition, error) {
+	var schema map[string]any
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return nil, err
+	}
+	def := FromMap(schema)
+	return &def, nil
+}
+
+// FromMap converts a JSON schema, as decoded by encoding/json, to a
+// Definition. Keywords a Definition cannot describe are dropped: a type given
+// as a list, such as ["string", "null"], keeps its first non null type, the
+// schemas of allOf are merged, and anyOf and oneOf keep their first schema.
+// References ($ref) must be resolved beforehand.
+func FromMap(schema map[string]any) Definition {
+	var def Definition
+	for _, key := range []string{"anyOf", "oneOf"} {
+		if options, ok := schema[key].([]any); ok && len(options) > 0 {
+			if first, ok := options[0].(map[string]any); ok {
+				def = FromMap(first)
+			}
+		}
+	}
+	if all, ok := schema["allOf"].([]any); ok {
+		for _, s := range all {
+			if sub, ok := s.(map[string]any); ok {
+				def = merge(def, FromMap(sub))
+			}
+		}
+	}
+
+	switch typ := schema["type"].(type) {
+	case string:
+		def.Type = DataType(typ)
+	case []any:
+		for _, t := range typ {
+			if s, ok := t.(string); ok && s != string(Null) {
+				def.Type = DataType(s)
+				break
+			}
+		}
+	}
+	if description, ok := schema["description"].(string); ok {
+		def.Description = description
+	}
+	if enum, ok := schema["enum"].([]any); ok {
+		def.Enum = nil
+		for _, value := range enum {
+			def.Enum = append(def.Enum, fmt.Sprint(value))
+		}
+	}
+	if properties, ok := schema["properties"].(map[string]any); ok && len(properties) > 0 {
+		if def.Properties == nil {
+			def.Properties = make(map[string]Definition, len(properties))
+		}
+		for name, prop := range properties {
+			if propSchema, ok := prop.(map[string]any); ok {
+				def.Properties[name] = FromMap(propSchema)
+			}
+		}
+	}
+	if required, ok := schema["required"].([]any); ok {
+		for _, name := range required {
+			if s, ok := name.(string); ok && !slices.Contains(def.Required, s) {
+				def.Required = append(def.Required, s)
+			}
+		}
+	}
+	if items, ok := schema["items"].(map[string]any); ok {
+		itemsDef := FromMap(items)
+		def.Items = &itemsDef
+	}
+	return def
+}
+
+// merge merges the properties of b into a, as for allOf.
+func merge(a, b Definition) Definition {
+	if b.Type != "" {
+		a.Type = b.Type
+	}
+	if b.Description != "" {
+		a.Description = b.Description
+	}
+	if b.Enum != nil {
+		a.Enum = b.Enum
+	}
+	if b.Items != nil {
+		a.Items = b.Items
+	}
+	if len(b.Properties) > 0 && a.Properties == nil {
+		a.Properties = make(map[string]Definition, len(b.Properties))
+	}
+	for name, prop := range b.Properties {
+		a.Properties[name] = prop
+	}
+	for _, name := range b.Required {
+		if !slices.Contains(a.Required, name) {
+			a.Required = append(a.Required, name)
+		}
+	}
+	return a
+}
@@ -0,0 +1,47 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	got, err := jsonschema.Parse([]byte(`{
+		"type": "object",
+		"properties": {
+			"name": {"type": ["string", "null"], "description": "The name.", "format": "hostname"},
+			"size": {"enum": [1, 2, 3]},
+			"pet": {"allOf": [
+				{"type": "object", "properties": {"kind": {"type": "string"}}, "required": ["kind"]},
+				{"properties": {"age": {"type": "integer"}}}
+			]},
+			"tags": {"type": "array", "items": {"oneOf": [{"type": "string"}, {"type": "number"}]}}
+		},
+		"required": ["name"]
+	}`))
+	require.NoError(t, err)
+	require.Equal(t, &jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"name": {Type: jsonschema.String, Description: "The name."},
+			"size": {Enum: []string{"1", "2", "3"}},
+			"pet": {
+				Type: jsonschema.Object,
+				Properties: map[string]jsonschema.Definition{
+					"kind": {Type: jsonschema.String},
+					"age":  {Type: jsonschema.Integer},
+				},
+				Required: []string{"kind"},
+			},
+			"tags": {Type: jsonschema.Array, Items: &jsonschema.Definition{Type: jsonschema.String}},
+		},
+		Required: []string{"name"},
+	}, got)
+
+	_, err = jsonschema.Parse([]byte(`[]`))
+	require.Error(t, err)
+}
@@ -0,0 +1,141 @@
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// ErrUnsupportedType is returned when a JSON schema cannot be generated for a
+// Go type, e.g. a channel or a function.
+var ErrUnsupportedType = errors.New("unsupported type")
+
+// GenerateSchemaForType generates the JSON schema of the type of v, following
+// the rules of encoding/json. Struct fields can be described with tags:
+//
+//   - `json:"name,omitempty"` sets the name of the property, which is required
+//     unless it has the omitempty option or is a pointer.
+//   - `description:"..."` sets the description of the property.
+//   - `enum:"a,b,c"` restricts the values of the property.
+//   - `required:"true"` or `required:"false"` overrides whether the property
+//     is required.
+func GenerateSchemaForType(v any) (*Definition, error) {
+	return reflectSchema(reflect.TypeOf(v), nil)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+func reflectSchema(t reflect.Type, seen []reflect.Type) (*Definition, error) {
+	if t == nil {
+		return &Definition{}, nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t == timeType {
+		return &Definition{Type: String}, nil
+	}
+
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.String:
+		return &Definition{Type: String}, nil
+	case reflect.Bool:
+		return &Definition{Type: Boolean}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Definition{Type: Integer}, nil
+	case reflect.Float32, reflect.Float64:
+		return &Definition{Type: Number}, nil
+	case reflect.Interface:
+		return &Definition{}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// encoding/json encodes []byte as a base64 string.
+			return &Definition{Type: String}, nil
+		}
+		items, err := reflectSchema(t.Elem(), seen)
+		if err != nil {
+			return nil, err
+		}
+		return &Definition{Type: Array, Items: items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%w: %s, map keys must be strings", ErrUnsupportedType, t)
+		}
+		return &Definition{Type: Object}, nil
+	case reflect.Struct:
+		for _, s := range seen {
+			if s == t {
+				return nil, fmt.Errorf("%w: %s is recursive", ErrUnsupportedType, t)
+			}
+		}
+		return reflectStruct(t, append(seen, t))
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedType, t)
+	}
+}
+
+func reflectStruct(t reflect.Type, seen []reflect.Type) (*Definition, error) {
+	d := &Definition{
+		Type:       Object,
+		Properties: make(map[string]Definition),
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		name, opts, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" && opts == "" {
+			continue
+		}
+
+		// Fields of embedded structs are promoted, as with encoding/json.
+		if field.Anonymous && name == "" {
+			ft := field.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				embedded, err := reflectSchema(ft, seen)
+				if err != nil {
+					return nil, err
+				}
+				for propName, prop := range embedded.Properties {
+					d.Properties[propName] = prop
+				}
+				d.Required = append(d.Required, embedded.Required...)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		prop, err := reflectSchema(field.Type, seen)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		prop.Description = field.Tag.Get("description")
+		if enum := field.Tag.Get("enum"); enum != "" {
+			prop.Enum = strings.Split(enum, ",")
+		}
+		d.Properties[name] = *prop
+
+		required := !strings.Contains(opts, "omitempty") && field.Type.Kind() != reflect.Pointer
+		switch field.Tag.Get("required") {
+		case "true":
+			required = true
+		case "false":
+			required = false
+		}
+		if required {
+			d.Required = append(d.Required, name)
+		}
+	}
+
+	return d, nil
+}
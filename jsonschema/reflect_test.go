@@ -0,0 +1,77 @@
+package jsonschema_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+type address struct {
+	City    string `json:"city" description:"The city."`
+	Country string `json:"country,omitempty"`
+}
+
+type weatherArgs struct {
+	address
+	Unit     string            `json:"unit" enum:"celsius,fahrenheit"`
+	Days     int               `json:"days" required:"false"`
+	Detailed *bool             `json:"detailed"`
+	Tags     []string          `json:"tags,omitempty"`
+	Extra    map[string]string `json:"extra,omitempty"`
+	Ignored  string            `json:"-"`
+	internal string
+}
+
+func TestGenerateSchemaForType(t *testing.T) {
+	t.Parallel()
+
+	got, err := jsonschema.GenerateSchemaForType(weatherArgs{})
+	require.NoError(t, err)
+
+	want := &jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"city":     {Type: jsonschema.String, Description: "The city."},
+			"country":  {Type: jsonschema.String},
+			"unit":     {Type: jsonschema.String, Enum: []string{"celsius", "fahrenheit"}},
+			"days":     {Type: jsonschema.Integer},
+			"detailed": {Type: jsonschema.Boolean},
+			"tags":     {Type: jsonschema.Array, Items: &jsonschema.Definition{Type: jsonschema.String}},
+			"extra":    {Type: jsonschema.Object},
+		},
+		Required: []string{"city", "unit"},
+	}
+	assert.Equal(t, want, got)
+
+	_, err = jsonschema.GenerateSchemaForType(struct{ C chan int }{})
+	require.ErrorIs(t, err, jsonschema.ErrUnsupportedType)
+}
+
+func TestVerifySchemaAndUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	schema, err := jsonschema.GenerateSchemaForType(weatherArgs{})
+	require.NoError(t, err)
+
+	var args weatherArgs
+	err = jsonschema.VerifySchemaAndUnmarshal(*schema, []byte(`{"city":"Paris","unit":"celsius","days":3}`), &args)
+	require.NoError(t, err)
+	assert.Equal(t, "Paris", args.City)
+	assert.Equal(t, 3, args.Days)
+
+	tests := map[string]string{
+		"not json":         `{`,
+		"not an object":    `[]`,
+		"missing required": `{"unit":"celsius"}`,
+		"wrong type":       `{"city":1,"unit":"celsius"}`,
+		"not in enum":      `{"city":"Paris","unit":"kelvin"}`,
+		"not an integer":   `{"city":"Paris","unit":"celsius","days":1.5}`,
+		"wrong item type":  `{"city":"Paris","unit":"celsius","tags":[1]}`,
+	}
+	for name, content := range tests {
+		err := jsonschema.VerifySchemaAndUnmarshal(*schema, []byte(content), &args)
+		require.ErrorIs(t, err, jsonschema.ErrInvalidValue, name)
+	}
+}
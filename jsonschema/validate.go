@@ -0,0 +1,104 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// ErrInvalidValue is returned when a value does not match a schema.
+var ErrInvalidValue = errors.New("value does not match schema")
+
+// Validate checks that the value, as decoded by encoding/json into an any,
+// matches the schema. A schema without a type matches any value.
+func (d Definition) Validate(v any) error {
+	return d.validate("", v)
+}
+
+// VerifySchemaAndUnmarshal validates the JSON content against the schema and
+// then unmarshals it into v.
+func VerifySchemaAndUnmarshal(schema Definition, content []byte, v any) error {
+	var data any
+	if err := json.Unmarshal(content, &data); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidValue, err)
+	}
+	if err := schema.Validate(data); err != nil {
+		return err
+	}
+	return json.Unmarshal(content, v)
+}
+
+func (d Definition) validate(path string, v any) error { //nolint:cyclop
+	if path == "" {
+		path = "value"
+	}
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("%w: %s %s", ErrInvalidValue, path, fmt.Sprintf(format, args...))
+	}
+
+	switch d.Type {
+	case "":
+	case Object:
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return invalid("must be an object")
+		}
+		for _, name := range d.Required {
+			if _, ok := obj[name]; !ok {
+				return invalid("is missing the required property %q", name)
+			}
+		}
+		for name, prop := range d.Properties {
+			value, ok := obj[name]
+			if !ok || (value == nil && !slices.Contains(d.Required, name)) {
+				continue
+			}
+			if err := prop.validate(path+"."+name, value); err != nil {
+				return err
+			}
+		}
+	case Array:
+		arr, ok := v.([]any)
+		if !ok {
+			return invalid("must be an array")
+		}
+		if d.Items != nil {
+			for i, item := range arr {
+				if err := d.Items.validate(fmt.Sprintf("%s[%d]", path, i), item); err != nil {
+					return err
+				}
+			}
+		}
+	case String:
+		s, ok := v.(string)
+		if !ok {
+			return invalid("must be a string")
+		}
+		if len(d.Enum) > 0 && !slices.Contains(d.Enum, s) {
+			return invalid("must be one of %q", d.Enum)
+		}
+	case Number:
+		if _, ok := v.(float64); !ok {
+			return invalid("must be a number")
+		}
+	case Integer:
+		f, ok := v.(float64)
+		if !ok || f != math.Trunc(f) {
+			return invalid("must be an integer")
+		}
+	case Boolean:
+		if _, ok := v.(bool); !ok {
+			return invalid("must be a boolean")
+		}
+	case Null:
+		if v != nil {
+			return invalid("must be null")
+		}
+	default:
+		return invalid("has unknown type %q", d.Type)
+	}
+
+	return nil
+}
@@ -3,31 +3,61 @@ package googleai
 import (
 	"context"
 	"fmt"
+	"strings"
 
-	"github.com/google/generative-ai-go/genai"
+	pb "cloud.google.com/go/ai/generativelanguage/apiv1beta/generativelanguagepb"
+	"github.com/tmc/langchaingo/embeddings"
 )
 
+// _maxEmbeddingBatchSize is the maximum number of documents of a request to
+// the Gemini Embedding Batch API.
+const _maxEmbeddingBatchSize = 100
+
+var _ embeddings.DimensionsEmbedderClient = &GoogleAI{}
+
 // CreateEmbedding creates embeddings from texts.
 func (g *GoogleAI) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
-	em := g.client.EmbeddingModel(g.opts.DefaultEmbeddingModel)
+	return g.CreateEmbeddingWithDimensions(ctx, texts, 0)
+}
 
-	results := make([][]float32, 0, len(texts))
+// CreateEmbeddingWithDimensions creates embeddings from texts, with the given
+// number of dimensions. The embedding model reduces the dimensions itself, so
+// it must support it, as text-embedding-004 does. A dimensions value of 0
+// keeps the default size of the model.
+func (g *GoogleAI) CreateEmbeddingWithDimensions(ctx context.Context, texts []string, dimensions int) ([][]float32, error) { //nolint:lll
+	model := g.opts.DefaultEmbeddingModel
+	if !strings.ContainsRune(model, '/') {
+		model = "models/" + model
+	}
+	var outputDimensionality *int32
+	if dimensions > 0 {
+		d := int32(dimensions)
+		outputDimensionality = &d
+	}
 
-	batch := em.NewBatch()
+	results := make([][]float32, 0, len(texts))
+	req := &pb.BatchEmbedContentsRequest{Model: model}
 	for i, t := range texts {
-		batch = batch.AddContent(genai.Text(t))
+		req.Requests = append(req.Requests, &pb.EmbedContentRequest{
+			Model: model,
+			Content: &pb.Content{
+				Role:  "user",
+				Parts: []*pb.Part{{Data: &pb.Part_Text{Text: t}}},
+			},
+			OutputDimensionality: outputDimensionality,
+		})
 		// The Gemini Embedding Batch API allows up to 100 documents per batch,
 		// so send a request every 100 documents and when we hit the
 		// last document.
-		if (i > 0 && (i+1)%100 == 0) || i == len(texts)-1 {
-			embeddings, err := em.BatchEmbedContents(ctx, batch)
+		if len(req.Requests) == _maxEmbeddingBatchSize || i == len(texts)-1 {
+			res, err := g.embeddingClient.BatchEmbedContents(ctx, req)
 			if err != nil {
 				return nil, fmt.Errorf("failed to create embeddings: %w", err)
 			}
-			for _, e := range embeddings.Embeddings {
-				results = append(results, e.Values)
+			for _, e := range res.GetEmbeddings() {
+				results = append(results, e.GetValues())
 			}
-			batch = em.NewBatch()
+			req = &pb.BatchEmbedContentsRequest{Model: model}
 		}
 	}
 
@@ -0,0 +1,58 @@
+package googleai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/option"
+)
+
+func TestCreateEmbeddingWithDimensions(t *testing.T) {
+	t.Parallel()
+
+	type request struct {
+		Model                string `json:"model"`
+		OutputDimensionality int    `json:"outputDimensionality"`
+	}
+	var requests []request
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Requests []request `json:"requests"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		requests = append(requests, body.Requests...)
+		embeddings := make([]map[string]any, len(body.Requests))
+		for i := range embeddings {
+			embeddings[i] = map[string]any{"values": []float32{0.6, 0.8}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"embeddings": embeddings})
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	llm, err := New(ctx, WithAPIKey("test"), WithDefaultEmbeddingModel("text-embedding-004"),
+		func(o *Options) {
+			o.ClientOptions = append(o.ClientOptions, option.WithEndpoint(server.URL))
+		})
+	require.NoError(t, err)
+
+	vectors, err := llm.CreateEmbeddingWithDimensions(ctx, []string{"a", "b"}, 2)
+	require.NoError(t, err)
+	require.Equal(t, [][]float32{{0.6, 0.8}, {0.6, 0.8}}, vectors)
+	require.Equal(t, []request{
+		{Model: "models/text-embedding-004", OutputDimensionality: 2},
+		{Model: "models/text-embedding-004", OutputDimensionality: 2},
+	}, requests)
+
+	requests = nil
+	_, err = llm.CreateEmbedding(ctx, []string{"a"})
+	require.NoError(t, err)
+	require.Equal(t, []request{{Model: "models/text-embedding-004"}}, requests)
+}
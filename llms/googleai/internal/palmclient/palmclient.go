@@ -2,6 +2,7 @@ package palmclient
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"runtime"
@@ -28,9 +29,10 @@ var defaultParameters = map[string]interface{}{ //nolint:gochecknoglobals
 }
 
 const (
-	embeddingModelName = "textembedding-gecko"
-	TextModelName      = "text-bison"
-	ChatModelName      = "chat-bison"
+	embeddingModelName           = "textembedding-gecko"
+	multimodalEmbeddingModelName = "multimodalembedding@001"
+	TextModelName                = "text-bison"
+	ChatModelName                = "chat-bison"
 
 	defaultMaxConns = 4
 )
@@ -134,23 +136,88 @@ func (c *PaLMClient) CreateEmbedding(ctx context.Context, r *EmbeddingRequest) (
 		if !ok {
 			return nil, fmt.Errorf("%w: %v", ErrMissingValue, "values")
 		}
-		floatValues := []float32{}
-		for _, v := range values {
-			val, ok := v.(float32)
-			if !ok {
-				valF64, ok := v.(float64)
-				if !ok {
-					return nil, fmt.Errorf("%w: %v is not a float64 or float32, it is a %T", ErrInvalidValue, "value", v)
-				}
-				val = float32(valF64)
+		floatValues, err := toFloat32s(values)
+		if err != nil {
+			return nil, err
+		}
+		embeddings = append(embeddings, floatValues)
+	}
+	return embeddings, nil
+}
+
+// MultimodalEmbeddingInstance is a text or an image to embed with the
+// multimodal embedding model. Exactly one of the fields is set.
+type MultimodalEmbeddingInstance struct {
+	Text        string
+	ImageBytes  []byte
+	ImageGCSURI string
+}
+
+// CreateMultimodalEmbedding creates embeddings of texts and images in the same
+// vector space.
+func (c *PaLMClient) CreateMultimodalEmbedding(ctx context.Context, instances []MultimodalEmbeddingInstance) ([][]float32, error) { //nolint:lll
+	embeddings := make([][]float32, 0, len(instances))
+	// The multimodal embedding model only accepts one instance per request.
+	for _, instance := range instances {
+		fields := map[string]interface{}{}
+		key := "textEmbedding"
+		switch {
+		case len(instance.ImageBytes) > 0:
+			fields["image"] = map[string]interface{}{
+				"bytesBase64Encoded": base64.StdEncoding.EncodeToString(instance.ImageBytes),
 			}
-			floatValues = append(floatValues, val)
+			key = "imageEmbedding"
+		case instance.ImageGCSURI != "":
+			fields["image"] = map[string]interface{}{"gcsUri": instance.ImageGCSURI}
+			key = "imageEmbedding"
+		default:
+			fields["text"] = instance.Text
+		}
+
+		content, err := structpb.NewStruct(fields)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.client.Predict(ctx, &aiplatformpb.PredictRequest{
+			Endpoint:  c.projectLocationPublisherModelPath(c.projectID, "us-central1", "google", multimodalEmbeddingModelName),
+			Instances: []*structpb.Value{structpb.NewStructValue(content)},
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(resp.GetPredictions()) == 0 {
+			return nil, ErrEmptyResponse
+		}
+
+		values, ok := resp.GetPredictions()[0].GetStructValue().AsMap()[key].([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %v", ErrMissingValue, key)
+		}
+		floatValues, err := toFloat32s(values)
+		if err != nil {
+			return nil, err
 		}
 		embeddings = append(embeddings, floatValues)
 	}
 	return embeddings, nil
 }
 
+func toFloat32s(values []interface{}) ([]float32, error) {
+	floatValues := make([]float32, 0, len(values))
+	for _, v := range values {
+		val, ok := v.(float32)
+		if !ok {
+			valF64, ok := v.(float64)
+			if !ok {
+				return nil, fmt.Errorf("%w: %v is not a float64 or float32, it is a %T", ErrInvalidValue, "value", v)
+			}
+			val = float32(valF64)
+		}
+		floatValues = append(floatValues, val)
+	}
+	return floatValues, nil
+}
+
 // ChatRequest is a request to create an embedding.
 type ChatRequest struct {
 	Context        string         `json:"context"`
@@ -5,6 +5,7 @@ package googleai
 import (
 	"context"
 
+	gl "cloud.google.com/go/ai/generativelanguage/apiv1beta"
 	"github.com/google/generative-ai-go/genai"
 	"github.com/tmc/langchaingo/callbacks"
 	"github.com/tmc/langchaingo/llms"
@@ -15,6 +16,10 @@ type GoogleAI struct {
 	CallbacksHandler callbacks.Handler
 	client           *genai.Client
 	opts             Options
+
+	// embeddingClient is used for embeddings, as the genai client doesn't
+	// support setting their output dimensionality.
+	embeddingClient *gl.GenerativeClient
 }
 
 var _ llms.Model = &GoogleAI{}
@@ -37,5 +42,12 @@ func New(ctx context.Context, opts ...Option) (*GoogleAI, error) {
 	}
 
 	gi.client = client
+
+	embeddingClient, err := gl.NewGenerativeRESTClient(ctx, clientOptions.ClientOptions...)
+	if err != nil {
+		return gi, err
+	}
+
+	gi.embeddingClient = embeddingClient
 	return gi, nil
 }
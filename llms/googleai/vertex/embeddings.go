@@ -4,10 +4,15 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/internal/imageutil"
 	"github.com/tmc/langchaingo/llms/googleai/internal/palmclient"
 )
 
+var _ embeddings.MultimodalEmbedderClient = &Vertex{}
+
 // CreateEmbedding creates embeddings from texts.
 func (g *Vertex) CreateEmbedding(ctx context.Context, texts []string) ([][]float32, error) {
 	embeddings, err := g.palmClient.CreateEmbedding(ctx, &palmclient.EmbeddingRequest{
@@ -26,3 +31,41 @@ func (g *Vertex) CreateEmbedding(ctx context.Context, texts []string) ([][]float
 
 	return embeddings, nil
 }
+
+// CreateMultimodalEmbedding creates embeddings from texts and images in the
+// same vector space, using the Vertex AI multimodal embedding model. Image
+// URLs with the "gs://" scheme are read by Vertex AI from Cloud Storage, other
+// URLs are downloaded first.
+func (g *Vertex) CreateMultimodalEmbedding(ctx context.Context, inputs []embeddings.MultimodalInput) ([][]float32, error) { //nolint:lll
+	instances := make([]palmclient.MultimodalEmbeddingInstance, 0, len(inputs))
+	for _, input := range inputs {
+		var instance palmclient.MultimodalEmbeddingInstance
+		switch {
+		case input.Image == nil:
+			instance.Text = input.Text
+		case len(input.Image.Data) > 0:
+			instance.ImageBytes = input.Image.Data
+		case strings.HasPrefix(input.Image.URL, "gs://"):
+			instance.ImageGCSURI = input.Image.URL
+		case input.Image.URL != "":
+			_, data, err := imageutil.DownloadImageData(input.Image.URL)
+			if err != nil {
+				return nil, err
+			}
+			instance.ImageBytes = data
+		default:
+			return nil, embeddings.ErrInvalidImage
+		}
+		instances = append(instances, instance)
+	}
+
+	vectors, err := g.palmClient.CreateMultimodalEmbedding(ctx, instances)
+	if err != nil {
+		return nil, err
+	}
+	if len(inputs) != len(vectors) {
+		return vectors, fmt.Errorf("returned %d embeddings for %d inputs", len(vectors), len(inputs))
+	}
+
+	return vectors, nil
+}
@@ -287,6 +287,7 @@ func generateFromMessages(
 	opts *llms.CallOptions,
 ) (*llms.ContentResponse, error) {
 	history := make([]*genai.Content, 0, len(messages))
+	var prevRole llms.ChatMessageType
 	for _, mc := range messages {
 		content, err := convertContent(mc)
 		if err != nil {
@@ -296,7 +297,14 @@ func generateFromMessages(
 			model.SystemInstruction = content
 			continue
 		}
+		// The responses to all the function calls of a turn must be in the
+		// same content.
+		if n := len(history); n > 0 && mc.Role == llms.ChatMessageTypeTool && prevRole == llms.ChatMessageTypeTool {
+			history[n-1].Parts = append(history[n-1].Parts, content.Parts...)
+			continue
+		}
 		history = append(history, content)
+		prevRole = mc.Role
 	}
 
 	// Given N total messages, genai's chat expects the first N-1 messages as
@@ -389,75 +397,114 @@ func convertTools(tools []llms.Tool) ([]*genai.Tool, error) {
 		}
 
 		// Expect the Parameters field to be a map[string]any, from which we will
-		// extract properties to populate the schema.
+		// extract properties to populate the schema. Other values, e.g. a
+		// jsonschema.Definition, are converted to a map through JSON.
 		params, ok := tool.Function.Parameters.(map[string]any)
 		if !ok {
-			return nil, fmt.Errorf("tool [%d]: unsupported type %T of Parameters", i, tool.Function.Parameters)
+			b, err := json.Marshal(tool.Function.Parameters)
+			if err != nil {
+				return nil, fmt.Errorf("tool [%d]: unsupported type %T of Parameters", i, tool.Function.Parameters)
+			}
+			if err := json.Unmarshal(b, &params); err != nil {
+				return nil, fmt.Errorf("tool [%d]: unsupported type %T of Parameters", i, tool.Function.Parameters)
+			}
 		}
 
-		schema := &genai.Schema{}
-		if ty, ok := params["type"]; ok {
-			tyString, ok := ty.(string)
-			if !ok {
-				return nil, fmt.Errorf("tool [%d]: expected string for type", i)
-			}
-			schema.Type = convertToolSchemaType(tyString)
+		if _, ok := params["properties"].(map[string]any); !ok {
+			return nil, fmt.Errorf("tool [%d]: expected to find a map of properties", i)
+		}
+		schema, err := convertToolSchema(params)
+		if err != nil {
+			return nil, fmt.Errorf("tool [%d]: %w", i, err)
 		}
+		genaiFuncDecl.Parameters = schema
 
-		paramProperties, ok := params["properties"].(map[string]any)
+		genaiTools = append(genaiTools, &genai.Tool{
+			FunctionDeclarations: []*genai.FunctionDeclaration{genaiFuncDecl},
+		})
+	}
+
+	return genaiTools, nil
+}
+
+// convertToolSchema converts a JSON schema, decoded as a map, to a genai schema.
+func convertToolSchema(params map[string]any) (*genai.Schema, error) {
+	schema := &genai.Schema{}
+	if ty, ok := params["type"]; ok {
+		tyString, ok := ty.(string)
 		if !ok {
-			return nil, fmt.Errorf("tool [%d]: expected to find a map of properties", i)
+			return nil, errors.New("expected string for type")
 		}
+		schema.Type = convertToolSchemaType(tyString)
+	}
+	if desc, ok := params["description"]; ok {
+		descString, ok := desc.(string)
+		if !ok {
+			return nil, errors.New("expected string for description")
+		}
+		schema.Description = descString
+	}
 
+	if enum, ok := params["enum"]; ok {
+		values, err := toStrings(enum)
+		if err != nil {
+			return nil, fmt.Errorf("enum: %w", err)
+		}
+		schema.Enum = values
+	}
+
+	if paramProperties, ok := params["properties"].(map[string]any); ok && len(paramProperties) > 0 {
 		schema.Properties = make(map[string]*genai.Schema)
 		for propName, propValue := range paramProperties {
 			valueMap, ok := propValue.(map[string]any)
 			if !ok {
-				return nil, fmt.Errorf("tool [%d], property [%v]: expect to find a value map", i, propName)
-			}
-			schema.Properties[propName] = &genai.Schema{}
-
-			if ty, ok := valueMap["type"]; ok {
-				tyString, ok := ty.(string)
-				if !ok {
-					return nil, fmt.Errorf("tool [%d]: expected string for type", i)
-				}
-				schema.Properties[propName].Type = convertToolSchemaType(tyString)
+				return nil, fmt.Errorf("property [%v]: expect to find a value map", propName)
 			}
-			if desc, ok := valueMap["description"]; ok {
-				descString, ok := desc.(string)
-				if !ok {
-					return nil, fmt.Errorf("tool [%d]: expected string for description", i)
-				}
-				schema.Properties[propName].Description = descString
+			prop, err := convertToolSchema(valueMap)
+			if err != nil {
+				return nil, fmt.Errorf("property [%v]: %w", propName, err)
 			}
+			schema.Properties[propName] = prop
 		}
+	}
 
-		if required, ok := params["required"]; ok {
-			if rs, ok := required.([]string); ok {
-				schema.Required = rs
-			} else if ri, ok := required.([]interface{}); ok {
-				rs := make([]string, 0, len(ri))
-				for _, r := range ri {
-					rString, ok := r.(string)
-					if !ok {
-						return nil, fmt.Errorf("tool [%d]: expected string for required", i)
-					}
-					rs = append(rs, rString)
-				}
-				schema.Required = rs
-			} else {
-				return nil, fmt.Errorf("tool [%d]: expected string for required", i)
-			}
+	if items, ok := params["items"].(map[string]any); ok {
+		itemsSchema, err := convertToolSchema(items)
+		if err != nil {
+			return nil, fmt.Errorf("items: %w", err)
 		}
-		genaiFuncDecl.Parameters = schema
+		schema.Items = itemsSchema
+	}
 
-		genaiTools = append(genaiTools, &genai.Tool{
-			FunctionDeclarations: []*genai.FunctionDeclaration{genaiFuncDecl},
-		})
+	if required, ok := params["required"]; ok {
+		rs, err := toStrings(required)
+		if err != nil {
+			return nil, fmt.Errorf("required: %w", err)
+		}
+		schema.Required = rs
 	}
 
-	return genaiTools, nil
+	return schema, nil
+}
+
+// toStrings converts a []string, or a []any of strings, to a []string.
+func toStrings(v any) ([]string, error) {
+	if rs, ok := v.([]string); ok {
+		return rs, nil
+	}
+	ri, ok := v.([]interface{})
+	if !ok {
+		return nil, errors.New("expected a list of strings")
+	}
+	rs := make([]string, 0, len(ri))
+	for _, r := range ri {
+		rString, ok := r.(string)
+		if !ok {
+			return nil, errors.New("expected a list of strings")
+		}
+		rs = append(rs, rString)
+	}
+	return rs, nil
 }
 
 // convertToolSchemaType converts a tool's schema type from its langchaingo
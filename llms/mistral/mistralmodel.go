@@ -242,6 +242,7 @@ func generateStreamingContent(ctx context.Context, m *Model, callOptions *llms.C
 func convertToMistralChatMessages(langchainMessages []llms.MessageContent) ([]sdk.ChatMessage, error) {
 	messages := make([]sdk.ChatMessage, 0)
 	for _, msg := range langchainMessages {
+		prevPartIsToolCall := false
 		for _, part := range msg.Parts {
 			switch p := part.(type) {
 			case llms.TextContent:
@@ -251,16 +252,23 @@ func convertToMistralChatMessages(langchainMessages []llms.MessageContent) ([]sd
 					messages = append(messages, chatMsg)
 				}
 			case llms.ToolCallResponse:
-				chatMsg := sdk.ChatMessage{Role: string(msg.Role), Content: p.Content}
+				chatMsg := sdk.ChatMessage{Role: string(msg.Role), Name: p.Name, Content: p.Content}
 				setMistralChatMessageRole(&msg, &chatMsg) // #nosec G601
 				messages = append(messages, chatMsg)
 			case llms.ToolCall:
-				chatMsg := sdk.ChatMessage{Role: string(msg.Role), ToolCalls: []sdk.ToolCall{{Id: p.ID, Type: sdk.ToolTypeFunction, Function: sdk.FunctionCall{Name: p.FunctionCall.Name, Arguments: p.FunctionCall.Arguments}}}}
+				toolCall := sdk.ToolCall{Id: p.ID, Type: sdk.ToolTypeFunction, Function: sdk.FunctionCall{Name: p.FunctionCall.Name, Arguments: p.FunctionCall.Arguments}}
+				// All the tool calls of a message go in the same assistant message.
+				if n := len(messages); n > 0 && prevPartIsToolCall {
+					messages[n-1].ToolCalls = append(messages[n-1].ToolCalls, toolCall)
+					break
+				}
+				chatMsg := sdk.ChatMessage{Role: string(msg.Role), ToolCalls: []sdk.ToolCall{toolCall}}
 				setMistralChatMessageRole(&msg, &chatMsg) // #nosec G601
 				messages = append(messages, chatMsg)
 			default:
 				return nil, errors.New("unsupported content type encountered while preparing chat messages to send to mistral platform")
 			}
+			_, prevPartIsToolCall = part.(llms.ToolCall)
 		}
 	}
 	return messages, nil
@@ -96,8 +96,9 @@ func (c *Client) CreateCompletion(ctx context.Context, r *CompletionRequest) (*C
 
 // EmbeddingRequest is a request to create an embedding.
 type EmbeddingRequest struct {
-	Model string   `json:"model"`
-	Input []string `json:"input"`
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
 }
 
 // CreateEmbedding creates embeddings.
@@ -107,8 +108,9 @@ func (c *Client) CreateEmbedding(ctx context.Context, r *EmbeddingRequest) ([][]
 	}
 
 	resp, err := c.createEmbedding(ctx, &embeddingPayload{
-		Model: r.Model,
-		Input: r.Input,
+		Model:      r.Model,
+		Input:      r.Input,
+		Dimensions: r.Dimensions,
 	})
 	if err != nil {
 		return nil, err
@@ -194,9 +194,17 @@ func (o *LLM) GenerateContent(ctx context.Context, messages []llms.MessageConten
 
 // CreateEmbedding creates embeddings for the given input texts.
 func (o *LLM) CreateEmbedding(ctx context.Context, inputTexts []string) ([][]float32, error) {
+	return o.CreateEmbeddingWithDimensions(ctx, inputTexts, 0)
+}
+
+// CreateEmbeddingWithDimensions creates embeddings for the given input texts
+// with the given number of dimensions. Only supported by text-embedding-3 and
+// later models. Zero uses the default size of the model.
+func (o *LLM) CreateEmbeddingWithDimensions(ctx context.Context, inputTexts []string, dimensions int) ([][]float32, error) { //nolint:lll
 	embeddings, err := o.client.CreateEmbedding(ctx, &openaiclient.EmbeddingRequest{
-		Input: inputTexts,
-		Model: o.client.EmbeddingModel,
+		Input:      inputTexts,
+		Model:      o.client.EmbeddingModel,
+		Dimensions: dimensions,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to create openai embeddings: %w", err)
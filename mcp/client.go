@@ -0,0 +1,337 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strconv"
+	"sync"
+)
+
+var (
+	// ErrUnsupportedProtocolVersion is returned when a server only supports
+	// versions of the protocol this package does not.
+	ErrUnsupportedProtocolVersion = errors.New("mcp: unsupported protocol version")
+	// ErrClientClosed is returned when using a closed client, or a client
+	// whose connection was closed by the server.
+	ErrClientClosed = errors.New("mcp: client closed")
+)
+
+// Client is a client of an MCP server. It lists and calls the tools of the
+// server, and reads its resources and prompts. Tools returns the tools of the
+// server as tools.Tool, to give to agents.
+//
+// A Client is safe for concurrent use.
+type Client struct {
+	transport Transport
+	opts      options
+	info      InitializeResult
+
+	mu      sync.Mutex
+	nextID  int64
+	pending map[string]chan *message
+	done    chan struct{}
+	err     error
+}
+
+// Connect connects to a server over the transport, and initializes the
+// connection.
+func Connect(ctx context.Context, transport Transport, opts ...Option) (*Client, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	c := &Client{
+		transport: transport,
+		opts:      o,
+		pending:   make(map[string]chan *message),
+		done:      make(chan struct{}),
+	}
+	go c.readLoop()
+
+	if err := c.initialize(ctx); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// ConnectCommand runs the server command and connects to it over its stdin and
+// stdout.
+func ConnectCommand(ctx context.Context, cmd *exec.Cmd, opts ...Option) (*Client, error) {
+	transport, err := NewCommandTransport(cmd)
+	if err != nil {
+		return nil, err
+	}
+	return Connect(ctx, transport, opts...)
+}
+
+// ConnectHTTP connects to the server at the endpoint url with the streamable
+// HTTP transport. The HTTP client and the headers of the requests can be set
+// with WithHTTPClient and WithHeader.
+func ConnectHTTP(ctx context.Context, url string, opts ...Option) (*Client, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return Connect(ctx, NewHTTPTransport(url, o.httpClient, o.header), opts...)
+}
+
+func (c *Client) initialize(ctx context.Context) error {
+	params := initializeParams{ProtocolVersion: ProtocolVersion, ClientInfo: c.opts.clientInfo}
+	if err := c.call(ctx, "initialize", params, &c.info); err != nil {
+		return fmt.Errorf("mcp: initializing: %w", err)
+	}
+	if !slices.Contains(_supportedProtocolVersions, c.info.ProtocolVersion) {
+		return fmt.Errorf("%w: %s", ErrUnsupportedProtocolVersion, c.info.ProtocolVersion)
+	}
+	if t, ok := c.transport.(*HTTPTransport); ok {
+		t.setProtocolVersion(c.info.ProtocolVersion)
+	}
+	return c.notify(ctx, "notifications/initialized", nil)
+}
+
+// ServerInfo returns the answer of the server to the initialization: its
+// name, version, capabilities and instructions.
+func (c *Client) ServerInfo() InitializeResult {
+	return c.info
+}
+
+// ListTools returns the tools of the server.
+func (c *Client) ListTools(ctx context.Context) ([]ToolInfo, error) {
+	var tools []ToolInfo
+	err := c.list(ctx, "tools/list", func(raw json.RawMessage) (string, error) {
+		var result listToolsResult
+		err := json.Unmarshal(raw, &result)
+		tools = append(tools, result.Tools...)
+		return result.NextCursor, err
+	})
+	return tools, err
+}
+
+// CallTool calls a tool of the server with the JSON object of its arguments.
+// A tool failing is not an error of CallTool: the result then has IsError set.
+func (c *Client) CallTool(ctx context.Context, name string, arguments json.RawMessage) (*CallToolResult, error) {
+	var result CallToolResult
+	if err := c.call(ctx, "tools/call", callToolParams{Name: name, Arguments: arguments}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListResources returns the resources of the server.
+func (c *Client) ListResources(ctx context.Context) ([]Resource, error) {
+	var resources []Resource
+	err := c.list(ctx, "resources/list", func(raw json.RawMessage) (string, error) {
+		var result listResourcesResult
+		err := json.Unmarshal(raw, &result)
+		resources = append(resources, result.Resources...)
+		return result.NextCursor, err
+	})
+	return resources, err
+}
+
+// ReadResource returns the contents of the resource with the URI.
+func (c *Client) ReadResource(ctx context.Context, uri string) ([]ResourceContents, error) {
+	var result readResourceResult
+	if err := c.call(ctx, "resources/read", readResourceParams{URI: uri}, &result); err != nil {
+		return nil, err
+	}
+	return result.Contents, nil
+}
+
+// ListPrompts returns the prompt templates of the server.
+func (c *Client) ListPrompts(ctx context.Context) ([]Prompt, error) {
+	var prompts []Prompt
+	err := c.list(ctx, "prompts/list", func(raw json.RawMessage) (string, error) {
+		var result listPromptsResult
+		err := json.Unmarshal(raw, &result)
+		prompts = append(prompts, result.Prompts...)
+		return result.NextCursor, err
+	})
+	return prompts, err
+}
+
+// GetPrompt returns the prompt template with the name, filled with the
+// arguments.
+func (c *Client) GetPrompt(ctx context.Context, name string, arguments map[string]string) (*GetPromptResult, error) {
+	var result GetPromptResult
+	if err := c.call(ctx, "prompts/get", getPromptParams{Name: name, Arguments: arguments}, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Ping checks that the server is alive.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.call(ctx, "ping", nil, nil)
+}
+
+// Close closes the connection to the server.
+func (c *Client) Close() error {
+	return c.transport.Close()
+}
+
+// list calls a paginated list method until the last page, giving the result
+// of each page to the page function, which returns the next cursor.
+func (c *Client) list(ctx context.Context, method string, page func(json.RawMessage) (string, error)) error {
+	cursor := ""
+	for {
+		var raw json.RawMessage
+		if err := c.call(ctx, method, listParams{Cursor: cursor}, &raw); err != nil {
+			return err
+		}
+		next, err := page(raw)
+		if err != nil {
+			return fmt.Errorf("mcp: decoding %s result: %w", method, err)
+		}
+		if next == "" {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// call sends a request and decodes the result of its response into result.
+func (c *Client) call(ctx context.Context, method string, params, result any) error {
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return c.err
+	}
+	c.nextID++
+	id := json.RawMessage(strconv.FormatInt(c.nextID, 10))
+	responses := make(chan *message, 1)
+	c.pending[string(id)] = responses
+	c.mu.Unlock()
+
+	defer func() {
+		c.mu.Lock()
+		delete(c.pending, string(id))
+		c.mu.Unlock()
+	}()
+
+	if err := c.send(ctx, &message{ID: id, Method: method}, params); err != nil {
+		return err
+	}
+
+	select {
+	case resp := <-responses:
+		if resp.Error != nil {
+			return resp.Error
+		}
+		if result == nil {
+			return nil
+		}
+		if err := json.Unmarshal(resp.Result, result); err != nil {
+			return fmt.Errorf("mcp: decoding %s result: %w", method, err)
+		}
+		return nil
+	case <-c.done:
+		return c.closedErr()
+	case <-ctx.Done():
+		_ = c.notify(context.Background(), "notifications/cancelled", cancelledParams{
+			RequestID: id,
+			Reason:    ctx.Err().Error(),
+		})
+		return ctx.Err()
+	}
+}
+
+// notify sends a notification.
+func (c *Client) notify(ctx context.Context, method string, params any) error {
+	return c.send(ctx, &message{Method: method}, params)
+}
+
+func (c *Client) send(ctx context.Context, msg *message, params any) error {
+	msg.JSONRPC = "2.0"
+	if params != nil {
+		raw, err := json.Marshal(params)
+		if err != nil {
+			return err
+		}
+		msg.Params = raw
+	}
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return c.transport.Send(ctx, raw)
+}
+
+func (c *Client) closedErr() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// readLoop dispatches the messages of the server until the connection is
+// closed.
+func (c *Client) readLoop() {
+	defer close(c.done)
+	for {
+		raw, err := c.transport.Receive(context.Background())
+		if err != nil {
+			c.mu.Lock()
+			c.err = fmt.Errorf("%w: %w", ErrClientClosed, err)
+			c.mu.Unlock()
+			return
+		}
+
+		var messages []*message
+		if len(raw) > 0 && raw[0] == '[' {
+			err = json.Unmarshal(raw, &messages)
+		} else {
+			var msg message
+			err = json.Unmarshal(raw, &msg)
+			messages = []*message{&msg}
+		}
+		if err != nil {
+			continue
+		}
+		for _, msg := range messages {
+			c.dispatch(msg)
+		}
+	}
+}
+
+func (c *Client) dispatch(msg *message) {
+	switch {
+	case msg.isRequest():
+		go c.answer(msg)
+	case msg.isNotification():
+		if c.opts.notificationHandler != nil {
+			c.opts.notificationHandler(msg.Method, msg.Params)
+		}
+	default:
+		// The request is removed from the pending ones before sending its
+		// response, so that a duplicate or late response is dropped instead of
+		// blocking the reading of the messages.
+		c.mu.Lock()
+		responses, ok := c.pending[string(msg.ID)]
+		delete(c.pending, string(msg.ID))
+		c.mu.Unlock()
+		if ok {
+			responses <- msg
+		}
+	}
+}
+
+// answer answers the requests of the server. Only pings are supported.
+func (c *Client) answer(req *message) {
+	resp := &message{JSONRPC: "2.0", ID: req.ID}
+	if req.Method == "ping" {
+		resp.Result = json.RawMessage("{}")
+	} else {
+		resp.Error = &RPCError{Code: CodeMethodNotFound, Message: "method not found: " + req.Method}
+	}
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+	_ = c.transport.Send(context.Background(), raw)
+}
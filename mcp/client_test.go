@@ -0,0 +1,183 @@
+package mcp_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/mcp"
+	"github.com/tmc/langchaingo/mcp/internal/mcptest"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// buildTestServer builds the stdio test server binary.
+func buildTestServer(t *testing.T) string {
+	t.Helper()
+	bin := filepath.Join(t.TempDir(), "testserver")
+	out, err := exec.Command("go", "build", "-o", bin, "./internal/mcptest/testserver").CombinedOutput()
+	require.NoError(t, err, string(out))
+	return bin
+}
+
+func TestStdioClient(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, err := mcp.ConnectCommand(ctx, exec.Command(buildTestServer(t)))
+	require.NoError(t, err)
+	defer client.Close()
+
+	info := client.ServerInfo()
+	require.Equal(t, "mcptest", info.ServerInfo.Name)
+	require.Equal(t, "Use the tools to test.", info.Instructions)
+	require.NotNil(t, info.Capabilities.Tools)
+	require.NoError(t, client.Ping(ctx))
+
+	serverTools, err := client.Tools(ctx)
+	require.NoError(t, err)
+	names := make([]string, 0, len(serverTools))
+	for _, tool := range serverTools {
+		names = append(names, tool.Name())
+	}
+	require.Equal(t, []string{"echo", "add", "fail"}, names)
+
+	echo := serverTools[0].(tools.StructuredTool) //nolint:forcetypeassert
+	require.Equal(t, "Echoes the text.", echo.Description())
+	require.Equal(t, jsonschema.String, echo.Parameters().Properties["text"].Type)
+	require.Equal(t, []string{"text"}, echo.Parameters().Required)
+
+	out, err := echo.Call(ctx, `{"text":"hello"}`)
+	require.NoError(t, err)
+	require.Equal(t, "hello", out)
+	// A free-form input is the value of the single string argument.
+	out, err = echo.Call(ctx, "hi there")
+	require.NoError(t, err)
+	require.Equal(t, "hi there", out)
+
+	add := serverTools[1].(tools.StructuredTool) //nolint:forcetypeassert
+	require.Equal(t, jsonschema.Number, add.Parameters().Properties["b"].Type)
+	_, err = add.Call(ctx, "1 + 2")
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+
+	_, err = serverTools[2].Call(ctx, `{}`)
+	require.ErrorIs(t, err, mcp.ErrToolFailed)
+	require.ErrorContains(t, err, "something went wrong")
+
+	resources, err := client.ListResources(ctx)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	contents, err := client.ReadResource(ctx, resources[0].URI)
+	require.NoError(t, err)
+	require.Equal(t, "Hello, world!", contents[0].Text)
+	_, err = client.ReadResource(ctx, "file:///missing.txt")
+	var rpcErr *mcp.RPCError
+	require.ErrorAs(t, err, &rpcErr)
+
+	prompts, err := client.ListPrompts(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "greet", prompts[0].Name)
+	require.True(t, prompts[0].Arguments[0].Required)
+	prompt, err := client.GetPrompt(ctx, "greet", map[string]string{"name": "Ada"})
+	require.NoError(t, err)
+	require.Equal(t, []mcp.PromptMessage{
+		{Role: mcp.RoleUser, Content: mcp.TextContent("Say hello to Ada.")},
+	}, prompt.Messages)
+
+	require.NoError(t, client.Close())
+	require.ErrorIs(t, client.Ping(ctx), mcp.ErrClientClosed)
+}
+
+func TestHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	handler := &mcptest.HTTPHandler{}
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	var mu sync.Mutex
+	var notifications []string
+	ctx := context.Background()
+	client, err := mcp.ConnectHTTP(ctx, server.URL,
+		mcp.WithHeader("Authorization", "Bearer token"),
+		mcp.WithNotificationHandler(func(method string, _ json.RawMessage) {
+			mu.Lock()
+			defer mu.Unlock()
+			notifications = append(notifications, method)
+		}),
+	)
+	require.NoError(t, err)
+
+	result, err := client.CallTool(ctx, "add", json.RawMessage(`{"a":1,"b":2}`))
+	require.NoError(t, err)
+	require.Equal(t, "3", mcp.ContentString(result.Content))
+	require.JSONEq(t, `{"sum":3}`, string(result.StructuredContent))
+
+	mu.Lock()
+	require.Equal(t, []string{"notifications/message"}, notifications)
+	mu.Unlock()
+
+	require.NoError(t, client.Close())
+	require.True(t, handler.Deleted())
+
+	headers := handler.Headers()
+	require.Equal(t, "Bearer token", headers[0].Get("Authorization"))
+	require.Empty(t, headers[0].Get("Mcp-Session-Id"))
+	last := headers[len(headers)-1]
+	require.Equal(t, "session-1", last.Get("Mcp-Session-Id"))
+	require.Equal(t, mcp.ProtocolVersion, last.Get("MCP-Protocol-Version"))
+}
+
+// repeatingTransport receives each message of the server three times.
+type repeatingTransport struct {
+	mcp.Transport
+
+	last    json.RawMessage
+	repeats int
+}
+
+func (t *repeatingTransport) Receive(ctx context.Context) (json.RawMessage, error) {
+	if t.repeats > 0 {
+		t.repeats--
+		return t.last, nil
+	}
+	msg, err := t.Transport.Receive(ctx)
+	if err == nil {
+		t.last, t.repeats = msg, 2
+	}
+	return msg, err
+}
+
+func TestClientDuplicateResponses(t *testing.T) {
+	t.Parallel()
+
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+	served := make(chan error, 1)
+	go func() {
+		served <- newTestServer(t).ServeStream(context.Background(), serverReader, serverWriter)
+		serverWriter.Close()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client, err := mcp.Connect(ctx, &repeatingTransport{
+		Transport: mcp.NewStreamTransport(clientReader, clientWriter),
+	})
+	require.NoError(t, err)
+
+	// The duplicate responses are dropped, without blocking the next calls.
+	for i := 0; i < 10; i++ {
+		require.NoError(t, client.Ping(ctx))
+	}
+
+	require.NoError(t, client.Close())
+	require.NoError(t, <-served)
+}
@@ -0,0 +1,29 @@
+// Package mcp implements the Model Context Protocol (MCP), which lets
+// applications give tools, resources and prompts to models.
+//
+// A Client connects to an MCP server, over the stdin and stdout of the server
+// process with ConnectCommand or over HTTP with ConnectHTTP, and lists and
+// calls its tools and reads its resources and prompts. Client.Tools returns the
+// tools of the server as tools.Tool, with their input schemas, usable by any
+// agent:
+//
+//	client, err := mcp.ConnectCommand(ctx, exec.Command("my-mcp-server"))
+//	if err != nil {
+//		return err
+//	}
+//	defer client.Close()
+//	serverTools, err := client.Tools(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	agent := agents.NewToolCallingAgent(llm, serverTools)
+//
+// Conversely, a Server publishes tools, retrievers and prompt templates to MCP
+// clients, such as desktop applications, over stdio with Server.ServeStdio or
+// over HTTP with Server.HTTPHandler:
+//
+//	server := mcp.NewServer("my-server", "1.0.0")
+//	server.AddTools(tools.Calculator{})
+//	server.AddRetriever("search_docs", "Searches the documentation.", retriever)
+//	return server.ServeStdio(ctx)
+package mcp
@@ -0,0 +1,263 @@
+// Package mcptest is a minimal MCP server for the tests of the mcp package,
+// written against the protocol rather than the package to test it.
+//
+// Its tools are "echo", returning its text argument, "add", adding its a and b
+// arguments, and "fail", always failing; they are listed one per page to
+// exercise pagination. It has a "hello" resource and a "greet" prompt taking a
+// name argument.
+package mcptest
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+type message struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method,omitempty"`
+	Params  json.RawMessage `json:"params,omitempty"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// HelloURI is the URI of the resource of the server.
+const HelloURI = "file:///hello.txt"
+
+var _tools = []map[string]any{ //nolint:gochecknoglobals
+	{
+		"name":        "echo",
+		"description": "Echoes the text.",
+		"inputSchema": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"text": map[string]any{"type": "string"}},
+			"required":   []string{"text"},
+		},
+	},
+	{
+		"name":        "add",
+		"description": "Adds two numbers.",
+		"inputSchema": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"a": map[string]any{"type": "number"},
+				"b": map[string]any{"type": []string{"number", "null"}},
+			},
+			"required": []string{"a", "b"},
+		},
+	},
+	{
+		"name":        "fail",
+		"description": "Always fails.",
+		"inputSchema": map[string]any{"type": "object"},
+	},
+}
+
+// handle returns the response to a message, or nil for notifications.
+func handle(msg *message) *message {
+	if len(msg.ID) == 0 {
+		return nil
+	}
+	resp := &message{JSONRPC: "2.0", ID: msg.ID}
+	result, err := call(msg.Method, msg.Params)
+	if err != nil {
+		resp.Error = err
+	} else {
+		resp.Result = result
+	}
+	return resp
+}
+
+func call(method string, params json.RawMessage) (any, *rpcError) { //nolint:cyclop
+	var p struct {
+		Cursor    string         `json:"cursor"`
+		Name      string         `json:"name"`
+		URI       string         `json:"uri"`
+		Arguments map[string]any `json:"arguments"`
+	}
+	if len(params) > 0 {
+		if err := json.Unmarshal(params, &p); err != nil {
+			return nil, &rpcError{Code: -32602, Message: err.Error()}
+		}
+	}
+
+	switch method {
+	case "initialize":
+		return map[string]any{
+			"protocolVersion": "2025-06-18",
+			"capabilities":    map[string]any{"tools": map[string]any{}, "resources": map[string]any{}, "prompts": map[string]any{}},
+			"serverInfo":      map[string]any{"name": "mcptest", "version": "1.0.0"},
+			"instructions":    "Use the tools to test.",
+		}, nil
+	case "ping":
+		return map[string]any{}, nil
+	case "tools/list":
+		i, _ := strconv.Atoi(p.Cursor)
+		result := map[string]any{"tools": _tools[i : i+1]}
+		if i+1 < len(_tools) {
+			result["nextCursor"] = strconv.Itoa(i + 1)
+		}
+		return result, nil
+	case "tools/call":
+		return callTool(p.Name, p.Arguments)
+	case "resources/list":
+		return map[string]any{"resources": []map[string]any{
+			{"uri": HelloURI, "name": "hello", "mimeType": "text/plain"},
+		}}, nil
+	case "resources/read":
+		if p.URI != HelloURI {
+			return nil, &rpcError{Code: -32002, Message: "resource not found"}
+		}
+		return map[string]any{"contents": []map[string]any{
+			{"uri": HelloURI, "mimeType": "text/plain", "text": "Hello, world!"},
+		}}, nil
+	case "prompts/list":
+		return map[string]any{"prompts": []map[string]any{{
+			"name":        "greet",
+			"description": "Greets someone.",
+			"arguments":   []map[string]any{{"name": "name", "required": true}},
+		}}}, nil
+	case "prompts/get":
+		return map[string]any{"messages": []map[string]any{{
+			"role":    "user",
+			"content": map[string]any{"type": "text", "text": fmt.Sprintf("Say hello to %v.", p.Arguments["name"])},
+		}}}, nil
+	default:
+		return nil, &rpcError{Code: -32601, Message: "method not found"}
+	}
+}
+
+func callTool(name string, args map[string]any) (any, *rpcError) {
+	text := func(s string) []map[string]any {
+		return []map[string]any{{"type": "text", "text": s}}
+	}
+	switch name {
+	case "echo":
+		return map[string]any{"content": text(fmt.Sprint(args["text"]))}, nil
+	case "add":
+		a, _ := args["a"].(float64)
+		b, _ := args["b"].(float64)
+		return map[string]any{
+			"content":           text(strconv.FormatFloat(a+b, 'f', -1, 64)),
+			"structuredContent": map[string]any{"sum": a + b},
+		}, nil
+	case "fail":
+		return map[string]any{"content": text("something went wrong"), "isError": true}, nil
+	default:
+		return nil, &rpcError{Code: -32602, Message: "unknown tool " + name}
+	}
+}
+
+// ServeStream serves newline-delimited messages read from r, writing the
+// responses to w, until r is closed.
+func ServeStream(r io.Reader, w io.Writer) error {
+	scanner := bufio.NewScanner(r)
+	encoder := json.NewEncoder(w)
+	for scanner.Scan() {
+		var msg message
+		if err := json.Unmarshal(scanner.Bytes(), &msg); err != nil {
+			continue
+		}
+		if resp := handle(&msg); resp != nil {
+			if err := encoder.Encode(resp); err != nil {
+				return err
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// HTTPHandler is a streamable HTTP handler of the server. It answers tool
+// calls with a stream of events, starting with a log notification, and the
+// other requests with JSON. It checks the session ID given at initialization,
+// and records the headers of the requests.
+type HTTPHandler struct {
+	mu      sync.Mutex
+	headers []http.Header
+	deleted bool
+}
+
+const _sessionID = "session-1"
+
+// Headers returns the headers of the requests received.
+func (h *HTTPHandler) Headers() []http.Header {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.headers
+}
+
+// Deleted returns true if the client ended its session.
+func (h *HTTPHandler) Deleted() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.deleted
+}
+
+func (h *HTTPHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	h.headers = append(h.headers, r.Header.Clone())
+	h.mu.Unlock()
+
+	var msg message
+	if r.Method == http.MethodPost {
+		if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	if msg.Method != "initialize" && r.Header.Get("Mcp-Session-Id") != _sessionID {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodDelete:
+		h.mu.Lock()
+		h.deleted = true
+		h.mu.Unlock()
+		return
+	case http.MethodPost:
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp := handle(&msg)
+	if resp == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	if msg.Method == "initialize" {
+		w.Header().Set("Mcp-Session-Id", _sessionID)
+	}
+
+	if msg.Method != "tools/call" {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(resp)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	notification := &message{
+		JSONRPC: "2.0",
+		Method:  "notifications/message",
+		Params:  json.RawMessage(`{"level":"info","data":"calling tool"}`),
+	}
+	for _, m := range []*message{notification, resp} {
+		data, _ := json.Marshal(m)
+		fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+		if f, ok := w.(http.Flusher); ok {
+			f.Flush()
+		}
+	}
+}
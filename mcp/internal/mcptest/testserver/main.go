@@ -0,0 +1,16 @@
+// Command testserver serves the test MCP server of the mcptest package over
+// stdio.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/tmc/langchaingo/mcp/internal/mcptest"
+)
+
+func main() {
+	if err := mcptest.ServeStream(os.Stdin, os.Stdout); err != nil {
+		log.Fatal(err)
+	}
+}
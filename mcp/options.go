@@ -0,0 +1,89 @@
+package mcp
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Option is an option of a client.
+type Option func(*options)
+
+type options struct {
+	clientInfo          Implementation
+	httpClient          *http.Client
+	header              http.Header
+	notificationHandler func(method string, params json.RawMessage)
+}
+
+func defaultOptions() options {
+	return options{
+		clientInfo: Implementation{Name: "langchaingo", Version: "0.1.0"},
+		header:     http.Header{},
+	}
+}
+
+// WithClientInfo sets the name and version of the client given to the server.
+func WithClientInfo(name, version string) Option {
+	return func(o *options) {
+		o.clientInfo = Implementation{Name: name, Version: version}
+	}
+}
+
+// WithHTTPClient sets the HTTP client used by ConnectHTTP.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithHeader adds a header to the requests of ConnectHTTP, e.g. for
+// authentication.
+func WithHeader(key, value string) Option {
+	return func(o *options) {
+		o.header.Add(key, value)
+	}
+}
+
+// WithNotificationHandler sets a function called with the notifications of
+// the server, e.g. "notifications/tools/list_changed" or
+// "notifications/message" for logs. It must not block.
+func WithNotificationHandler(handler func(method string, params json.RawMessage)) Option {
+	return func(o *options) {
+		o.notificationHandler = handler
+	}
+}
+
+// ServerOption is an option of a server.
+type ServerOption func(*serverOptions)
+
+type serverOptions struct {
+	instructions   string
+	allowedOrigins []string
+	maxSessions    int
+}
+
+// WithInstructions sets the instructions given to clients at initialization,
+// explaining how to use the server.
+func WithInstructions(instructions string) ServerOption {
+	return func(o *serverOptions) {
+		o.instructions = instructions
+	}
+}
+
+// WithMaxSessions sets the maximum number of sessions of the HTTP handler of a
+// server. Once it is reached, a new session replaces the least recently used
+// one. Defaults to 1000.
+func WithMaxSessions(n int) ServerOption {
+	return func(o *serverOptions) {
+		o.maxSessions = n
+	}
+}
+
+// WithAllowedOrigins sets the origins, e.g. "https://example.com", allowed to
+// call the HTTP handler of a server from a browser besides the origin of the
+// server itself.
+func WithAllowedOrigins(origins ...string) ServerOption {
+	return func(o *serverOptions) {
+		o.allowedOrigins = append(o.allowedOrigins, origins...)
+	}
+}
@@ -0,0 +1,460 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// Server is an MCP server publishing tools, retrievers and prompt templates
+// to MCP clients, over stdio with ServeStdio or over HTTP with HTTPHandler.
+//
+// Tools are called with the JSON arguments matching their schema, see
+// tools.Parameters, and their string output is returned as text. Retrievers
+// are published as tools taking a query. Prompt templates take their input
+// variables as arguments.
+//
+// A Server is safe for concurrent use, and tools, retrievers and prompts can
+// be added while serving.
+type Server struct {
+	info Implementation
+	opts serverOptions
+
+	mu      sync.RWMutex
+	tools   []tools.Tool
+	prompts []serverPrompt
+
+	sessionsMu sync.Mutex
+	sessions   map[string]time.Time // The time each session was last used.
+}
+
+type serverPrompt struct {
+	info   Prompt
+	prompt prompts.FormatPrompter
+}
+
+// NewServer creates a server with a name and a version, given to clients.
+func NewServer(name, version string, opts ...ServerOption) *Server {
+	o := serverOptions{maxSessions: _defaultMaxSessions}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Server{
+		info:     Implementation{Name: name, Version: version},
+		opts:     o,
+		sessions: make(map[string]time.Time),
+	}
+}
+
+// AddTools adds tools to the server, replacing the tools with the same names.
+func (s *Server) AddTools(newTools ...tools.Tool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, tool := range newTools {
+		s.tools = slices.DeleteFunc(s.tools, func(t tools.Tool) bool { return t.Name() == tool.Name() })
+		s.tools = append(s.tools, tool)
+	}
+}
+
+// AddRetriever adds a tool with the name and description, returning the
+// documents retrieved for its "query" argument.
+func (s *Server) AddRetriever(name, description string, retriever schema.Retriever) {
+	s.AddTools(&retrieverTool{name: name, description: description, retriever: retriever})
+}
+
+// AddPrompt adds a prompt template with the name and description, replacing
+// the prompt with the same name. Its input variables are the arguments of the
+// prompt, all required.
+func (s *Server) AddPrompt(name, description string, prompt prompts.FormatPrompter) {
+	info := Prompt{Name: name, Description: description}
+	for _, variable := range prompt.GetInputVariables() {
+		info.Arguments = append(info.Arguments, PromptArgument{Name: variable, Required: true})
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prompts = slices.DeleteFunc(s.prompts, func(p serverPrompt) bool { return p.info.Name == name })
+	s.prompts = append(s.prompts, serverPrompt{info: info, prompt: prompt})
+}
+
+// ServeStdio serves a client over the stdin and stdout of the process, until
+// stdin is closed or ctx is done.
+func (s *Server) ServeStdio(ctx context.Context) error {
+	return s.ServeStream(ctx, os.Stdin, os.Stdout)
+}
+
+// ServeStream serves a client sending newline-delimited messages to r and
+// reading the answers from w, until r is closed or ctx is done. Requests are
+// handled concurrently.
+func (s *Server) ServeStream(ctx context.Context, r io.Reader, w io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		writeMu  sync.Mutex
+		wg       sync.WaitGroup
+		cancelMu sync.Mutex
+		cancels  = make(map[string]context.CancelFunc)
+	)
+	defer wg.Wait()
+
+	write := func(msg *message) {
+		raw, err := json.Marshal(msg)
+		if err != nil {
+			return
+		}
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		_, _ = w.Write(append(raw, '\n'))
+	}
+
+	lines := make(chan []byte)
+	readErr := make(chan error, 1)
+	go func() {
+		reader := bufio.NewReader(r)
+		for {
+			line, err := reader.ReadBytes('\n')
+			if len(strings.TrimSpace(string(line))) > 0 {
+				select {
+				case lines <- line:
+				case <-ctx.Done():
+					return
+				}
+			}
+			if err != nil {
+				readErr <- err
+				return
+			}
+		}
+	}()
+
+	for {
+		var line []byte
+		select {
+		case line = <-lines:
+		case err := <-readErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		var msg message
+		if err := json.Unmarshal(line, &msg); err != nil {
+			write(errorResponse(nil, CodeParseError, err.Error()))
+			continue
+		}
+		if msg.Method == "notifications/cancelled" {
+			var params cancelledParams
+			if json.Unmarshal(msg.Params, &params) == nil {
+				cancelMu.Lock()
+				if cancelRequest, ok := cancels[string(params.RequestID)]; ok {
+					cancelRequest()
+				}
+				cancelMu.Unlock()
+			}
+			continue
+		}
+		if !msg.isRequest() {
+			continue
+		}
+
+		reqCtx, cancelRequest := context.WithCancel(ctx)
+		cancelMu.Lock()
+		cancels[string(msg.ID)] = cancelRequest
+		cancelMu.Unlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp := s.handle(reqCtx, &msg)
+			// Cancelled requests are not answered.
+			cancelled := reqCtx.Err() != nil
+
+			cancelMu.Lock()
+			delete(cancels, string(msg.ID))
+			cancelMu.Unlock()
+			cancelRequest()
+
+			if !cancelled {
+				write(resp)
+			}
+		}()
+	}
+}
+
+// handle returns the response to a request.
+func (s *Server) handle(ctx context.Context, req *message) *message {
+	result, err := s.call(ctx, req.Method, req.Params)
+	if err != nil {
+		var rpcErr *RPCError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &RPCError{Code: CodeInternalError, Message: err.Error()}
+		}
+		return &message{JSONRPC: "2.0", ID: req.ID, Error: rpcErr}
+	}
+
+	raw, err := json.Marshal(result)
+	if err != nil {
+		return errorResponse(req.ID, CodeInternalError, err.Error())
+	}
+	return &message{JSONRPC: "2.0", ID: req.ID, Result: raw}
+}
+
+func errorResponse(id json.RawMessage, code int, msg string) *message {
+	return &message{JSONRPC: "2.0", ID: id, Error: &RPCError{Code: code, Message: msg}}
+}
+
+func invalidParams(format string, args ...any) *RPCError {
+	return &RPCError{Code: CodeInvalidParams, Message: fmt.Sprintf(format, args...)}
+}
+
+func (s *Server) call(ctx context.Context, method string, rawParams json.RawMessage) (any, error) {
+	decode := func(params any) error {
+		if len(rawParams) == 0 {
+			return nil
+		}
+		if err := json.Unmarshal(rawParams, params); err != nil {
+			return invalidParams("invalid params: %s", err)
+		}
+		return nil
+	}
+
+	switch method {
+	case "initialize":
+		var params initializeParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return s.initialize(params), nil
+	case "ping":
+		return struct{}{}, nil
+	case "tools/list":
+		return s.listTools()
+	case "tools/call":
+		var params callToolParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return s.callTool(ctx, params)
+	case "prompts/list":
+		return s.listPrompts(), nil
+	case "prompts/get":
+		var params getPromptParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		return s.getPrompt(params)
+	default:
+		return nil, &RPCError{Code: CodeMethodNotFound, Message: "method not found: " + method}
+	}
+}
+
+func (s *Server) initialize(params initializeParams) InitializeResult {
+	// The version of the client if supported, else the latest version.
+	version := ProtocolVersion
+	if slices.Contains(_supportedProtocolVersions, params.ProtocolVersion) {
+		version = params.ProtocolVersion
+	}
+	return InitializeResult{
+		ProtocolVersion: version,
+		Capabilities: ServerCapabilities{
+			Tools:   &ListChangedCapability{},
+			Prompts: &ListChangedCapability{},
+		},
+		ServerInfo:   s.info,
+		Instructions: s.opts.instructions,
+	}
+}
+
+func (s *Server) listTools() (listToolsResult, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := listToolsResult{Tools: make([]ToolInfo, 0, len(s.tools))}
+	for _, tool := range s.tools {
+		inputSchema, err := json.Marshal(tools.Parameters(tool))
+		if err != nil {
+			return result, fmt.Errorf("schema of tool %s: %w", tool.Name(), err)
+		}
+		result.Tools = append(result.Tools, ToolInfo{
+			Name:        tool.Name(),
+			Description: tool.Description(),
+			InputSchema: inputSchema,
+		})
+	}
+	return result, nil
+}
+
+func (s *Server) tool(name string) tools.Tool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, tool := range s.tools {
+		if tool.Name() == name {
+			return tool
+		}
+	}
+	return nil
+}
+
+// callTool calls a tool. The errors of the tool are returned as results with
+// IsError set, for the model to see them.
+func (s *Server) callTool(ctx context.Context, params callToolParams) (*CallToolResult, error) {
+	tool := s.tool(params.Name)
+	if tool == nil {
+		return nil, invalidParams("unknown tool: %s", params.Name)
+	}
+
+	arguments := string(params.Arguments)
+	if arguments == "" || arguments == "null" {
+		arguments = "{}"
+	}
+	if rt, ok := tool.(*retrieverTool); ok {
+		return rt.callTool(ctx, arguments), nil
+	}
+
+	output, err := tool.Call(ctx, tools.InputFromArguments(tool, arguments))
+	if err != nil {
+		return &CallToolResult{Content: []Content{TextContent(err.Error())}, IsError: true}, nil
+	}
+	return &CallToolResult{Content: []Content{TextContent(output)}}, nil
+}
+
+func (s *Server) listPrompts() listPromptsResult {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	result := listPromptsResult{Prompts: make([]Prompt, 0, len(s.prompts))}
+	for _, p := range s.prompts {
+		result.Prompts = append(result.Prompts, p.info)
+	}
+	return result
+}
+
+func (s *Server) getPrompt(params getPromptParams) (*GetPromptResult, error) {
+	s.mu.RLock()
+	i := slices.IndexFunc(s.prompts, func(p serverPrompt) bool { return p.info.Name == params.Name })
+	var p serverPrompt
+	if i >= 0 {
+		p = s.prompts[i]
+	}
+	s.mu.RUnlock()
+	if i < 0 {
+		return nil, invalidParams("unknown prompt: %s", params.Name)
+	}
+
+	values := make(map[string]any, len(params.Arguments))
+	for _, arg := range p.info.Arguments {
+		value, ok := params.Arguments[arg.Name]
+		if !ok && arg.Required {
+			return nil, invalidParams("missing argument %s of prompt %s", arg.Name, params.Name)
+		}
+		values[arg.Name] = value
+	}
+
+	value, err := p.prompt.FormatPrompt(values)
+	if err != nil {
+		return nil, invalidParams("formatting prompt %s: %s", params.Name, err)
+	}
+	result := &GetPromptResult{Description: p.info.Description}
+	for _, msg := range value.Messages() {
+		result.Messages = append(result.Messages, promptMessage(msg))
+	}
+	return result, nil
+}
+
+// promptMessage converts a chat message to a prompt message. Prompts only have
+// user and assistant messages, so the other messages are given by the user.
+func promptMessage(msg llms.ChatMessage) PromptMessage {
+	role := RoleUser
+	if msg.GetType() == llms.ChatMessageTypeAI {
+		role = RoleAssistant
+	}
+	return PromptMessage{Role: role, Content: TextContent(msg.GetContent())}
+}
+
+// retrieverTool is the tool of a retriever added to a server.
+type retrieverTool struct {
+	name        string
+	description string
+	retriever   schema.Retriever
+}
+
+var _ tools.StructuredTool = &retrieverTool{}
+
+type retrieverArguments struct {
+	Query string `json:"query"`
+}
+
+type retrievedDocument struct {
+	PageContent string         `json:"pageContent"`
+	Metadata    map[string]any `json:"metadata,omitempty"`
+	Score       float32        `json:"score,omitempty"`
+}
+
+func (t *retrieverTool) Name() string        { return t.name }
+func (t *retrieverTool) Description() string { return t.description }
+
+func (t *retrieverTool) Parameters() jsonschema.Definition {
+	return jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"query": {Type: jsonschema.String, Description: "The query to retrieve documents for."},
+		},
+		Required: []string{"query"},
+	}
+}
+
+// Call returns the page contents of the documents, separated by blank lines.
+func (t *retrieverTool) Call(ctx context.Context, input string) (string, error) {
+	result := t.callTool(ctx, input)
+	if result.IsError {
+		return "", fmt.Errorf("%w: %s: %s", ErrToolFailed, t.name, ContentString(result.Content))
+	}
+	return ContentString(result.Content), nil
+}
+
+// callTool returns a text block for each document, and the documents with
+// their metadata as structured content.
+func (t *retrieverTool) callTool(ctx context.Context, arguments string) *CallToolResult {
+	var args retrieverArguments
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil || args.Query == "" {
+		return &CallToolResult{Content: []Content{TextContent("a query is required")}, IsError: true}
+	}
+
+	docs, err := t.retriever.GetRelevantDocuments(ctx, args.Query)
+	if err != nil {
+		return &CallToolResult{Content: []Content{TextContent(err.Error())}, IsError: true}
+	}
+
+	result := &CallToolResult{Content: make([]Content, 0, len(docs))}
+	structured := struct {
+		Documents []retrievedDocument `json:"documents"`
+	}{Documents: make([]retrievedDocument, 0, len(docs))}
+	for _, doc := range docs {
+		result.Content = append(result.Content, TextContent(doc.PageContent))
+		structured.Documents = append(structured.Documents, retrievedDocument{
+			PageContent: doc.PageContent,
+			Metadata:    doc.Metadata,
+			Score:       doc.Score,
+		})
+	}
+	result.StructuredContent, _ = json.Marshal(structured)
+	return result
+}
@@ -0,0 +1,149 @@
+package mcp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+)
+
+const (
+	// _maxRequestSize is the maximum size of the messages posted to the HTTP
+	// handler of a server.
+	_maxRequestSize = 4 << 20
+	// _defaultMaxSessions is the default maximum number of sessions of the
+	// HTTP handler of a server.
+	_defaultMaxSessions = 1000
+	// _sessionIdleTimeout is the time after which unused sessions expire.
+	_sessionIdleTimeout = time.Hour
+)
+
+// HTTPHandler returns a handler serving clients with the streamable HTTP
+// transport. Each client gets a session at initialization, ended when the
+// client deletes it, after an hour without requests, or when the maximum
+// number of sessions is reached and it is the least recently used, see
+// WithMaxSessions. Requests from browsers of other origins are rejected,
+// see WithAllowedOrigins.
+func (s *Server) HTTPHandler() http.Handler {
+	return http.HandlerFunc(s.serveHTTP)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if !s.allowedOrigin(r) {
+		http.Error(w, "origin not allowed", http.StatusForbidden)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+	case http.MethodDelete:
+		if s.checkSession(w, r) {
+			s.sessionsMu.Lock()
+			delete(s.sessions, r.Header.Get(_sessionIDHeader))
+			s.sessionsMu.Unlock()
+		}
+		return
+	default:
+		// There is no stream of messages from the server, opened with GET.
+		w.Header().Set("Allow", "POST, DELETE")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, _maxRequestSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	var msg message
+	if err := json.Unmarshal(body, &msg); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse(nil, CodeParseError, err.Error()))
+		return
+	}
+
+	if msg.Method == "initialize" {
+		w.Header().Set(_sessionIDHeader, s.newSession())
+	} else if !s.checkSession(w, r) {
+		return
+	}
+
+	if !msg.isRequest() {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	writeJSON(w, http.StatusOK, s.handle(r.Context(), &msg))
+}
+
+func writeJSON(w http.ResponseWriter, status int, msg *message) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(msg)
+}
+
+func (s *Server) newSession() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	id := hex.EncodeToString(b)
+
+	now := time.Now()
+	s.sessionsMu.Lock()
+	defer s.sessionsMu.Unlock()
+	oldest := ""
+	for other, used := range s.sessions {
+		switch {
+		case now.Sub(used) > _sessionIdleTimeout:
+			delete(s.sessions, other)
+		case oldest == "" || used.Before(s.sessions[oldest]):
+			oldest = other
+		}
+	}
+	if len(s.sessions) >= max(s.opts.maxSessions, 1) {
+		delete(s.sessions, oldest)
+	}
+	s.sessions[id] = now
+	return id
+}
+
+// checkSession checks the session of a request, answering it with an error if
+// the session is missing or unknown.
+func (s *Server) checkSession(w http.ResponseWriter, r *http.Request) bool {
+	id := r.Header.Get(_sessionIDHeader)
+	if id == "" {
+		http.Error(w, "missing session", http.StatusBadRequest)
+		return false
+	}
+
+	now := time.Now()
+	s.sessionsMu.Lock()
+	used, ok := s.sessions[id]
+	if ok && now.Sub(used) > _sessionIdleTimeout {
+		delete(s.sessions, id)
+		ok = false
+	} else if ok {
+		s.sessions[id] = now
+	}
+	s.sessionsMu.Unlock()
+	if !ok {
+		// The client must start a new session.
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return false
+	}
+	return true
+}
+
+// allowedOrigin protects local servers from DNS rebinding attacks, by only
+// accepting requests from browsers on the same origin or on allowed origins.
+func (s *Server) allowedOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	if u, err := url.Parse(origin); err == nil && u.Host == r.Host {
+		return true
+	}
+	return slices.Contains(s.opts.allowedOrigins, origin)
+}
@@ -0,0 +1,230 @@
+package mcp_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/mcp"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+type fakeRetriever struct{}
+
+func (fakeRetriever) GetRelevantDocuments(_ context.Context, query string) ([]schema.Document, error) {
+	if query == "fail" {
+		return nil, errors.New("index unavailable")
+	}
+	return []schema.Document{
+		{PageContent: "Go is a programming language.", Metadata: map[string]any{"source": "go.dev"}},
+		{PageContent: "Go was designed at Google."},
+	}, nil
+}
+
+type weatherArgs struct {
+	City string `json:"city"`
+}
+
+func newTestServer(t *testing.T) *mcp.Server {
+	t.Helper()
+
+	weather, err := tools.NewTyped("weather", "Gets the weather of a city.",
+		func(_ context.Context, args weatherArgs) (string, error) {
+			if args.City == "Atlantis" {
+				return "", errors.New("unknown city")
+			}
+			return "sunny in " + args.City, nil
+		})
+	require.NoError(t, err)
+
+	server := mcp.NewServer("test", "1.0.0", mcp.WithInstructions("Ask about the weather."))
+	server.AddTools(tools.Calculator{}, weather)
+	server.AddRetriever("search_docs", "Searches the documentation.", fakeRetriever{})
+	server.AddPrompt("joke", "Tells a joke.", prompts.NewPromptTemplate("Tell me a joke about {{.topic}}.", []string{"topic"}))
+	server.AddPrompt("chat", "Starts a chat.", prompts.NewChatPromptTemplate([]prompts.MessageFormatter{
+		prompts.NewSystemMessagePromptTemplate("You are a {{.role}}.", []string{"role"}),
+		prompts.NewAIMessagePromptTemplate("How can I help?", nil),
+	}))
+	return server
+}
+
+// connectStream connects a client to a server over pipes.
+func connectStream(t *testing.T, server *mcp.Server) *mcp.Client {
+	t.Helper()
+
+	clientReader, serverWriter := io.Pipe()
+	serverReader, clientWriter := io.Pipe()
+	served := make(chan error, 1)
+	go func() {
+		served <- server.ServeStream(context.Background(), serverReader, serverWriter)
+		serverWriter.Close()
+	}()
+
+	client, err := mcp.Connect(context.Background(), mcp.NewStreamTransport(clientReader, clientWriter))
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		client.Close()
+		require.NoError(t, <-served)
+	})
+	return client
+}
+
+func TestServer(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := connectStream(t, newTestServer(t))
+	require.Equal(t, "test", client.ServerInfo().ServerInfo.Name)
+	require.Equal(t, "Ask about the weather.", client.ServerInfo().Instructions)
+
+	serverTools, err := client.Tools(ctx)
+	require.NoError(t, err)
+	require.Len(t, serverTools, 3)
+
+	// A plain tool takes its input as a single string argument.
+	calculator := serverTools[0].(tools.StructuredTool) //nolint:forcetypeassert
+	require.Equal(t, tools.Parameters(tools.Calculator{}), calculator.Parameters())
+	out, err := calculator.Call(ctx, "2 * 21")
+	require.NoError(t, err)
+	require.Equal(t, "42", out)
+
+	weather := serverTools[1].(tools.StructuredTool) //nolint:forcetypeassert
+	require.Equal(t, []string{"city"}, weather.Parameters().Required)
+	out, err = weather.Call(ctx, `{"city":"Paris"}`)
+	require.NoError(t, err)
+	require.Equal(t, "sunny in Paris", out)
+	_, err = weather.Call(ctx, `{"city":"Atlantis"}`)
+	require.ErrorIs(t, err, mcp.ErrToolFailed)
+	require.ErrorContains(t, err, "unknown city")
+
+	result, err := client.CallTool(ctx, "search_docs", json.RawMessage(`{"query":"go"}`))
+	require.NoError(t, err)
+	require.Equal(t, "Go is a programming language.\nGo was designed at Google.", mcp.ContentString(result.Content))
+	require.JSONEq(t, `{"documents":[
+		{"pageContent":"Go is a programming language.","metadata":{"source":"go.dev"}},
+		{"pageContent":"Go was designed at Google."}
+	]}`, string(result.StructuredContent))
+	result, err = client.CallTool(ctx, "search_docs", json.RawMessage(`{"query":"fail"}`))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+
+	_, err = client.CallTool(ctx, "missing", nil)
+	var rpcErr *mcp.RPCError
+	require.ErrorAs(t, err, &rpcErr)
+	require.Equal(t, mcp.CodeInvalidParams, rpcErr.Code)
+}
+
+func TestServerPrompts(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client := connectStream(t, newTestServer(t))
+
+	serverPrompts, err := client.ListPrompts(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []mcp.Prompt{
+		{
+			Name:        "joke",
+			Description: "Tells a joke.",
+			Arguments:   []mcp.PromptArgument{{Name: "topic", Required: true}},
+		},
+		{
+			Name:        "chat",
+			Description: "Starts a chat.",
+			Arguments:   []mcp.PromptArgument{{Name: "role", Required: true}},
+		},
+	}, serverPrompts)
+
+	joke, err := client.GetPrompt(ctx, "joke", map[string]string{"topic": "gophers"})
+	require.NoError(t, err)
+	require.Equal(t, []mcp.PromptMessage{
+		{Role: mcp.RoleUser, Content: mcp.TextContent("Tell me a joke about gophers.")},
+	}, joke.Messages)
+
+	chat, err := client.GetPrompt(ctx, "chat", map[string]string{"role": "pirate"})
+	require.NoError(t, err)
+	require.Equal(t, []mcp.PromptMessage{
+		{Role: mcp.RoleUser, Content: mcp.TextContent("You are a pirate.")},
+		{Role: mcp.RoleAssistant, Content: mcp.TextContent("How can I help?")},
+	}, chat.Messages)
+
+	_, err = client.GetPrompt(ctx, "joke", nil)
+	require.ErrorContains(t, err, "missing argument topic")
+}
+
+func TestServerHTTP(t *testing.T) {
+	t.Parallel()
+
+	httpServer := httptest.NewServer(newTestServer(t).HTTPHandler())
+	defer httpServer.Close()
+
+	ctx := context.Background()
+	client, err := mcp.ConnectHTTP(ctx, httpServer.URL)
+	require.NoError(t, err)
+
+	serverTools, err := client.Tools(ctx)
+	require.NoError(t, err)
+	out, err := serverTools[0].Call(ctx, "1 + 1")
+	require.NoError(t, err)
+	require.Equal(t, "2", out)
+	require.NoError(t, client.Close())
+
+	// The session is ended by the client.
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, httpServer.URL,
+		strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"ping"}`))
+	require.NoError(t, err)
+	req.Header.Set("Mcp-Session-Id", "unknown")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Browsers of other origins are rejected.
+	req, err = http.NewRequestWithContext(ctx, http.MethodPost, httpServer.URL,
+		strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"initialize"}`))
+	require.NoError(t, err)
+	req.Header.Set("Origin", "https://evil.example.com")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestServerHTTPMaxSessions(t *testing.T) {
+	t.Parallel()
+
+	server := mcp.NewServer("test", "1.0.0", mcp.WithMaxSessions(2))
+	httpServer := httptest.NewServer(server.HTTPHandler())
+	defer httpServer.Close()
+
+	post := func(method, session string) *http.Response {
+		req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, httpServer.URL,
+			strings.NewReader(`{"jsonrpc":"2.0","id":1,"method":"`+method+`"}`))
+		require.NoError(t, err)
+		if session != "" {
+			req.Header.Set("Mcp-Session-Id", session)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		resp.Body.Close()
+		return resp
+	}
+
+	first := post("initialize", "").Header.Get("Mcp-Session-Id")
+	second := post("initialize", "").Header.Get("Mcp-Session-Id")
+	require.Equal(t, http.StatusOK, post("ping", first).StatusCode)
+
+	// The least recently used session is replaced by the new one.
+	third := post("initialize", "").Header.Get("Mcp-Session-Id")
+	require.Equal(t, http.StatusNotFound, post("ping", second).StatusCode)
+	require.Equal(t, http.StatusOK, post("ping", first).StatusCode)
+	require.Equal(t, http.StatusOK, post("ping", third).StatusCode)
+}
@@ -0,0 +1,162 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// ErrToolFailed is returned by Tool.Call when the tool reports an error. Use
+// agents.ToolErrorHandler to give these errors to the agent.
+var ErrToolFailed = errors.New("mcp: tool failed")
+
+// Tool is a tool of an MCP server, usable by agents as a tools.Tool.
+type Tool struct {
+	CallbacksHandler callbacks.Handler
+
+	client *Client
+	info   ToolInfo
+	params jsonschema.Definition
+}
+
+var _ tools.StructuredTool = &Tool{}
+
+// NewTool creates a tool calling the tool described by info on the server of
+// the client.
+func NewTool(client *Client, info ToolInfo) *Tool {
+	return &Tool{client: client, info: info, params: schemaDefinition(info.InputSchema)}
+}
+
+// Tools returns the tools of the server, for agents.
+func (c *Client) Tools(ctx context.Context) ([]tools.Tool, error) {
+	infos, err := c.ListTools(ctx)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]tools.Tool, 0, len(infos))
+	for _, info := range infos {
+		res = append(res, NewTool(c, info))
+	}
+	return res, nil
+}
+
+// Name returns the name of the tool.
+func (t *Tool) Name() string {
+	return t.info.Name
+}
+
+// Description returns the description of the tool.
+func (t *Tool) Description() string {
+	if t.info.Description == "" {
+		return t.info.Title
+	}
+	return t.info.Description
+}
+
+// Parameters returns the input schema of the tool.
+func (t *Tool) Parameters() jsonschema.Definition {
+	return t.params
+}
+
+// Info returns the description of the tool given by the server.
+func (t *Tool) Info() ToolInfo {
+	return t.info
+}
+
+// Call calls the tool with its JSON arguments, and returns the text of the
+// result. A tool taking a single string argument can also be called with the
+// value of the argument, for agents giving free-form inputs to tools.
+func (t *Tool) Call(ctx context.Context, input string) (string, error) {
+	if t.CallbacksHandler != nil {
+		t.CallbacksHandler.HandleToolStart(ctx, input)
+	}
+
+	result, err := t.call(ctx, input)
+	if err != nil {
+		if t.CallbacksHandler != nil {
+			t.CallbacksHandler.HandleToolError(ctx, err)
+		}
+		return "", err
+	}
+
+	if t.CallbacksHandler != nil {
+		t.CallbacksHandler.HandleToolEnd(ctx, result)
+	}
+	return result, nil
+}
+
+func (t *Tool) call(ctx context.Context, input string) (string, error) {
+	arguments, err := t.arguments(input)
+	if err != nil {
+		return "", err
+	}
+	result, err := t.client.CallTool(ctx, t.info.Name, arguments)
+	if err != nil {
+		return "", err
+	}
+
+	text := ContentString(result.Content)
+	if text == "" && len(result.StructuredContent) > 0 {
+		text = string(result.StructuredContent)
+	}
+	if result.IsError {
+		return "", fmt.Errorf("%w: %s: %s", ErrToolFailed, t.info.Name, text)
+	}
+	return text, nil
+}
+
+// arguments returns the JSON arguments of the tool from the input.
+func (t *Tool) arguments(input string) (json.RawMessage, error) {
+	input = strings.TrimSpace(input)
+	var object map[string]any
+	if json.Unmarshal([]byte(input), &object) == nil {
+		return json.RawMessage(input), nil
+	}
+
+	// Not a JSON object: the input is the value of the single string argument.
+	if len(t.params.Properties) == 1 {
+		for name, prop := range t.params.Properties {
+			if prop.Type == jsonschema.String {
+				return json.Marshal(map[string]string{name: input})
+			}
+		}
+	}
+	return nil, fmt.Errorf("%w: %s takes a JSON object", tools.ErrInvalidArguments, t.info.Name)
+}
+
+// ContentString returns the text of content blocks, one block per line.
+// Blocks without text, such as images, are described in brackets.
+func ContentString(content []Content) string {
+	parts := make([]string, 0, len(content))
+	for _, c := range content {
+		switch {
+		case c.Type == ContentText:
+			parts = append(parts, c.Text)
+		case c.Type == ContentResource && c.Resource != nil && c.Resource.Text != "":
+			parts = append(parts, c.Resource.Text)
+		case c.Type == ContentResource && c.Resource != nil:
+			parts = append(parts, fmt.Sprintf("[resource %s]", c.Resource.URI))
+		case c.Type == ContentResourceLink:
+			parts = append(parts, fmt.Sprintf("[resource %s]", c.URI))
+		default:
+			parts = append(parts, fmt.Sprintf("[%s %s]", c.Type, c.MIMEType))
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// schemaDefinition converts the JSON schema of the arguments of a tool to a
+// jsonschema.Definition.
+func schemaDefinition(raw json.RawMessage) jsonschema.Definition {
+	def, err := jsonschema.Parse(raw)
+	if err != nil {
+		return jsonschema.Definition{Type: jsonschema.Object}
+	}
+	return *def
+}
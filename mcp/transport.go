@@ -0,0 +1,358 @@
+package mcp
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrTransportClosed is returned when using a closed transport.
+var ErrTransportClosed = errors.New("mcp: transport closed")
+
+// Transport carries the JSON-RPC messages of a connection.
+type Transport interface {
+	// Send sends a message to the other side.
+	Send(ctx context.Context, msg json.RawMessage) error
+	// Receive returns the next message from the other side. It returns io.EOF
+	// once the other side closed the connection.
+	Receive(ctx context.Context) (json.RawMessage, error)
+	// Close closes the transport.
+	Close() error
+}
+
+// StreamTransport is a Transport over a stream of newline-delimited JSON
+// messages, as used by the stdio transport of the protocol.
+type StreamTransport struct {
+	r      *bufio.Reader
+	w      io.Writer
+	closer io.Closer
+
+	mu sync.Mutex
+}
+
+var _ Transport = &StreamTransport{}
+
+// NewStreamTransport creates a transport reading messages from r and writing
+// them to w. Closing the transport closes w if it is an io.Closer.
+func NewStreamTransport(r io.Reader, w io.Writer) *StreamTransport {
+	closer, _ := w.(io.Closer)
+	return &StreamTransport{r: bufio.NewReader(r), w: w, closer: closer}
+}
+
+// Send writes a message on a line.
+func (t *StreamTransport) Send(_ context.Context, msg json.RawMessage) error {
+	// Messages must not contain newlines.
+	var buf bytes.Buffer
+	if err := json.Compact(&buf, msg); err != nil {
+		return err
+	}
+	buf.WriteByte('\n')
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	_, err := t.w.Write(buf.Bytes())
+	return err
+}
+
+// Receive reads the next non-empty line.
+func (t *StreamTransport) Receive(_ context.Context) (json.RawMessage, error) {
+	for {
+		line, err := t.r.ReadBytes('\n')
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			return line, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// Close closes the writer of the transport.
+func (t *StreamTransport) Close() error {
+	if t.closer == nil {
+		return nil
+	}
+	return t.closer.Close()
+}
+
+// _commandShutdownTimeout is how long a server process has to exit after its
+// stdin is closed, before being killed.
+const _commandShutdownTimeout = 5 * time.Second
+
+// CommandTransport is the stdio transport: it runs a server as a subprocess
+// and talks to it over its stdin and stdout.
+type CommandTransport struct {
+	*StreamTransport
+	cmd *exec.Cmd
+}
+
+var _ Transport = &CommandTransport{}
+
+// NewCommandTransport starts the command and returns a transport over its
+// stdin and stdout. The stderr of the command, often used for logs by servers,
+// is left as set on the command.
+func NewCommandTransport(cmd *exec.Cmd) (*CommandTransport, error) {
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("mcp: starting server: %w", err)
+	}
+
+	return &CommandTransport{StreamTransport: NewStreamTransport(stdout, stdin), cmd: cmd}, nil
+}
+
+// Close closes the stdin of the server and waits for it to exit, killing it if
+// it does not exit in time.
+func (t *CommandTransport) Close() error {
+	closeErr := t.StreamTransport.Close()
+
+	done := make(chan error, 1)
+	go func() { done <- t.cmd.Wait() }()
+	select {
+	case <-done:
+	case <-time.After(_commandShutdownTimeout):
+		_ = t.cmd.Process.Kill()
+		<-done
+	}
+	return closeErr
+}
+
+const (
+	_sessionIDHeader       = "Mcp-Session-Id"
+	_protocolVersionHeader = "MCP-Protocol-Version"
+)
+
+// HTTPTransport is the streamable HTTP transport: each message is posted to
+// the endpoint of the server, which answers requests with a JSON response or
+// with a stream of server-sent events ending with the response.
+type HTTPTransport struct {
+	url    string
+	client *http.Client
+	header http.Header
+
+	mu              sync.Mutex
+	sessionID       string
+	protocolVersion string
+
+	messages  chan json.RawMessage
+	ctx       context.Context //nolint:containedctx
+	cancel    context.CancelFunc
+	closed    chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+var _ Transport = &HTTPTransport{}
+
+// NewHTTPTransport creates a transport posting to the endpoint url with the
+// client, adding the header to every request, e.g. for authentication.
+func NewHTTPTransport(url string, client *http.Client, header http.Header) *HTTPTransport {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &HTTPTransport{
+		url:      url,
+		client:   client,
+		header:   header,
+		messages: make(chan json.RawMessage),
+		ctx:      ctx,
+		cancel:   cancel,
+		closed:   make(chan struct{}),
+	}
+}
+
+// setProtocolVersion sets the version negotiated at initialization, sent in
+// the headers of the following requests.
+func (t *HTTPTransport) setProtocolVersion(version string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.protocolVersion = version
+}
+
+func (t *HTTPTransport) newRequest(ctx context.Context, method string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, t.url, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range t.header {
+		req.Header[key] = values
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.sessionID != "" {
+		req.Header.Set(_sessionIDHeader, t.sessionID)
+	}
+	if t.protocolVersion != "" {
+		req.Header.Set(_protocolVersionHeader, t.protocolVersion)
+	}
+	return req, nil
+}
+
+// Send posts a message. The messages of the answer are read in the background
+// and returned by Receive.
+func (t *HTTPTransport) Send(ctx context.Context, msg json.RawMessage) error {
+	select {
+	case <-t.closed:
+		return ErrTransportClosed
+	default:
+	}
+
+	// The answer may be streamed after ctx is done, so only the request is
+	// bound to ctx: the reading of the answer lasts until the transport is
+	// closed.
+	reqCtx, cancel := context.WithCancel(t.ctx)
+	stop := context.AfterFunc(ctx, cancel)
+	req, err := t.newRequest(reqCtx, http.MethodPost, bytes.NewReader(msg))
+	if err != nil {
+		cancel()
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json, text/event-stream")
+
+	resp, err := t.client.Do(req)
+	stop()
+	if err != nil {
+		cancel()
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer cancel()
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("mcp: server returned %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	if sessionID := resp.Header.Get(_sessionIDHeader); sessionID != "" {
+		t.mu.Lock()
+		t.sessionID = sessionID
+		t.mu.Unlock()
+	}
+
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		defer cancel()
+		defer resp.Body.Close()
+		t.readResponse(resp)
+	}()
+	return nil
+}
+
+func (t *HTTPTransport) readResponse(resp *http.Response) {
+	if resp.StatusCode == http.StatusAccepted {
+		return
+	}
+
+	mediaType, _, _ := strings.Cut(resp.Header.Get("Content-Type"), ";")
+	switch strings.TrimSpace(mediaType) {
+	case "text/event-stream":
+		t.readEvents(resp.Body)
+	case "application/json":
+		body, err := io.ReadAll(resp.Body)
+		if err == nil {
+			t.deliver(body)
+		}
+	}
+}
+
+// readEvents reads the messages of a stream of server-sent events.
+func (t *HTTPTransport) readEvents(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 16<<20)
+
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			if len(data) > 0 && !t.deliver([]byte(strings.Join(data, "\n"))) {
+				return
+			}
+			data = data[:0]
+			continue
+		}
+		if value, ok := strings.CutPrefix(line, "data:"); ok {
+			data = append(data, strings.TrimPrefix(value, " "))
+		}
+	}
+	if len(data) > 0 {
+		t.deliver([]byte(strings.Join(data, "\n")))
+	}
+}
+
+// deliver gives a message to Receive. It returns false if the transport is
+// closed.
+func (t *HTTPTransport) deliver(msg []byte) bool {
+	if len(bytes.TrimSpace(msg)) == 0 {
+		return true
+	}
+	select {
+	case t.messages <- msg:
+		return true
+	case <-t.closed:
+		return false
+	}
+}
+
+// Receive returns the next message of the answers of the server.
+func (t *HTTPTransport) Receive(ctx context.Context) (json.RawMessage, error) {
+	select {
+	case msg := <-t.messages:
+		return msg, nil
+	case <-t.closed:
+		return nil, io.EOF
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Close ends the session on the server, if any, and stops reading answers.
+func (t *HTTPTransport) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		close(t.closed)
+		t.cancel()
+		t.wg.Wait()
+		err = t.deleteSession()
+	})
+	return err
+}
+
+func (t *HTTPTransport) deleteSession() error {
+	t.mu.Lock()
+	sessionID := t.sessionID
+	t.mu.Unlock()
+	if sessionID == "" {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), _commandShutdownTimeout)
+	defer cancel()
+	req, err := t.newRequest(ctx, http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	// Servers not allowing clients to end sessions answer 405.
+	return nil
+}
@@ -0,0 +1,56 @@
+package outputparser
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/schema"
+)
+
+var numberedItem = regexp.MustCompile(`^\s*\d+\s*[.)]\s+(.+)$`)
+
+// NumberedList is an output parser used to parse a numbered list, e.g. a plan,
+// in the output of an LLM as a string slice. Lines that are not items of the
+// list, such as a header, are ignored.
+type NumberedList struct{}
+
+// NewNumberedList creates a new NumberedList.
+func NewNumberedList() NumberedList {
+	return NumberedList{}
+}
+
+// Statically assert that NumberedList implement the OutputParser interface.
+var _ schema.OutputParser[[]string] = NumberedList{}
+
+// GetFormatInstructions returns the format instruction.
+func (p NumberedList) GetFormatInstructions() string {
+	return "Your response should be a numbered list, with one item per line, eg:\n1. foo\n2. bar"
+}
+
+// Parse parses the output of an LLM into a string slice.
+func (p NumberedList) Parse(text string) ([]string, error) {
+	var items []string
+	for _, line := range strings.Split(text, "\n") {
+		if match := numberedItem.FindStringSubmatch(line); match != nil {
+			items = append(items, strings.TrimSpace(match[1]))
+		}
+	}
+
+	if len(items) == 0 {
+		return nil, ParseError{
+			Text:   text,
+			Reason: "Expected a numbered list",
+		}
+	}
+	return items, nil
+}
+
+// ParseWithPrompt with prompts does the same as Parse.
+func (p NumberedList) ParseWithPrompt(text string, _ llms.PromptValue) ([]string, error) {
+	return p.Parse(text)
+}
+
+func (p NumberedList) Type() string {
+	return "numbered_list_parser"
+}
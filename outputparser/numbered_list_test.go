@@ -0,0 +1,42 @@
+package outputparser_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/outputparser"
+)
+
+func TestNumberedList(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		input    string
+		expected []string
+	}{
+		{
+			input:    "1. foo\n2. bar",
+			expected: []string{"foo", "bar"},
+		},
+		{
+			input:    "Plan:\n  1) foo bar \n\n 2) baz\n<END_OF_PLAN>",
+			expected: []string{"foo bar", "baz"},
+		},
+		{
+			input:    "10. foo\nsome text\n11. bar",
+			expected: []string{"foo", "bar"},
+		},
+	}
+
+	parser := outputparser.NewNumberedList()
+
+	for _, tc := range testCases {
+		output, err := parser.Parse(tc.input)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, output)
+	}
+
+	_, err := parser.Parse("no plan")
+	require.ErrorAs(t, err, &outputparser.ParseError{})
+}
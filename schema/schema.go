@@ -6,6 +6,10 @@ type AgentAction struct {
 	ToolInput string
 	Log       string
 	ToolID    string
+	// TurnID identifies the model response the action comes from, for agents
+	// whose model can make several tool calls in one response. The actions of
+	// the same response share it.
+	TurnID string
 }
 
 // AgentStep is a step of the agent.
@@ -0,0 +1,169 @@
+package chaintool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/tools"
+)
+
+var (
+	// ErrInputKey is returned when the input key of the chain cannot be chosen
+	// and was not set with WithInputKey.
+	ErrInputKey = errors.New("chain does not have a single input key")
+	// ErrOutputKey is returned when the output key of the chain cannot be
+	// chosen and was not set with WithOutputKey, or is missing from the
+	// outputs of the chain.
+	ErrOutputKey = errors.New("chain does not have a single output key")
+)
+
+// Tool is a tool running a chain with its input, and returning the output of
+// the chain.
+type Tool struct {
+	CallbacksHandler callbacks.Handler
+
+	name        string
+	description string
+	chain       chains.Chain
+	inputKey    string
+	outputKey   string
+	callOptions []chains.ChainCallOption
+	// parseInputs returns the inputs of the chain from the input of the tool,
+	// if the input is not given as the single input of the chain.
+	parseInputs func(input string) (map[string]any, error)
+}
+
+var _ tools.Tool = &Tool{}
+
+// New creates a new tool running a chain. The name and description are the
+// ones given to the agents using the tool, and should say what the chain is
+// useful for and what its input should be.
+func New(name, description string, chain chains.Chain, opts ...Option) *Tool {
+	options := &options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	return &Tool{
+		CallbacksHandler: options.callbacksHandler,
+		name:             name,
+		description:      description,
+		chain:            chain,
+		inputKey:         options.inputKey,
+		outputKey:        options.outputKey,
+		callOptions:      options.callOptions,
+	}
+}
+
+// Name returns the name of the tool.
+func (t *Tool) Name() string {
+	return t.name
+}
+
+// Description returns the description of the tool.
+func (t *Tool) Description() string {
+	return t.description
+}
+
+// Chain returns the chain run by the tool.
+func (t *Tool) Chain() chains.Chain { //nolint:ireturn
+	return t.chain
+}
+
+// Call runs the chain with the input and returns its output.
+func (t *Tool) Call(ctx context.Context, input string) (string, error) {
+	if t.CallbacksHandler != nil {
+		t.CallbacksHandler.HandleToolStart(ctx, input)
+	}
+
+	result, err := t.call(ctx, input)
+	if err != nil {
+		if t.CallbacksHandler != nil {
+			t.CallbacksHandler.HandleToolError(ctx, err)
+		}
+		return "", err
+	}
+
+	if t.CallbacksHandler != nil {
+		t.CallbacksHandler.HandleToolEnd(ctx, result)
+	}
+	return result, nil
+}
+
+// call runs the chain with the inputs of the input and returns its output.
+func (t *Tool) call(ctx context.Context, input string) (string, error) {
+	inputs, err := t.inputs(ctx, input)
+	if err != nil {
+		return "", err
+	}
+	outputKey, err := t.getOutputKey()
+	if err != nil {
+		return "", err
+	}
+
+	outputs, err := chains.Call(ctx, t.chain, inputs, t.callOptions...)
+	if err != nil {
+		return "", err
+	}
+
+	output, ok := outputs[outputKey]
+	if !ok {
+		return "", fmt.Errorf("%w: %s not in outputs", ErrOutputKey, outputKey)
+	}
+	if s, ok := output.(string); ok {
+		return s, nil
+	}
+	return fmt.Sprint(output), nil
+}
+
+// inputs returns the inputs of the chain for the input of the tool.
+func (t *Tool) inputs(ctx context.Context, input string) (map[string]any, error) {
+	if t.parseInputs != nil {
+		return t.parseInputs(input)
+	}
+	inputKey, err := t.getInputKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{inputKey: input}, nil
+}
+
+func (t *Tool) getInputKey(ctx context.Context) (string, error) {
+	if t.inputKey != "" {
+		return t.inputKey, nil
+	}
+
+	inputKeys := t.getInputKeys(ctx)
+	if len(inputKeys) != 1 {
+		return "", fmt.Errorf("%w: %v", ErrInputKey, inputKeys)
+	}
+	return inputKeys[0], nil
+}
+
+// getInputKeys returns the input keys of the chain not given by its memory.
+func (t *Tool) getInputKeys(ctx context.Context) []string {
+	memoryKeys := t.chain.GetMemory().MemoryVariables(ctx)
+	var inputKeys []string
+	for _, key := range t.chain.GetInputKeys() {
+		if !slices.Contains(memoryKeys, key) {
+			inputKeys = append(inputKeys, key)
+		}
+	}
+	return inputKeys
+}
+
+func (t *Tool) getOutputKey() (string, error) {
+	if t.outputKey != "" {
+		return t.outputKey, nil
+	}
+
+	outputKeys := t.chain.GetOutputKeys()
+	if len(outputKeys) != 1 {
+		return "", fmt.Errorf("%w: %v", ErrOutputKey, outputKeys)
+	}
+	return outputKeys[0], nil
+}
@@ -0,0 +1,85 @@
+package chaintool_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/tools"
+	"github.com/tmc/langchaingo/tools/chaintool"
+)
+
+func TestTool(t *testing.T) {
+	t.Parallel()
+
+	upper := chains.NewTransform(
+		func(_ context.Context, inputs map[string]any, _ ...chains.ChainCallOption) (map[string]any, error) {
+			query, _ := inputs["query"].(string)
+			return map[string]any{"answer": strings.ToUpper(query), "length": len(query)}, nil
+		},
+		[]string{"query"},
+		[]string{"answer", "length"},
+	)
+
+	tool := chaintool.New("upper", "Upper cases the input.", upper)
+	require.Equal(t, "upper", tool.Name())
+	_, err := tool.Call(context.Background(), "hello")
+	require.ErrorIs(t, err, chaintool.ErrOutputKey)
+
+	tool = chaintool.New("upper", "Upper cases the input.", upper, chaintool.WithOutputKey("answer"))
+	result, err := tool.Call(context.Background(), "hello")
+	require.NoError(t, err)
+	require.Equal(t, "HELLO", result)
+
+	tool = chaintool.New("length", "Counts the characters of the input.", upper, chaintool.WithOutputKey("length"))
+	result, err = tool.Call(context.Background(), "hello")
+	require.NoError(t, err)
+	require.Equal(t, "5", result)
+}
+
+func TestStructuredTool(t *testing.T) {
+	t.Parallel()
+
+	translate := chains.NewTransform(
+		func(_ context.Context, inputs map[string]any, _ ...chains.ChainCallOption) (map[string]any, error) {
+			return map[string]any{"translation": fmt.Sprintf("%v in %v", inputs["text"], inputs["language"])}, nil
+		},
+		[]string{"text", "language"},
+		[]string{"translation"},
+	)
+
+	tool, err := chaintool.NewStructured("translate", "Translates a text.", translate,
+		chaintool.WithInputDescription("language", "The language to translate to."))
+	require.NoError(t, err)
+	require.Equal(t, jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"text":     {Type: jsonschema.String},
+			"language": {Type: jsonschema.String, Description: "The language to translate to."},
+		},
+		Required: []string{"text", "language"},
+	}, tool.Parameters())
+
+	result, err := tool.Call(context.Background(), `{"text": "hello", "language": "French"}`)
+	require.NoError(t, err)
+	require.Equal(t, "hello in French", result)
+	_, err = tool.Call(context.Background(), `{"text": "hello"}`)
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+
+	tool, err = chaintool.NewStructured("translate", "Translates a text.", translate,
+		chaintool.WithParameters(jsonschema.Definition{
+			Type: jsonschema.Object,
+			Properties: map[string]jsonschema.Definition{
+				"text":     {Type: jsonschema.String},
+				"language": {Type: jsonschema.String, Enum: []string{"French", "German"}},
+			},
+			Required: []string{"text", "language"},
+		}))
+	require.NoError(t, err)
+	_, err = tool.Call(context.Background(), `{"text": "hello", "language": "Klingon"}`)
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+}
@@ -0,0 +1,6 @@
+// Package chaintool contains an implementation of the tool interface running a
+// chain, e.g. a retrieval QA chain or an agents.Executor, so that an agent can
+// delegate work to other chains and agents. A Tool gives its input to the
+// single input of the chain, and a StructuredTool takes a JSON object with the
+// inputs of a chain having several.
+package chaintool
@@ -0,0 +1,70 @@
+package chaintool
+
+import (
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+type options struct {
+	inputKey         string
+	outputKey        string
+	callOptions      []chains.ChainCallOption
+	callbacksHandler callbacks.Handler
+
+	parameters        *jsonschema.Definition
+	inputDescriptions map[string]string
+}
+
+// Option is a function for configuring a Tool.
+type Option func(*options)
+
+// WithInputKey sets the input of the chain the input of the tool is given as.
+// Defaults to the only input of the chain not given by its memory.
+func WithInputKey(inputKey string) Option {
+	return func(opts *options) {
+		opts.inputKey = inputKey
+	}
+}
+
+// WithOutputKey sets the output of the chain returned by the tool. Defaults to
+// the only output key of the chain.
+func WithOutputKey(outputKey string) Option {
+	return func(opts *options) {
+		opts.outputKey = outputKey
+	}
+}
+
+// WithCallOptions sets the options the chain is called with.
+func WithCallOptions(callOptions ...chains.ChainCallOption) Option {
+	return func(opts *options) {
+		opts.callOptions = callOptions
+	}
+}
+
+// WithCallbacksHandler sets the callbacks handler of the tool.
+func WithCallbacksHandler(handler callbacks.Handler) Option {
+	return func(opts *options) {
+		opts.callbacksHandler = handler
+	}
+}
+
+// WithParameters sets the JSON schema of the inputs of a StructuredTool, e.g.
+// for inputs which are not strings. The properties of the schema are the input
+// keys of the chain.
+func WithParameters(parameters jsonschema.Definition) Option {
+	return func(opts *options) {
+		opts.parameters = &parameters
+	}
+}
+
+// WithInputDescription sets the description of an input of the chain in the
+// schema generated for a StructuredTool.
+func WithInputDescription(inputKey, description string) Option {
+	return func(opts *options) {
+		if opts.inputDescriptions == nil {
+			opts.inputDescriptions = make(map[string]string)
+		}
+		opts.inputDescriptions[inputKey] = description
+	}
+}
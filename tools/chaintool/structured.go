@@ -0,0 +1,72 @@
+package chaintool
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// StructuredTool is a tool running a chain with several inputs. Its input is a
+// JSON object with the inputs of the chain, described by a JSON schema, so that
+// agents using the tool calling API of a model can give each input separately.
+// The object is validated against the schema before running the chain.
+type StructuredTool struct {
+	*Tool
+
+	parameters jsonschema.Definition
+}
+
+var _ tools.StructuredTool = &StructuredTool{}
+
+// NewStructured creates a new tool running a chain with the inputs of the JSON
+// object it is given. The schema of the inputs is set with WithParameters, or
+// generated from the input keys of the chain not given by its memory, as
+// required strings described with WithInputDescription.
+func NewStructured(name, description string, chain chains.Chain, opts ...Option) (*StructuredTool, error) {
+	options := &options{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	t := &StructuredTool{Tool: New(name, description, chain, opts...)}
+	t.Tool.parseInputs = t.parseInputs
+	if options.parameters != nil {
+		t.parameters = *options.parameters
+		return t, nil
+	}
+
+	inputKeys := t.getInputKeys(context.Background())
+	if len(inputKeys) == 0 {
+		return nil, fmt.Errorf("%w: the chain has no inputs", ErrInputKey)
+	}
+	t.parameters = jsonschema.Definition{
+		Type:       jsonschema.Object,
+		Properties: make(map[string]jsonschema.Definition, len(inputKeys)),
+		Required:   inputKeys,
+	}
+	for _, key := range inputKeys {
+		t.parameters.Properties[key] = jsonschema.Definition{
+			Type:        jsonschema.String,
+			Description: options.inputDescriptions[key],
+		}
+	}
+	return t, nil
+}
+
+// Parameters returns the JSON schema of the inputs of the chain.
+func (t *StructuredTool) Parameters() jsonschema.Definition {
+	return t.parameters
+}
+
+// parseInputs validates the JSON object of the input against the schema of
+// the tool, and returns its values as the inputs of the chain.
+func (t *StructuredTool) parseInputs(input string) (map[string]any, error) {
+	var inputs map[string]any
+	if err := jsonschema.VerifySchemaAndUnmarshal(t.parameters, []byte(input), &inputs); err != nil {
+		return nil, fmt.Errorf("%w: %w", tools.ErrInvalidArguments, err)
+	}
+	return inputs, nil
+}
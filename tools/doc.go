@@ -1,2 +1,8 @@
 // Package tools defines a standard interface for tools to be used by agents.
+//
+// A Tool takes a free-form string as input. A StructuredTool additionally
+// describes its arguments with a JSON schema, so that agents using the tool
+// calling API of a model can advertise them; its input is the JSON object of
+// the arguments. NewTyped creates a StructuredTool from a function taking a Go
+// value, whose schema is generated from its type.
 package tools
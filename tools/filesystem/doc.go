@@ -0,0 +1,15 @@
+// Package filesystem contains the tools of an agent reading and editing the
+// files of a workspace directory: listing a directory, reading a file by line
+// ranges, searching files with a regular expression, writing or appending to
+// a file, moving and deleting files.
+//
+// All the paths are relative to the root directory of the toolkit, and the
+// tools refuse the paths escaping it, with .. or through symbolic links. The
+// toolkit can be read-only, without the tools changing files, and limits the
+// size of the files written and of the outputs of the tools.
+//
+// The checks do not protect against concurrent changes of the directory by
+// other processes, e.g. a directory replaced by a symbolic link between the
+// check of a path and its use: the agent must be the only one changing the
+// workspace.
+package filesystem
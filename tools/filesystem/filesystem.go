@@ -0,0 +1,275 @@
+package filesystem
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tmc/langchaingo/tools"
+)
+
+// Names of the tools.
+const (
+	ListDirectoryToolName = "list_directory"
+	ReadFileToolName      = "read_file"
+	SearchFilesToolName   = "search_files"
+	WriteFileToolName     = "write_file"
+	MoveFileToolName      = "move_file"
+	DeleteFileToolName    = "delete_file"
+)
+
+var (
+	// ErrOutsideRoot is returned for the paths outside the root directory of
+	// the toolkit.
+	ErrOutsideRoot = errors.New("path outside the root directory")
+	// ErrNotFile is returned when reading or writing a directory or another
+	// file which is not a regular file.
+	ErrNotFile = errors.New("not a regular file")
+	// ErrBinaryFile is returned when reading a file which is not text.
+	ErrBinaryFile = errors.New("binary file")
+	// ErrFileTooLarge is returned when reading or writing a file larger than
+	// the maximum file size.
+	ErrFileTooLarge = errors.New("file too large")
+)
+
+// Toolkit is the set of tools of an agent working on the files of a root
+// directory.
+type Toolkit struct {
+	// root is the absolute path of the root directory, with its symbolic
+	// links evaluated, and dir its absolute path as given.
+	root    string
+	dir     string
+	options options
+
+	readTools  []tools.Tool
+	writeTools []tools.Tool
+}
+
+// New creates a toolkit working on the files of the root directory.
+func New(root string, opts ...Option) (*Toolkit, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	dir, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return nil, err
+	}
+	info, err := os.Stat(resolved)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("root %s is not a directory", root)
+	}
+
+	t := &Toolkit{root: resolved, dir: dir, options: o}
+	if err := t.initTools(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// Root returns the absolute path of the root directory, with its symbolic
+// links evaluated.
+func (t *Toolkit) Root() string {
+	return t.root
+}
+
+// Tools returns the tools of the toolkit: listing a directory, reading a file,
+// searching files, and unless the toolkit is read-only, writing, moving and
+// deleting files.
+func (t *Toolkit) Tools() []tools.Tool {
+	res := append([]tools.Tool{}, t.readTools...)
+	if !t.options.readOnly {
+		res = append(res, t.writeTools...)
+	}
+	return res
+}
+
+func (t *Toolkit) initTools() error {
+	var errs []error
+	add := func(tool tools.Tool, err error) tools.Tool {
+		errs = append(errs, err)
+		return tool
+	}
+
+	t.readTools = []tools.Tool{
+		add(newTool(t, ListDirectoryToolName,
+			"Lists the files of a directory of the workspace, with their sizes. Directories end with a slash.",
+			t.listDirectory)),
+		add(newTool(t, ReadFileToolName,
+			"Reads a text file of the workspace, or a range of its lines. Long files are cut, "+
+				"telling the line to read the rest from.",
+			t.readFile)),
+		add(newTool(t, SearchFilesToolName,
+			"Searches the lines of the files of the workspace matching a regular expression. "+
+				"Returns the matching lines as path:line: text.",
+			t.searchFiles)),
+	}
+	t.writeTools = []tools.Tool{
+		add(newTool(t, WriteFileToolName,
+			"Writes a text file of the workspace, replacing its content or appending to it. "+
+				"The file and its directories are created if needed.",
+			t.writeFile)),
+		add(newTool(t, MoveFileToolName,
+			"Moves or renames a file or a directory of the workspace. The destination must not exist.",
+			t.moveFile)),
+		add(newTool(t, DeleteFileToolName,
+			"Deletes a file or a directory of the workspace.",
+			t.deleteFile)),
+	}
+	return errors.Join(errs...)
+}
+
+// tool is a structured tool of the toolkit, calling the callbacks handler and
+// giving the paths of the errors relative to the root directory.
+type tool struct {
+	tools.StructuredTool
+	toolkit *Toolkit
+}
+
+func newTool[T any](
+	t *Toolkit,
+	name, description string,
+	fn func(ctx context.Context, args T) (string, error),
+) (tools.Tool, error) {
+	typed, err := tools.NewTyped(name, description+" Paths are relative to the root of the workspace.", fn)
+	if err != nil {
+		return nil, err
+	}
+	return tool{StructuredTool: typed, toolkit: t}, nil
+}
+
+func (t tool) Call(ctx context.Context, input string) (string, error) {
+	handler := t.toolkit.options.callbacksHandler
+	if handler != nil {
+		handler.HandleToolStart(ctx, input)
+	}
+
+	result, err := t.StructuredTool.Call(ctx, input)
+	if err != nil {
+		err = t.toolkit.relativeError(err)
+		if handler != nil {
+			handler.HandleToolError(ctx, err)
+		}
+		return "", err
+	}
+
+	if handler != nil {
+		handler.HandleToolEnd(ctx, result)
+	}
+	return result, nil
+}
+
+// rel returns the cleaned path of the named file relative to the root
+// directory. Absolute paths are accepted inside the root directory.
+func (t *Toolkit) rel(name string) (string, error) {
+	name = filepath.FromSlash(strings.TrimSpace(name))
+	if name == "" {
+		return ".", nil
+	}
+	if filepath.IsAbs(name) {
+		for _, root := range []string{t.root, t.dir} {
+			if rel, err := filepath.Rel(root, name); err == nil && filepath.IsLocal(rel) {
+				return rel, nil
+			}
+		}
+		return "", fmt.Errorf("%w: %s", ErrOutsideRoot, filepath.ToSlash(name))
+	}
+	if !filepath.IsLocal(name) {
+		return "", fmt.Errorf("%w: %s", ErrOutsideRoot, filepath.ToSlash(name))
+	}
+	return filepath.Clean(name), nil
+}
+
+// resolve returns the path of the named file in the root directory, with the
+// symbolic links of its directories evaluated, and of the file itself if
+// follow is true. An error is returned if the path is outside the root
+// directory.
+func (t *Toolkit) resolve(name string, follow bool) (string, error) {
+	rel, err := t.rel(name)
+	if err != nil {
+		return "", err
+	}
+	if !follow && rel != "." {
+		dir, err := t.resolve(filepath.Dir(rel), true)
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, filepath.Base(rel)), nil
+	}
+
+	// The symbolic links of the longest existing prefix of the path are
+	// evaluated, the rest of the path being created by the tools.
+	path, missing := filepath.Join(t.root, rel), ""
+	for {
+		resolved, err := filepath.EvalSymlinks(path)
+		if err == nil {
+			path = filepath.Join(resolved, missing)
+			break
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return "", err
+		}
+		if _, err := os.Lstat(path); err == nil {
+			// A broken symbolic link could be created anywhere.
+			return "", fmt.Errorf("%w: %s is a broken symbolic link", ErrOutsideRoot, t.display(path))
+		}
+		missing = filepath.Join(filepath.Base(path), missing)
+		path = filepath.Dir(path)
+	}
+	if !t.contains(path) {
+		return "", fmt.Errorf("%w: %s", ErrOutsideRoot, filepath.ToSlash(name))
+	}
+	return path, nil
+}
+
+// resolveEntry resolves the named file to move or delete, which is not the
+// root directory, without evaluating the file itself if it is a symbolic link.
+func (t *Toolkit) resolveEntry(name string) (string, error) {
+	path, err := t.resolve(name, false)
+	if err != nil {
+		return "", err
+	}
+	if path == t.root {
+		return "", fmt.Errorf("%w: the root directory cannot be changed", tools.ErrInvalidArguments)
+	}
+	return path, nil
+}
+
+// contains reports whether the path is the root directory or in it.
+func (t *Toolkit) contains(path string) bool {
+	return path == t.root || strings.HasPrefix(path, t.root+string(filepath.Separator))
+}
+
+// display returns the path of a file of the root directory as shown to the
+// agent: relative to the root directory, with slashes.
+func (t *Toolkit) display(path string) string {
+	if rel, err := filepath.Rel(t.root, path); err == nil && filepath.IsLocal(rel) {
+		return filepath.ToSlash(rel)
+	}
+	return filepath.ToSlash(path)
+}
+
+// relativeError returns the errors of the os package with their paths
+// relative to the root directory, not to show the agent where it is.
+func (t *Toolkit) relativeError(err error) error {
+	switch e := err.(type) { //nolint:errorlint
+	case *fs.PathError:
+		return &fs.PathError{Op: e.Op, Path: t.display(e.Path), Err: e.Err}
+	case *os.LinkError:
+		return &os.LinkError{Op: e.Op, Old: t.display(e.Old), New: t.display(e.New), Err: e.Err}
+	default:
+		return err
+	}
+}
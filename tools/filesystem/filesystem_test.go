@@ -0,0 +1,194 @@
+package filesystem_test
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/tools"
+	"github.com/tmc/langchaingo/tools/filesystem"
+)
+
+// newWorkspace creates a workspace directory with some files, next to a
+// secret file outside of it.
+func newWorkspace(t *testing.T) (string, string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	root := filepath.Join(dir, "workspace")
+	secret := filepath.Join(dir, "secret.txt")
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "src", "pkg"), 0o755))
+	require.NoError(t, os.WriteFile(secret, []byte("password\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "README.md"), []byte("# Project\n\nSee src.\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "src", "main.go"),
+		[]byte("package main\n\nfunc main() {\n\tprintln(\"hello\")\n}\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "src", "pkg", "pkg.go"),
+		[]byte("package pkg\n\n// Hello says hello.\nfunc Hello() {}\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(root, "image.png"), []byte("\x89PNG\x00\x00"), 0o600))
+	return root, secret
+}
+
+func call(t *testing.T, toolkit *filesystem.Toolkit, name, input string) (string, error) {
+	t.Helper()
+
+	for _, tool := range toolkit.Tools() {
+		if tool.Name() == name {
+			return tool.Call(context.Background(), input)
+		}
+	}
+	t.Fatalf("no tool %s", name)
+	return "", nil
+}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+
+	root, _ := newWorkspace(t)
+	toolkit, err := filesystem.New(root)
+	require.NoError(t, err)
+	small, err := filesystem.New(root, filesystem.WithMaxOutputLength(30))
+	require.NoError(t, err)
+
+	out, err := call(t, toolkit, filesystem.ListDirectoryToolName, `{}`)
+	require.NoError(t, err)
+	require.Equal(t, "README.md (20 bytes)\nimage.png (6 bytes)\nsrc/\n", out)
+	out, err = call(t, toolkit, filesystem.ListDirectoryToolName, `{"path": "src", "recursive": true}`)
+	require.NoError(t, err)
+	require.Equal(t, "src/main.go (48 bytes)\nsrc/pkg/\nsrc/pkg/pkg.go (50 bytes)\n", out)
+
+	out, err = call(t, toolkit, filesystem.ReadFileToolName, `{"path": "src/main.go", "start_line": 3, "end_line": 4}`)
+	require.NoError(t, err)
+	require.Equal(t, "func main() {\n\tprintln(\"hello\")\n", out)
+	out, err = call(t, small, filesystem.ReadFileToolName, `{"path": "src/main.go"}`)
+	require.NoError(t, err)
+	require.Equal(t, "package main\n\nfunc main() {\n[Lines 1 to 3 of the file, read from start_line 4 for more.]", out)
+	_, err = call(t, toolkit, filesystem.ReadFileToolName, `{"path": "src/main.go", "start_line": 10}`)
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+	require.ErrorContains(t, err, "which has 5 lines")
+	_, err = call(t, toolkit, filesystem.ReadFileToolName, `{"path": "image.png"}`)
+	require.ErrorIs(t, err, filesystem.ErrBinaryFile)
+	_, err = call(t, toolkit, filesystem.ReadFileToolName, `{"path": "src"}`)
+	require.ErrorIs(t, err, filesystem.ErrNotFile)
+	_, err = call(t, toolkit, filesystem.ReadFileToolName, `{"path": "missing.txt"}`)
+	require.ErrorIs(t, err, fs.ErrNotExist)
+	require.EqualError(t, err, "open missing.txt: no such file or directory")
+
+	out, err = call(t, small, filesystem.SearchFilesToolName, `{"pattern": "^package", "glob": "*.go"}`)
+	require.NoError(t, err)
+	require.Equal(t, "src/main.go:1: package main\n[Stopped after the first matches, search with a narrower pattern or path.]\n", out) //nolint:lll
+	out, err = call(t, toolkit, filesystem.SearchFilesToolName, `{"pattern": "^func", "path": "src/pkg"}`)
+	require.NoError(t, err)
+	require.Equal(t, "src/pkg/pkg.go:4: func Hello() {}\n", out)
+	out, err = call(t, toolkit, filesystem.SearchFilesToolName, `{"pattern": "PNG"}`)
+	require.NoError(t, err)
+	require.Equal(t, "No matches found.", out)
+	_, err = call(t, toolkit, filesystem.SearchFilesToolName, `{"pattern": "("}`)
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+
+	root, _ := newWorkspace(t)
+	toolkit, err := filesystem.New(root, filesystem.WithMaxFileSize(30))
+	require.NoError(t, err)
+
+	out, err := call(t, toolkit, filesystem.WriteFileToolName, `{"path": "docs/notes.txt", "content": "first\n"}`)
+	require.NoError(t, err)
+	require.Equal(t, "Wrote 6 bytes to docs/notes.txt.", out)
+	out, err = call(t, toolkit, filesystem.WriteFileToolName, `{"path": "docs/notes.txt", "content": "second\n", "append": true}`)
+	require.NoError(t, err)
+	require.Equal(t, "Appended 7 bytes to docs/notes.txt.", out)
+	data, err := os.ReadFile(filepath.Join(root, "docs", "notes.txt"))
+	require.NoError(t, err)
+	require.Equal(t, "first\nsecond\n", string(data))
+
+	_, err = call(t, toolkit, filesystem.WriteFileToolName,
+		`{"path": "docs/notes.txt", "content": "this line is too long\n", "append": true}`)
+	require.ErrorIs(t, err, filesystem.ErrFileTooLarge)
+	require.ErrorContains(t, err, "docs/notes.txt would be 35 bytes, the maximum is 30")
+	_, err = call(t, toolkit, filesystem.WriteFileToolName, `{"path": "src", "content": ""}`)
+	require.ErrorIs(t, err, filesystem.ErrNotFile)
+	require.NoError(t, os.WriteFile(filepath.Join(root, "docs", "big.txt"), []byte(strings.Repeat("a", 31)), 0o600))
+	_, err = call(t, toolkit, filesystem.ReadFileToolName, `{"path": "docs/big.txt"}`)
+	require.ErrorIs(t, err, filesystem.ErrFileTooLarge)
+	require.ErrorContains(t, err, "docs/big.txt is 31 bytes, the maximum is 30")
+
+	out, err = call(t, toolkit, filesystem.MoveFileToolName, `{"source": "docs/notes.txt", "destination": "archive/notes.txt"}`)
+	require.NoError(t, err)
+	require.Equal(t, "Moved docs/notes.txt to archive/notes.txt.", out)
+	_, err = call(t, toolkit, filesystem.MoveFileToolName, `{"source": "README.md", "destination": "archive/notes.txt"}`)
+	require.ErrorIs(t, err, fs.ErrExist)
+	_, err = call(t, toolkit, filesystem.MoveFileToolName, `{"source": ".", "destination": "other"}`)
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+
+	_, err = call(t, toolkit, filesystem.DeleteFileToolName, `{"path": "src"}`)
+	require.Error(t, err)
+	out, err = call(t, toolkit, filesystem.DeleteFileToolName, `{"path": "src", "recursive": true}`)
+	require.NoError(t, err)
+	require.Equal(t, "Deleted src.", out)
+	require.NoDirExists(t, filepath.Join(root, "src"))
+
+	readOnly, err := filesystem.New(root, filesystem.WithReadOnly())
+	require.NoError(t, err)
+	names := make([]string, 0)
+	for _, tool := range readOnly.Tools() {
+		names = append(names, tool.Name())
+	}
+	require.Equal(t, []string{
+		filesystem.ListDirectoryToolName,
+		filesystem.ReadFileToolName,
+		filesystem.SearchFilesToolName,
+	}, names)
+}
+
+func TestEscapes(t *testing.T) {
+	t.Parallel()
+
+	root, secret := newWorkspace(t)
+	require.NoError(t, os.Symlink(secret, filepath.Join(root, "link.txt")))
+	require.NoError(t, os.Symlink(filepath.Dir(secret), filepath.Join(root, "parent")))
+	require.NoError(t, os.Symlink(filepath.Join(filepath.Dir(secret), "new.txt"), filepath.Join(root, "broken.txt")))
+	require.NoError(t, os.Symlink("src/main.go", filepath.Join(root, "main.go")))
+
+	toolkit, err := filesystem.New(root)
+	require.NoError(t, err)
+
+	for _, c := range []struct{ name, input string }{
+		{filesystem.ReadFileToolName, `{"path": "../secret.txt"}`},
+		{filesystem.ReadFileToolName, `{"path": "src/../../secret.txt"}`},
+		{filesystem.ReadFileToolName, `{"path": "` + secret + `"}`},
+		{filesystem.ReadFileToolName, `{"path": "link.txt"}`},
+		{filesystem.ReadFileToolName, `{"path": "parent/secret.txt"}`},
+		{filesystem.ListDirectoryToolName, `{"path": "parent"}`},
+		{filesystem.SearchFilesToolName, `{"pattern": "password", "path": ".."}`},
+		{filesystem.WriteFileToolName, `{"path": "broken.txt", "content": "x"}`},
+		{filesystem.WriteFileToolName, `{"path": "parent/new.txt", "content": "x"}`},
+		{filesystem.MoveFileToolName, `{"source": "README.md", "destination": "parent/README.md"}`},
+		{filesystem.DeleteFileToolName, `{"path": "parent/secret.txt"}`},
+	} {
+		_, err := call(t, toolkit, c.name, c.input)
+		require.ErrorIs(t, err, filesystem.ErrOutsideRoot, c.input)
+	}
+	require.NoFileExists(t, filepath.Join(filepath.Dir(secret), "new.txt"))
+
+	// Symbolic links inside the root directory are followed, and the links
+	// themselves are deleted.
+	out, err := call(t, toolkit, filesystem.ReadFileToolName, `{"path": "main.go", "end_line": 1}`)
+	require.NoError(t, err)
+	require.Equal(t, "package main\n", out)
+	out, err = call(t, toolkit, filesystem.ReadFileToolName, `{"path": "`+filepath.Join(root, "README.md")+`"}`)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(out, "# Project"))
+	_, err = call(t, toolkit, filesystem.DeleteFileToolName, `{"path": "link.txt"}`)
+	require.NoError(t, err)
+	require.FileExists(t, secret)
+
+	out, err = call(t, toolkit, filesystem.SearchFilesToolName, `{"pattern": "password"}`)
+	require.NoError(t, err)
+	require.Equal(t, "No matches found.", out)
+}
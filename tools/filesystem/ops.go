@@ -0,0 +1,387 @@
+package filesystem
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tmc/langchaingo/tools"
+)
+
+// _maxMatchLength is the maximum length of the lines of the matches of a
+// search.
+const _maxMatchLength = 200
+
+type listDirectoryArgs struct {
+	Path      string `json:"path,omitempty" description:"The directory to list. Defaults to the root of the workspace."`
+	Recursive bool   `json:"recursive,omitempty" description:"Whether to list the files of the subdirectories too."`
+}
+
+// listDirectory lists the entries of a directory, with their paths relative to
+// the root directory.
+func (t *Toolkit) listDirectory(ctx context.Context, args listDirectoryArgs) (string, error) {
+	dir, err := t.resolve(args.Path, true)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	entries := 0
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == dir {
+			if !d.IsDir() {
+				return fmt.Errorf("%s is not a directory", t.display(dir))
+			}
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entries == t.options.maxResults {
+			fmt.Fprintf(&b, "[Stopped after %d entries.]\n", entries)
+			return fs.SkipAll
+		}
+		entries++
+
+		switch {
+		case d.IsDir():
+			fmt.Fprintf(&b, "%s/\n", t.display(path))
+			if !args.Recursive {
+				return fs.SkipDir
+			}
+		case d.Type()&fs.ModeSymlink != 0:
+			fmt.Fprintf(&b, "%s (symbolic link)\n", t.display(path))
+		default:
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			fmt.Fprintf(&b, "%s (%d bytes)\n", t.display(path), info.Size())
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if entries == 0 {
+		return "The directory is empty.", nil
+	}
+	return t.cut(b.String(), "Stopped after the first entries, list a subdirectory for more."), nil
+}
+
+type readFileArgs struct {
+	Path      string `json:"path" description:"The file to read."`
+	StartLine int    `json:"start_line,omitempty" description:"The first line to read, starting at 1. Defaults to 1."`
+	EndLine   int    `json:"end_line,omitempty" description:"The last line to read, included. Defaults to the last line."`
+}
+
+// readFile returns the lines of a file from the start line to the end line,
+// with a note telling where to read the rest from if the output is cut.
+func (t *Toolkit) readFile(_ context.Context, args readFileArgs) (string, error) {
+	start := max(args.StartLine, 1)
+	if args.EndLine != 0 && args.EndLine < start {
+		return "", fmt.Errorf("%w: end_line %d is before start_line %d", tools.ErrInvalidArguments, args.EndLine, start)
+	}
+	f, err := t.openFile(args.Path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	var b strings.Builder
+	// The file may have grown since it was opened.
+	reader := bufio.NewReader(io.LimitReader(f, t.options.maxFileSize))
+	line, last, more := 0, 0, false
+	for !more {
+		text, err := reader.ReadString('\n')
+		if text != "" {
+			line++
+			if strings.IndexByte(text, 0) >= 0 {
+				return "", fmt.Errorf("%w: %s", ErrBinaryFile, args.Path)
+			}
+			switch {
+			case line < start:
+			case args.EndLine != 0 && line > args.EndLine:
+				// The end line is reached.
+				err = io.EOF
+			case b.Len()+len(text) > t.options.maxOutputLength:
+				if b.Len() == 0 {
+					// The first line is cut, not to return nothing.
+					b.WriteString(truncate(text, t.options.maxOutputLength) + "\n")
+					last = line
+				}
+				more = true
+			default:
+				b.WriteString(text)
+				last = line
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if line < start && args.StartLine > 1 {
+		return "", fmt.Errorf("%w: start_line %d is after the end of the file, which has %d lines",
+			tools.ErrInvalidArguments, start, line)
+	}
+	if more {
+		if !strings.HasSuffix(b.String(), "\n") {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "[Lines %d to %d of the file, read from start_line %d for more.]", start, last, last+1)
+	}
+	return b.String(), nil
+}
+
+type searchFilesArgs struct {
+	Pattern string `json:"pattern" description:"The regular expression to search for, in Go syntax."`
+	Path    string `json:"path,omitempty" description:"The directory or the file to search in. Defaults to the root of the workspace."` //nolint:lll
+	Glob    string `json:"glob,omitempty" description:"Only search the files whose name matches this pattern, e.g. *.go."`
+}
+
+// searchFiles returns the lines of the text files matching the pattern, with
+// their path and line number. The files larger than the maximum file size are
+// skipped.
+func (t *Toolkit) searchFiles(ctx context.Context, args searchFilesArgs) (string, error) {
+	re, err := regexp.Compile(args.Pattern)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", tools.ErrInvalidArguments, err)
+	}
+	if _, err := filepath.Match(args.Glob, ""); err != nil {
+		return "", fmt.Errorf("%w: glob %s: %w", tools.ErrInvalidArguments, args.Glob, err)
+	}
+	root, err := t.resolve(args.Path, true)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	matches := 0
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		if matched, _ := filepath.Match(args.Glob, d.Name()); args.Glob != "" && !matched {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil || info.Size() > t.options.maxFileSize {
+			return nil //nolint:nilerr
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if !utf8.Valid(data) || strings.IndexByte(string(data), 0) >= 0 {
+			return nil
+		}
+
+		for i, line := range strings.Split(string(data), "\n") {
+			if !re.MatchString(line) {
+				continue
+			}
+			if matches == t.options.maxResults {
+				fmt.Fprintf(&b, "[Stopped after %d matches.]\n", matches)
+				return fs.SkipAll
+			}
+			matches++
+			fmt.Fprintf(&b, "%s:%d: %s\n", t.display(path), i+1,
+				truncate(strings.TrimSpace(line), _maxMatchLength))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if matches == 0 {
+		return "No matches found.", nil
+	}
+	return t.cut(b.String(), "Stopped after the first matches, search with a narrower pattern or path."), nil
+}
+
+type writeFileArgs struct {
+	Path    string `json:"path" description:"The file to write."`
+	Content string `json:"content" description:"The text to write."`
+	Append  bool   `json:"append,omitempty" description:"Whether to append the text to the file instead of replacing its content."`
+}
+
+// writeFile writes or appends the content to a file, creating it and its
+// directories if needed.
+func (t *Toolkit) writeFile(_ context.Context, args writeFileArgs) (string, error) {
+	path, err := t.resolve(args.Path, true)
+	if err != nil {
+		return "", err
+	}
+	size := int64(len(args.Content))
+	info, err := os.Stat(path)
+	switch {
+	case err == nil && !info.Mode().IsRegular():
+		return "", fmt.Errorf("%w: %s", ErrNotFile, t.display(path))
+	case err == nil && args.Append:
+		size += info.Size()
+	case err != nil && !errors.Is(err, fs.ErrNotExist):
+		return "", err
+	}
+	if size > t.options.maxFileSize {
+		return "", fmt.Errorf("%w: %s would be %d bytes, the maximum is %d",
+			ErrFileTooLarge, t.display(path), size, t.options.maxFileSize)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:gomnd
+		return "", err
+	}
+	flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if args.Append {
+		flag = os.O_WRONLY | os.O_CREATE | os.O_APPEND
+	}
+	f, err := os.OpenFile(path, flag, 0o644) //nolint:gomnd
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.WriteString(args.Content); err != nil {
+		f.Close()
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	if args.Append {
+		return fmt.Sprintf("Appended %d bytes to %s.", len(args.Content), t.display(path)), nil
+	}
+	return fmt.Sprintf("Wrote %d bytes to %s.", len(args.Content), t.display(path)), nil
+}
+
+type moveFileArgs struct {
+	Source      string `json:"source" description:"The file or directory to move."`
+	Destination string `json:"destination" description:"The new path of the file or directory."`
+}
+
+// moveFile moves a file or a directory, creating the directories of the
+// destination if needed. The destination must not exist.
+func (t *Toolkit) moveFile(_ context.Context, args moveFileArgs) (string, error) {
+	source, err := t.resolveEntry(args.Source)
+	if err != nil {
+		return "", err
+	}
+	destination, err := t.resolveEntry(args.Destination)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Lstat(source); err != nil {
+		return "", err
+	}
+	if _, err := os.Lstat(destination); err == nil {
+		return "", fmt.Errorf("%w: %s", fs.ErrExist, t.display(destination))
+	}
+
+	if err := os.MkdirAll(filepath.Dir(destination), 0o755); err != nil { //nolint:gomnd
+		return "", err
+	}
+	if err := os.Rename(source, destination); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Moved %s to %s.", t.display(source), t.display(destination)), nil
+}
+
+type deleteFileArgs struct {
+	Path      string `json:"path" description:"The file or directory to delete."`
+	Recursive bool   `json:"recursive,omitempty" description:"Whether to delete a directory which is not empty, with all its files."`
+}
+
+// deleteFile deletes a file or a directory. A symbolic link is deleted, not
+// the file it points to.
+func (t *Toolkit) deleteFile(_ context.Context, args deleteFileArgs) (string, error) {
+	path, err := t.resolveEntry(args.Path)
+	if err != nil {
+		return "", err
+	}
+	info, err := os.Lstat(path)
+	if err != nil {
+		return "", err
+	}
+	if info.IsDir() && args.Recursive {
+		err = os.RemoveAll(path)
+	} else {
+		err = os.Remove(path)
+	}
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("Deleted %s.", t.display(path)), nil
+}
+
+// openFile opens a regular file of the root directory, which is not larger than
+// the maximum file size.
+func (t *Toolkit) openFile(name string) (*os.File, error) {
+	path, err := t.resolve(name, true)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		f.Close()
+		return nil, fmt.Errorf("%w: %s", ErrNotFile, t.display(path))
+	}
+	if info.Size() > t.options.maxFileSize {
+		f.Close()
+		return nil, fmt.Errorf("%w: %s is %d bytes, the maximum is %d",
+			ErrFileTooLarge, t.display(path), info.Size(), t.options.maxFileSize)
+	}
+	return f, nil
+}
+
+// cut cuts an output longer than the maximum output length at the end of a
+// line, followed by the note.
+func (t *Toolkit) cut(output, note string) string {
+	if len(output) <= t.options.maxOutputLength {
+		return output
+	}
+	if i := strings.LastIndexByte(output[:t.options.maxOutputLength], '\n'); i >= 0 {
+		output = output[:i+1]
+	} else {
+		output = truncate(output, t.options.maxOutputLength) + "\n"
+	}
+	return output + "[" + note + "]\n"
+}
+
+// truncate cuts a line longer than n bytes, without splitting a rune.
+func truncate(s string, n int) string {
+	s = strings.TrimRight(s, "\r\n")
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "..."
+}
@@ -0,0 +1,68 @@
+package filesystem
+
+import "github.com/tmc/langchaingo/callbacks"
+
+const (
+	_defaultMaxFileSize     = 1 << 20
+	_defaultMaxOutputLength = 20000
+	_defaultMaxResults      = 100
+)
+
+type options struct {
+	readOnly         bool
+	maxFileSize      int64
+	maxOutputLength  int
+	maxResults       int
+	callbacksHandler callbacks.Handler
+}
+
+func defaultOptions() options {
+	return options{
+		maxFileSize:     _defaultMaxFileSize,
+		maxOutputLength: _defaultMaxOutputLength,
+		maxResults:      _defaultMaxResults,
+	}
+}
+
+// Option is a function for configuring a Toolkit.
+type Option func(*options)
+
+// WithReadOnly makes the toolkit read-only: it only has the tools listing,
+// reading and searching files.
+func WithReadOnly() Option {
+	return func(opts *options) {
+		opts.readOnly = true
+	}
+}
+
+// WithMaxFileSize sets the maximum size in bytes of the files read and written,
+// and of the files searched. Defaults to 1 MiB.
+func WithMaxFileSize(size int64) Option {
+	return func(opts *options) {
+		opts.maxFileSize = size
+	}
+}
+
+// WithMaxOutputLength sets the maximum length in bytes of the output of the
+// tools: longer outputs are cut, telling the agent how to get the rest.
+// Defaults to 20000.
+func WithMaxOutputLength(length int) Option {
+	return func(opts *options) {
+		opts.maxOutputLength = length
+	}
+}
+
+// WithMaxResults sets the maximum number of entries listed and of matches of a
+// search. Defaults to 100.
+func WithMaxResults(results int) Option {
+	return func(opts *options) {
+		opts.maxResults = results
+	}
+}
+
+// WithCallbacksHandler sets the callbacks handler of the tools.
+func WithCallbacksHandler(handler callbacks.Handler) Option {
+	return func(opts *options) {
+		opts.callbacksHandler = handler
+	}
+}
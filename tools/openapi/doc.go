@@ -0,0 +1,9 @@
+// Package openapi contains a toolkit generating a tool for each operation of an
+// OpenAPI 3 specification, so that agents can call an API with arguments
+// matching the schemas of its parameters and request bodies, rather than
+// writing raw HTTP requests.
+//
+// The requests are sent with an injectable HTTP client, can be edited before
+// being sent, e.g. for authentication with WithRequestEditor, and are only sent
+// to allowed hosts. Large responses are truncated.
+package openapi
@@ -0,0 +1,301 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/tools"
+	"sigs.k8s.io/yaml"
+)
+
+var (
+	// ErrInvalidSpec is returned when a specification cannot be parsed.
+	ErrInvalidSpec = errors.New("invalid OpenAPI specification")
+	// ErrNoBaseURL is returned when the base URL of the API is unknown: the
+	// specification has no absolute server URL and no WithBaseURL option.
+	ErrNoBaseURL = errors.New("no base URL for the API")
+)
+
+// _methods are the methods of the operations of a path, in the order of the
+// specification.
+var _methods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"} //nolint:gochecknoglobals
+
+// _maxRefDepth limits the resolution of nested references, which may be
+// recursive.
+const _maxRefDepth = 8
+
+// Toolkit is the set of tools of the operations of an API.
+type Toolkit struct {
+	// Title and Description are the ones of the API.
+	Title       string
+	Description string
+
+	tools []*Tool
+}
+
+// NewToolkit creates the tools of the operations of an OpenAPI 3
+// specification, in JSON or YAML.
+func NewToolkit(spec []byte, opts ...Option) (*Toolkit, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	jsonSpec, err := yaml.YAMLToJSON(spec)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSpec, err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(jsonSpec, &doc); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidSpec, err)
+	}
+	if version, _ := doc["openapi"].(string); !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("%w: unsupported version %q, only OpenAPI 3 is supported", ErrInvalidSpec, version)
+	}
+
+	c, err := newClient(doc, o)
+	if err != nil {
+		return nil, err
+	}
+
+	info, _ := doc["info"].(map[string]any)
+	toolkit := &Toolkit{}
+	toolkit.Title, _ = info["title"].(string)
+	toolkit.Description, _ = info["description"].(string)
+
+	paths, _ := doc["paths"].(map[string]any)
+	pathNames := make([]string, 0, len(paths))
+	for path := range paths {
+		pathNames = append(pathNames, path)
+	}
+	sort.Strings(pathNames)
+
+	names := make(map[string]int)
+	for _, path := range pathNames {
+		item, _ := resolve(doc, paths[path], 0).(map[string]any)
+		for _, method := range _methods {
+			op, ok := item[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			operationID, _ := op["operationId"].(string)
+			if len(o.operations) > 0 && !slices.Contains(o.operations, operationID) {
+				continue
+			}
+
+			tool := newTool(doc, c, strings.ToUpper(method), path, item, op)
+			names[tool.name]++
+			if n := names[tool.name]; n > 1 {
+				tool.name = fmt.Sprintf("%s_%d", tool.name, n)
+			}
+			tool.CallbacksHandler = o.callbacksHandler
+			toolkit.tools = append(toolkit.tools, tool)
+		}
+	}
+	return toolkit, nil
+}
+
+// Tools returns the tools of the operations, ordered by path and method.
+func (t *Toolkit) Tools() []tools.Tool {
+	res := make([]tools.Tool, 0, len(t.tools))
+	for _, tool := range t.tools {
+		res = append(res, tool)
+	}
+	return res
+}
+
+func newClient(doc map[string]any, o options) (*client, error) {
+	baseURL := o.baseURL
+	if baseURL == "" {
+		baseURL = serverURL(doc)
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, fmt.Errorf("%w: %q", ErrNoBaseURL, baseURL)
+	}
+
+	allowedHosts := o.allowedHosts
+	if len(allowedHosts) == 0 {
+		allowedHosts = []string{base.Host}
+	}
+
+	c := &client{
+		baseURL:           strings.TrimSuffix(base.String(), "/"),
+		allowedHosts:      allowedHosts,
+		requestEditors:    o.requestEditors,
+		maxResponseLength: o.maxResponseLength,
+	}
+	// A copy of the client, checking the hosts of redirects.
+	httpClient := *o.httpClient
+	checkRedirect := httpClient.CheckRedirect
+	httpClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		if err := c.checkHost(req.URL); err != nil {
+			return err
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		if len(via) >= 10 { //nolint:gomnd
+			return errors.New("stopped after 10 redirects") //nolint:goerr113
+		}
+		return nil
+	}
+	c.httpClient = &httpClient
+	return c, nil
+}
+
+// serverURL returns the URL of the first server of the specification, with
+// the default values of its variables.
+func serverURL(doc map[string]any) string {
+	servers, _ := doc["servers"].([]any)
+	if len(servers) == 0 {
+		return ""
+	}
+	server, _ := servers[0].(map[string]any)
+	serverURL, _ := server["url"].(string)
+	variables, _ := server["variables"].(map[string]any)
+	for name, v := range variables {
+		variable, _ := v.(map[string]any)
+		if value, ok := variable["default"].(string); ok {
+			serverURL = strings.ReplaceAll(serverURL, "{"+name+"}", value)
+		}
+	}
+	return serverURL
+}
+
+var _invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_-]+`)
+
+// toolName returns a name of a tool valid for the tool calling APIs of the
+// models.
+func toolName(operationID, method, path string) string {
+	name := operationID
+	if name == "" {
+		name = strings.ToLower(method) + " " + path
+	}
+	name = strings.Trim(_invalidNameChars.ReplaceAllString(name, "_"), "_")
+	if len(name) > 64 { //nolint:gomnd
+		name = name[:64]
+	}
+	return name
+}
+
+// resolve returns the node with its local references ($ref) replaced by what
+// they reference, up to a maximum depth.
+func resolve(doc map[string]any, node any, depth int) any {
+	switch n := node.(type) {
+	case map[string]any:
+		if ref, ok := n["$ref"].(string); ok {
+			if depth >= _maxRefDepth {
+				return map[string]any{}
+			}
+			return resolve(doc, lookup(doc, ref), depth+1)
+		}
+		res := make(map[string]any, len(n))
+		for key, value := range n {
+			res[key] = resolve(doc, value, depth)
+		}
+		return res
+	case []any:
+		res := make([]any, len(n))
+		for i, value := range n {
+			res[i] = resolve(doc, value, depth)
+		}
+		return res
+	default:
+		return node
+	}
+}
+
+// lookup returns the node of a local reference, e.g.
+// "#/components/schemas/Pet", or nil.
+func lookup(doc map[string]any, ref string) any {
+	pointer, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil
+	}
+	var node any = doc
+	for _, token := range strings.Split(pointer, "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil
+		}
+		node = m[token]
+	}
+	return node
+}
+
+// parameter is a parameter of an operation.
+type parameter struct {
+	name     string
+	in       string
+	required bool
+	schema   jsonschema.Definition
+}
+
+// operationParameters returns the parameters of an operation, including the
+// ones of its path not overridden by the operation. Cookie parameters are not
+// supported.
+func operationParameters(doc, item, op map[string]any) []parameter {
+	var params []parameter
+	add := func(list any) {
+		items, _ := resolve(doc, list, 0).([]any)
+		for _, p := range items {
+			p, _ := p.(map[string]any)
+			name, _ := p["name"].(string)
+			in, _ := p["in"].(string)
+			if name == "" || in == "cookie" {
+				continue
+			}
+			required, _ := p["required"].(bool)
+			schema, _ := p["schema"].(map[string]any)
+			def := jsonschema.FromMap(schema)
+			if description, ok := p["description"].(string); ok {
+				def.Description = description
+			}
+			if def.Type == "" {
+				def.Type = jsonschema.String
+			}
+
+			params = slices.DeleteFunc(params, func(other parameter) bool {
+				return other.name == name && other.in == in
+			})
+			params = append(params, parameter{name: name, in: in, required: required || in == "path", schema: def})
+		}
+	}
+	add(item["parameters"])
+	add(op["parameters"])
+	return params
+}
+
+// requestBody returns the JSON schema of the JSON request body of an
+// operation, if any.
+func requestBody(doc, op map[string]any) (*jsonschema.Definition, bool) {
+	body, ok := resolve(doc, op["requestBody"], 0).(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	content, _ := body["content"].(map[string]any)
+	for mediaType, c := range content {
+		if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+			continue
+		}
+		c, _ := c.(map[string]any)
+		schema, _ := c["schema"].(map[string]any)
+		def := jsonschema.FromMap(schema)
+		if description, ok := body["description"].(string); ok && def.Description == "" {
+			def.Description = description
+		}
+		required, _ := body["required"].(bool)
+		return &def, required
+	}
+	return nil, false
+}
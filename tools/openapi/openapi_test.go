@@ -0,0 +1,172 @@
+package openapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/tools"
+	"github.com/tmc/langchaingo/tools/openapi"
+)
+
+func readSpec(t *testing.T) []byte {
+	t.Helper()
+	spec, err := os.ReadFile("testdata/petstore.yaml")
+	require.NoError(t, err)
+	return spec
+}
+
+// petstore answers with the method, the URL, the authorization and the body
+// of the requests.
+func petstore(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/pets/missing" {
+			http.Error(w, "no such pet", http.StatusNotFound)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		fmt.Fprintf(w, "%s %s auth=%s request=%s body=%s",
+			r.Method, r.URL.RequestURI(), r.Header.Get("Authorization"), r.Header.Get("X-Request-Id"), body)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func toolsByName(t *testing.T, toolkit *openapi.Toolkit) map[string]tools.Tool {
+	t.Helper()
+	res := make(map[string]tools.Tool)
+	for _, tool := range toolkit.Tools() {
+		res[tool.Name()] = tool
+	}
+	return res
+}
+
+func TestToolkit(t *testing.T) {
+	t.Parallel()
+
+	server := petstore(t)
+	toolkit, err := openapi.NewToolkit(readSpec(t),
+		openapi.WithBaseURL(server.URL+"/v1"),
+		openapi.WithHTTPClient(server.Client()),
+		openapi.WithRequestEditor(func(_ context.Context, req *http.Request) error {
+			req.Header.Set("Authorization", "Bearer secret")
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+	require.Equal(t, "Petstore", toolkit.Title)
+
+	byName := toolsByName(t, toolkit)
+	require.Len(t, byName, 3)
+
+	listPets := byName["listPets"].(tools.StructuredTool) //nolint:forcetypeassert
+	require.Equal(t, "List the pets.", listPets.Description())
+	require.Equal(t, jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"limit": {Type: jsonschema.Integer, Description: "The maximum number of pets."},
+			"tags":  {Type: jsonschema.Array, Items: &jsonschema.Definition{Type: jsonschema.String}},
+		},
+	}, listPets.Parameters())
+
+	ctx := context.Background()
+	out, err := listPets.Call(ctx, `{"limit":10,"tags":["cat","dog"]}`)
+	require.NoError(t, err)
+	require.Equal(t, "GET /v1/pets?limit=10&tags=cat&tags=dog auth=Bearer secret request= body=", out)
+
+	getPet := byName["get_pets_petId"].(tools.StructuredTool) //nolint:forcetypeassert
+	require.Equal(t, "Get a pet.\nReturns the pet with the ID.", getPet.Description())
+	require.Equal(t, []string{"petId"}, getPet.Parameters().Required)
+	out, err = getPet.Call(ctx, `{"petId":"a/b","X-Request-Id":"42"}`)
+	require.NoError(t, err)
+	require.Equal(t, "GET /v1/pets/a%2Fb auth=Bearer secret request=42 body=", out)
+
+	_, err = getPet.Call(ctx, `{"petId":"missing"}`)
+	require.ErrorIs(t, err, openapi.ErrRequestFailed)
+	require.ErrorContains(t, err, "no such pet")
+
+	createPet := byName["createPet"].(tools.StructuredTool) //nolint:forcetypeassert
+	body := createPet.Parameters().Properties["body"]
+	require.Equal(t, []string{"name"}, body.Required)
+	// The recursive reference is cut.
+	require.Equal(t, jsonschema.Object, body.Properties["owner"].Properties["pets"].Items.Type)
+	out, err = createPet.Call(ctx, `{"body":{"name":"Rex"}}`)
+	require.NoError(t, err)
+	require.Equal(t, `POST /v1/pets auth=Bearer secret request= body={"name":"Rex"}`, out)
+
+	_, err = createPet.Call(ctx, `{"body":{}}`)
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+	_, err = createPet.Call(ctx, "Rex")
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+}
+
+func TestToolkitHosts(t *testing.T) {
+	t.Parallel()
+
+	toolkit, err := openapi.NewToolkit(readSpec(t), openapi.WithOperations("listPets"))
+	require.NoError(t, err)
+	tools := toolkit.Tools()
+	require.Len(t, tools, 1)
+
+	// The server of the specification is allowed, the host of the editor is not.
+	toolkit, err = openapi.NewToolkit(readSpec(t),
+		openapi.WithRequestEditor(func(_ context.Context, req *http.Request) error {
+			if req.URL.Host != "api.petstore.example.com" {
+				return fmt.Errorf("unexpected host %s", req.URL.Host)
+			}
+			req.URL.Host = "attacker.example.com"
+			return nil
+		}),
+	)
+	require.NoError(t, err)
+	_, err = toolkit.Tools()[0].Call(context.Background(), `{}`)
+	require.ErrorIs(t, err, openapi.ErrHostNotAllowed)
+
+	// Redirects to other hosts are not followed.
+	other := petstore(t)
+	redirect := httptest.NewServer(http.RedirectHandler(other.URL+"/v1/pets", http.StatusFound))
+	defer redirect.Close()
+	toolkit, err = openapi.NewToolkit(readSpec(t), openapi.WithBaseURL(redirect.URL))
+	require.NoError(t, err)
+	_, err = toolkit.Tools()[0].Call(context.Background(), `{}`)
+	require.ErrorIs(t, err, openapi.ErrHostNotAllowed)
+
+	_, err = openapi.NewToolkit(readSpec(t), openapi.WithBaseURL("/v1"))
+	require.ErrorIs(t, err, openapi.ErrNoBaseURL)
+}
+
+func TestToolkitTruncation(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, strings.Repeat("é", 100))
+	}))
+	defer server.Close()
+
+	spec, err := json.Marshal(map[string]any{
+		"openapi": "3.1.0",
+		"info":    map[string]any{"title": "Accents"},
+		"paths": map[string]any{
+			"/accents": map[string]any{"get": map[string]any{"operationId": "accents"}},
+		},
+	})
+	require.NoError(t, err)
+	toolkit, err := openapi.NewToolkit(spec, openapi.WithBaseURL(server.URL), openapi.WithMaxResponseLength(15))
+	require.NoError(t, err)
+
+	out, err := toolkit.Tools()[0].Call(context.Background(), `{}`)
+	require.NoError(t, err)
+	require.Equal(t, strings.Repeat("é", 7)+"\n[response truncated: 14 of at least 200 bytes shown]", out)
+
+	_, err = openapi.NewToolkit([]byte(`swagger: "2.0"`))
+	require.ErrorIs(t, err, openapi.ErrInvalidSpec)
+}
@@ -0,0 +1,91 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/tmc/langchaingo/callbacks"
+)
+
+const _defaultMaxResponseLength = 8000
+
+// RequestEditor edits the requests of the tools before they are sent, e.g. to
+// authenticate them.
+type RequestEditor func(ctx context.Context, req *http.Request) error
+
+type options struct {
+	httpClient        *http.Client
+	baseURL           string
+	allowedHosts      []string
+	requestEditors    []RequestEditor
+	maxResponseLength int
+	operations        []string
+	callbacksHandler  callbacks.Handler
+}
+
+func defaultOptions() options {
+	return options{
+		httpClient:        http.DefaultClient,
+		maxResponseLength: _defaultMaxResponseLength,
+	}
+}
+
+// Option is a function for configuring a Toolkit.
+type Option func(*options)
+
+// WithHTTPClient sets the HTTP client sending the requests. Defaults to
+// http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(opts *options) {
+		opts.httpClient = client
+	}
+}
+
+// WithBaseURL sets the URL the paths of the operations are relative to.
+// Defaults to the URL of the first server of the specification, which must
+// then be absolute.
+func WithBaseURL(baseURL string) Option {
+	return func(opts *options) {
+		opts.baseURL = baseURL
+	}
+}
+
+// WithAllowedHosts sets the hosts, with their port if not the default one, the
+// tools can send requests to, including redirects. Defaults to the host of the
+// base URL.
+func WithAllowedHosts(hosts ...string) Option {
+	return func(opts *options) {
+		opts.allowedHosts = hosts
+	}
+}
+
+// WithRequestEditor adds a function editing the requests before they are
+// sent, e.g. to add credentials.
+func WithRequestEditor(editor RequestEditor) Option {
+	return func(opts *options) {
+		opts.requestEditors = append(opts.requestEditors, editor)
+	}
+}
+
+// WithMaxResponseLength sets the maximum length, in bytes, of the responses
+// returned by the tools. Longer responses are truncated. Defaults to 8000.
+func WithMaxResponseLength(n int) Option {
+	return func(opts *options) {
+		opts.maxResponseLength = n
+	}
+}
+
+// WithOperations restricts the tools to the operations with the IDs. Defaults
+// to all the operations of the specification.
+func WithOperations(operationIDs ...string) Option {
+	return func(opts *options) {
+		opts.operations = operationIDs
+	}
+}
+
+// WithCallbacksHandler sets the callbacks handler of the tools.
+func WithCallbacksHandler(handler callbacks.Handler) Option {
+	return func(opts *options) {
+		opts.callbacksHandler = handler
+	}
+}
@@ -0,0 +1,291 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+var (
+	// ErrHostNotAllowed is returned when a request would be sent to a host not
+	// allowed, see WithAllowedHosts.
+	ErrHostNotAllowed = errors.New("host not allowed")
+	// ErrRequestFailed is returned when the API answers with an error status.
+	// Use agents.ToolErrorHandler to give these errors to the agent.
+	ErrRequestFailed = errors.New("request failed")
+)
+
+// _bodyArgument is the argument of the request body of an operation.
+const _bodyArgument = "body"
+
+// _maxReadLength is the maximum number of bytes read from a response.
+const _maxReadLength = 1 << 20
+
+// Tool is a tool calling an operation of an API.
+type Tool struct {
+	CallbacksHandler callbacks.Handler
+
+	name         string
+	description  string
+	method       string
+	path         string
+	params       []parameter
+	body         *jsonschema.Definition
+	bodyRequired bool
+	client       *client
+}
+
+var _ tools.StructuredTool = &Tool{}
+
+// client sends the requests of the tools of a toolkit.
+type client struct {
+	httpClient        *http.Client
+	baseURL           string
+	allowedHosts      []string
+	requestEditors    []RequestEditor
+	maxResponseLength int
+}
+
+func newTool(doc map[string]any, c *client, method, path string, item, op map[string]any) *Tool {
+	operationID, _ := op["operationId"].(string)
+	summary, _ := op["summary"].(string)
+	description, _ := op["description"].(string)
+	body, bodyRequired := requestBody(doc, op)
+
+	return &Tool{
+		name:         toolName(operationID, method, path),
+		description:  strings.TrimSpace(summary + "\n" + description),
+		method:       method,
+		path:         path,
+		params:       operationParameters(doc, item, op),
+		body:         body,
+		bodyRequired: bodyRequired,
+		client:       c,
+	}
+}
+
+// Name returns the name of the tool: the ID of the operation, or its method
+// and path.
+func (t *Tool) Name() string {
+	return t.name
+}
+
+// Description returns the summary and the description of the operation.
+func (t *Tool) Description() string {
+	if t.description == "" {
+		return t.method + " " + t.path
+	}
+	return t.description
+}
+
+// Method returns the HTTP method of the operation.
+func (t *Tool) Method() string {
+	return t.method
+}
+
+// Path returns the path of the operation, relative to the base URL.
+func (t *Tool) Path() string {
+	return t.path
+}
+
+// Parameters returns the schema of the arguments of the tool: the parameters
+// of the operation, and its JSON request body as "body".
+func (t *Tool) Parameters() jsonschema.Definition {
+	def := jsonschema.Definition{
+		Type:       jsonschema.Object,
+		Properties: make(map[string]jsonschema.Definition, len(t.params)+1),
+	}
+	for _, p := range t.params {
+		def.Properties[p.name] = p.schema
+		if p.required {
+			def.Required = append(def.Required, p.name)
+		}
+	}
+	if t.body != nil {
+		def.Properties[_bodyArgument] = *t.body
+		if t.bodyRequired {
+			def.Required = append(def.Required, _bodyArgument)
+		}
+	}
+	return def
+}
+
+// Call sends the request of the operation with the JSON arguments, and
+// returns the body of the response, truncated if too long. An operation with a
+// single parameter can also be called with the value of the parameter.
+func (t *Tool) Call(ctx context.Context, input string) (string, error) {
+	if t.CallbacksHandler != nil {
+		t.CallbacksHandler.HandleToolStart(ctx, input)
+	}
+
+	result, err := t.call(ctx, input)
+	if err != nil {
+		if t.CallbacksHandler != nil {
+			t.CallbacksHandler.HandleToolError(ctx, err)
+		}
+		return "", err
+	}
+
+	if t.CallbacksHandler != nil {
+		t.CallbacksHandler.HandleToolEnd(ctx, result)
+	}
+	return result, nil
+}
+
+func (t *Tool) call(ctx context.Context, input string) (string, error) {
+	args, err := t.arguments(input)
+	if err != nil {
+		return "", err
+	}
+	req, err := t.newRequest(ctx, args)
+	if err != nil {
+		return "", err
+	}
+	return t.client.do(ctx, req)
+}
+
+// arguments decodes and checks the arguments of a call.
+func (t *Tool) arguments(input string) (map[string]any, error) {
+	var args map[string]any
+	if err := json.Unmarshal([]byte(input), &args); err != nil {
+		if len(t.params) != 1 || t.body != nil {
+			return nil, fmt.Errorf("%w: %s takes a JSON object: %w", tools.ErrInvalidArguments, t.name, err)
+		}
+		args = map[string]any{t.params[0].name: strings.TrimSpace(input)}
+	}
+
+	if err := t.Parameters().Validate(args); err != nil {
+		return nil, fmt.Errorf("%w: %w", tools.ErrInvalidArguments, err)
+	}
+	return args, nil
+}
+
+func (t *Tool) newRequest(ctx context.Context, args map[string]any) (*http.Request, error) {
+	path := t.path
+	query := url.Values{}
+	header := http.Header{}
+	for _, p := range t.params {
+		value, ok := args[p.name]
+		if !ok {
+			continue
+		}
+		switch p.in {
+		case "path":
+			path = strings.ReplaceAll(path, "{"+p.name+"}", url.PathEscape(formatValue(value)))
+		case "query":
+			if values, ok := value.([]any); ok {
+				for _, v := range values {
+					query.Add(p.name, formatValue(v))
+				}
+			} else {
+				query.Set(p.name, formatValue(value))
+			}
+		case "header":
+			header.Set(p.name, formatValue(value))
+		}
+	}
+
+	var body io.Reader
+	if value, ok := args[_bodyArgument]; ok && t.body != nil {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+		header.Set("Content-Type", "application/json")
+	}
+
+	u := t.client.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, t.method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "application/json, text/plain;q=0.9, */*;q=0.8")
+	return req, nil
+}
+
+// formatValue formats a JSON value for a path, a query or a header.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case nil:
+		return ""
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+// do edits and sends a request, and returns the body of the response.
+func (c *client) do(ctx context.Context, req *http.Request) (string, error) {
+	for _, edit := range c.requestEditors {
+		if err := edit(ctx, req); err != nil {
+			return "", err
+		}
+	}
+	if err := c.checkHost(req.URL); err != nil {
+		return "", err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, _maxReadLength))
+	if err != nil {
+		return "", err
+	}
+	body := c.truncate(data)
+	if resp.StatusCode >= http.StatusBadRequest {
+		return "", fmt.Errorf("%w: %s %s returned %s: %s", ErrRequestFailed, req.Method, req.URL.Path, resp.Status, body)
+	}
+	return body, nil
+}
+
+func (c *client) checkHost(u *url.URL) error {
+	if slices.Contains(c.allowedHosts, u.Host) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrHostNotAllowed, u.Host)
+}
+
+// truncate returns the body of a response, truncated to the maximum length.
+func (c *client) truncate(data []byte) string {
+	if c.maxResponseLength <= 0 || len(data) <= c.maxResponseLength {
+		return string(data)
+	}
+	// Do not cut a UTF-8 character.
+	n := c.maxResponseLength
+	for n > 0 && data[n]&0xC0 == 0x80 {
+		n--
+	}
+	return fmt.Sprintf("%s\n[response truncated: %d of at least %d bytes shown]", data[:n], n, len(data))
+}
@@ -0,0 +1,8 @@
+// Package retrievertool contains an implementation of the tool interface
+// searching documents with a retriever, e.g. a vector store turned into one
+// with vectorstores.ToRetriever, so that an agent can look up the documents
+// it needs. The documents are formatted with a template, optionally showing
+// their metadata, and the output of the tool can be truncated. A Tool takes
+// the query as its input, and a StructuredTool takes a JSON object with the
+// query.
+package retrievertool
@@ -0,0 +1,87 @@
+package retrievertool
+
+import (
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/prompts"
+)
+
+const (
+	_defaultDocumentTemplate = "{{.metadata}}{{.page_content}}"
+	_defaultSeparator        = "\n\n"
+)
+
+type options struct {
+	documentPrompt   prompts.PromptTemplate
+	metadataKeys     []string
+	separator        string
+	maxDocuments     int
+	maxLength        int
+	callbacksHandler callbacks.Handler
+}
+
+func defaultOptions() options {
+	return options{
+		documentPrompt: DefaultDocumentPrompt(),
+		separator:      _defaultSeparator,
+	}
+}
+
+// Option is a function for configuring a Tool.
+type Option func(*options)
+
+// DefaultDocumentPrompt returns the default prompt formatting a document: its
+// metadata, then its content.
+func DefaultDocumentPrompt() prompts.PromptTemplate {
+	return prompts.NewPromptTemplate(_defaultDocumentTemplate, []string{"metadata", "page_content"})
+}
+
+// WithDocumentPrompt sets the prompt formatting each document. Its variables
+// can be the "page_content" of the document, its "metadata" as formatted with
+// the keys set by WithMetadataKeys, its 1-based "index" in the results, and
+// any key of its metadata.
+func WithDocumentPrompt(prompt prompts.PromptTemplate) Option {
+	return func(opts *options) {
+		opts.documentPrompt = prompt
+	}
+}
+
+// WithMetadataKeys sets the keys of the metadata shown before the content of
+// the documents, as "key: value" lines, e.g. their source. By default, no
+// metadata is shown.
+func WithMetadataKeys(keys ...string) Option {
+	return func(opts *options) {
+		opts.metadataKeys = keys
+	}
+}
+
+// WithSeparator sets the separator of the formatted documents. Defaults to an
+// empty line.
+func WithSeparator(separator string) Option {
+	return func(opts *options) {
+		opts.separator = separator
+	}
+}
+
+// WithMaxDocuments sets the maximum number of documents returned, the first
+// ones given by the retriever. By default, all the documents are returned.
+func WithMaxDocuments(maxDocuments int) Option {
+	return func(opts *options) {
+		opts.maxDocuments = maxDocuments
+	}
+}
+
+// WithMaxLength sets the maximum length in bytes of the output of the tool,
+// cut with a note telling the agent the results are truncated. By default, the
+// output is not truncated.
+func WithMaxLength(maxLength int) Option {
+	return func(opts *options) {
+		opts.maxLength = maxLength
+	}
+}
+
+// WithCallbacksHandler sets the callbacks handler of the tool.
+func WithCallbacksHandler(handler callbacks.Handler) Option {
+	return func(opts *options) {
+		opts.callbacksHandler = handler
+	}
+}
@@ -0,0 +1,176 @@
+package retrievertool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// ErrMissingMetadata is returned when a document is missing metadata used by
+// the document prompt.
+var ErrMissingMetadata = errors.New("document is missing metadata used in the document prompt")
+
+const (
+	// _noDocuments is the output of the tool when no documents are found.
+	_noDocuments = "No relevant documents found."
+	// _truncated is the note ending a truncated output.
+	_truncated = "\n[The results are truncated.]"
+)
+
+// Tool is a tool searching documents with a retriever, and returning them
+// formatted as text.
+type Tool struct {
+	CallbacksHandler callbacks.Handler
+
+	name           string
+	description    string
+	retriever      schema.Retriever
+	documentPrompt prompts.PromptTemplate
+	metadataKeys   []string
+	separator      string
+	maxDocuments   int
+	maxLength      int
+	// parseQuery returns the query from the input of the tool, if the input
+	// is not the query itself.
+	parseQuery func(input string) (string, error)
+}
+
+var _ tools.Tool = &Tool{}
+
+// New creates a new tool searching documents with a retriever. The name and
+// description are the ones given to the agents using the tool, and should say
+// what documents the tool searches, e.g. "Searches the documentation of the
+// project. The input is a search query.".
+func New(name, description string, retriever schema.Retriever, opts ...Option) *Tool {
+	options := defaultOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return &Tool{
+		CallbacksHandler: options.callbacksHandler,
+		name:             name,
+		description:      description,
+		retriever:        retriever,
+		documentPrompt:   options.documentPrompt,
+		metadataKeys:     options.metadataKeys,
+		separator:        options.separator,
+		maxDocuments:     options.maxDocuments,
+		maxLength:        options.maxLength,
+	}
+}
+
+// Name returns the name of the tool.
+func (t *Tool) Name() string {
+	return t.name
+}
+
+// Description returns the description of the tool.
+func (t *Tool) Description() string {
+	return t.description
+}
+
+// Retriever returns the retriever of the tool.
+func (t *Tool) Retriever() schema.Retriever { //nolint:ireturn
+	return t.retriever
+}
+
+// Call searches the documents relevant to the input and returns them
+// formatted.
+func (t *Tool) Call(ctx context.Context, input string) (string, error) {
+	if t.CallbacksHandler != nil {
+		t.CallbacksHandler.HandleToolStart(ctx, input)
+	}
+
+	result, err := t.call(ctx, input)
+	if err != nil {
+		if t.CallbacksHandler != nil {
+			t.CallbacksHandler.HandleToolError(ctx, err)
+		}
+		return "", err
+	}
+
+	if t.CallbacksHandler != nil {
+		t.CallbacksHandler.HandleToolEnd(ctx, result)
+	}
+	return result, nil
+}
+
+func (t *Tool) call(ctx context.Context, input string) (string, error) {
+	query := input
+	if t.parseQuery != nil {
+		var err error
+		if query, err = t.parseQuery(input); err != nil {
+			return "", err
+		}
+	}
+	docs, err := t.retriever.GetRelevantDocuments(ctx, strings.TrimSpace(query))
+	if err != nil {
+		return "", err
+	}
+	if t.maxDocuments > 0 && len(docs) > t.maxDocuments {
+		docs = docs[:t.maxDocuments]
+	}
+	if len(docs) == 0 {
+		return _noDocuments, nil
+	}
+
+	formatted := make([]string, 0, len(docs))
+	for i, doc := range docs {
+		s, err := t.formatDocument(doc, i+1)
+		if err != nil {
+			return "", err
+		}
+		formatted = append(formatted, s)
+	}
+	return t.truncate(strings.Join(formatted, t.separator)), nil
+}
+
+// formatDocument formats a document with the document prompt.
+func (t *Tool) formatDocument(doc schema.Document, index int) (string, error) {
+	values := make(map[string]any, len(doc.Metadata)+3) //nolint:gomnd
+	for key, value := range doc.Metadata {
+		values[key] = value
+	}
+	values["page_content"] = doc.PageContent
+	values["index"] = index
+
+	var metadata strings.Builder
+	for _, key := range t.metadataKeys {
+		if value, ok := doc.Metadata[key]; ok {
+			fmt.Fprintf(&metadata, "%s: %v\n", key, value)
+		}
+	}
+	values["metadata"] = metadata.String()
+
+	for _, variable := range t.documentPrompt.InputVariables {
+		if _, ok := values[variable]; !ok {
+			return "", fmt.Errorf("%w: %s", ErrMissingMetadata, variable)
+		}
+	}
+	return t.documentPrompt.Format(values)
+}
+
+// truncate cuts an output longer than the maximum length, without splitting a
+// rune, and ends it with a note if the note fits in the maximum length.
+func (t *Tool) truncate(output string) string {
+	if t.maxLength <= 0 || len(output) <= t.maxLength {
+		return output
+	}
+	note := _truncated
+	if len(note) > t.maxLength {
+		note = ""
+	}
+	n := t.maxLength - len(note)
+	for n > 0 && !utf8.RuneStart(output[n]) {
+		n--
+	}
+	return output[:n] + note
+}
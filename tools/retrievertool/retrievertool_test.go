@@ -0,0 +1,93 @@
+package retrievertool_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+	"github.com/tmc/langchaingo/tools/retrievertool"
+)
+
+type testRetriever struct {
+	queries []string
+}
+
+func (r *testRetriever) GetRelevantDocuments(_ context.Context, query string) ([]schema.Document, error) {
+	r.queries = append(r.queries, query)
+	if query == "nothing" {
+		return nil, nil
+	}
+	return []schema.Document{
+		{PageContent: "Gophers live in burrows.", Metadata: map[string]any{"source": "gophers.md", "page": 2}},
+		{PageContent: "Go was announced in 2009.", Metadata: map[string]any{"source": "history.md"}},
+		{PageContent: "Élan.", Metadata: map[string]any{}},
+	}, nil
+}
+
+func TestTool(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	retriever := &testRetriever{}
+	tool := retrievertool.New("docs", "Searches the docs.", retriever, retrievertool.WithMaxDocuments(2))
+	require.Equal(t, "docs", tool.Name())
+	require.Equal(t, "Searches the docs.", tool.Description())
+
+	result, err := tool.Call(ctx, " gophers \n")
+	require.NoError(t, err)
+	require.Equal(t, "Gophers live in burrows.\n\nGo was announced in 2009.", result)
+	require.Equal(t, []string{"gophers"}, retriever.queries)
+
+	result, err = tool.Call(ctx, "nothing")
+	require.NoError(t, err)
+	require.Equal(t, "No relevant documents found.", result)
+
+	tool = retrievertool.New("docs", "Searches the docs.", retriever,
+		retrievertool.WithMetadataKeys("source", "page"),
+		retrievertool.WithSeparator("\n---\n"))
+	result, err = tool.Call(ctx, "gophers")
+	require.NoError(t, err)
+	require.Equal(t, "source: gophers.md\npage: 2\nGophers live in burrows.\n---\n"+
+		"source: history.md\nGo was announced in 2009.\n---\nÉlan.", result)
+
+	tool = retrievertool.New("docs", "Searches the docs.", retriever,
+		retrievertool.WithDocumentPrompt(prompts.NewPromptTemplate(
+			"[{{.index}}] {{.page_content}}", []string{"index", "page_content"})),
+		retrievertool.WithMaxLength(70))
+	result, err = tool.Call(ctx, "gophers")
+	require.NoError(t, err)
+	require.Equal(t, "[1] Gophers live in burrows.\n\n[2] Go was \n[The results are truncated.]", result)
+	require.LessOrEqual(t, len(result), 70)
+
+	// A maximum length shorter than the note only cuts the output.
+	tool = retrievertool.New("docs", "Searches the docs.", retriever, retrievertool.WithMaxLength(10))
+	result, err = tool.Call(ctx, "gophers")
+	require.NoError(t, err)
+	require.Equal(t, "Gophers li", result)
+
+	tool = retrievertool.New("docs", "Searches the docs.", retriever,
+		retrievertool.WithDocumentPrompt(prompts.NewPromptTemplate(
+			"{{.source}}: {{.page_content}}", []string{"source", "page_content"})))
+	_, err = tool.Call(ctx, "gophers")
+	require.ErrorIs(t, err, retrievertool.ErrMissingMetadata)
+}
+
+func TestStructuredTool(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	retriever := &testRetriever{}
+	tool := retrievertool.NewStructured("docs", "Searches the docs.", retriever, retrievertool.WithMaxDocuments(1))
+	require.Equal(t, []string{"query"}, tool.Parameters().Required)
+
+	result, err := tool.Call(ctx, `{"query": "gophers"}`)
+	require.NoError(t, err)
+	require.Equal(t, "Gophers live in burrows.", result)
+	require.Equal(t, []string{"gophers"}, retriever.queries)
+
+	_, err = tool.Call(ctx, "gophers")
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+}
@@ -0,0 +1,49 @@
+package retrievertool
+
+import (
+	"fmt"
+
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+// StructuredTool is a tool searching documents with a retriever, taking the
+// query as the "query" property of a JSON object, for agents using the tool
+// calling API of a model. Its Call searches the documents relevant to the
+// query and returns them formatted.
+type StructuredTool struct {
+	*Tool
+}
+
+var _ tools.StructuredTool = &StructuredTool{}
+
+// NewStructured creates a new tool searching documents with a retriever, with
+// the query given as a JSON object.
+func NewStructured(name, description string, retriever schema.Retriever, opts ...Option) *StructuredTool {
+	t := &StructuredTool{Tool: New(name, description, retriever, opts...)}
+	t.Tool.parseQuery = t.parseQuery
+	return t
+}
+
+// Parameters returns the JSON schema of the arguments: the query.
+func (t *StructuredTool) Parameters() jsonschema.Definition {
+	return jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			"query": {Type: jsonschema.String, Description: "The query to search the documents for."},
+		},
+		Required: []string{"query"},
+	}
+}
+
+// parseQuery returns the query of the JSON object of the input.
+func (t *StructuredTool) parseQuery(input string) (string, error) {
+	var args struct {
+		Query string `json:"query"`
+	}
+	if err := jsonschema.VerifySchemaAndUnmarshal(t.Parameters(), []byte(input), &args); err != nil {
+		return "", fmt.Errorf("%w: %w", tools.ErrInvalidArguments, err)
+	}
+	return args.Query, nil
+}
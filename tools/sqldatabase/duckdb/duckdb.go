@@ -0,0 +1,233 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/marcboeker/go-duckdb"
+	"github.com/tmc/langchaingo/tools/sqldatabase"
+)
+
+const EngineName = "duckdb"
+
+// ErrUnsupportedFile is returned when attaching a file which is neither a
+// Parquet nor a CSV file.
+var ErrUnsupportedFile = errors.New("unsupported file")
+
+//nolint:gochecknoinits
+func init() {
+	sqldatabase.RegisterEngine(EngineName, NewDuckDB)
+}
+
+var (
+	_ sqldatabase.Engine          = &DuckDB{}
+	_ sqldatabase.RowsEngine      = &DuckDB{}
+	_ sqldatabase.ValueNormalizer = &DuckDB{}
+)
+
+// DuckDB is a DuckDB engine, running in-process.
+type DuckDB struct {
+	db *sql.DB
+}
+
+// NewDuckDB creates a new DuckDB engine.
+// The dsn is the path of the database file, or empty for an in-memory database
+// (e.g. analytics.duckdb?access_mode=read_only).
+func NewDuckDB(dsn string) (sqldatabase.Engine, error) { //nolint:ireturn
+	return New(context.Background(), dsn)
+}
+
+// New creates a new DuckDB engine, with the files of the options loaded into
+// tables. The dsn is the path of the database file, or empty for an in-memory
+// database.
+//
+// Once the files are loaded, the external access of the database is disabled
+// for as long as it runs: the queries can't read or write files, e.g. with
+// read_csv or COPY, or attach other databases.
+func New(ctx context.Context, dsn string, opts ...Option) (*DuckDB, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	db, err := sql.Open(EngineName, dsn)
+	if err != nil {
+		return nil, err
+	}
+	d := &DuckDB{db: db}
+	for _, file := range o.files {
+		if err := d.attachFile(ctx, file.name, file.path); err != nil {
+			db.Close()
+			return nil, err
+		}
+	}
+	if _, err := db.ExecContext(ctx, "SET enable_external_access = false"); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return d, nil
+}
+
+// attachFile creates a table named name with the rows of a local Parquet or CSV
+// file, chosen by the extension of the path: .parquet, .csv or .tsv, possibly
+// compressed. The table replaces any table of the same name.
+func (d *DuckDB) attachFile(ctx context.Context, name, path string) error {
+	ext := strings.ToLower(path)
+	for _, compression := range []string{".gz", ".zst"} {
+		ext = strings.TrimSuffix(ext, compression)
+	}
+	var reader string
+	switch filepath.Ext(ext) {
+	case ".parquet":
+		reader = "read_parquet"
+	case ".csv", ".tsv":
+		reader = "read_csv_auto"
+	default:
+		return fmt.Errorf("%w: %s, only Parquet and CSV files are supported", ErrUnsupportedFile, path)
+	}
+
+	_, err := d.db.ExecContext(ctx, fmt.Sprintf("CREATE OR REPLACE TABLE %s AS SELECT * FROM %s(%s)",
+		quote(name, '"'), reader, quote(path, '\'')))
+	return err
+}
+
+func (d *DuckDB) Dialect() string {
+	return EngineName
+}
+
+func (d *DuckDB) Query(ctx context.Context, query string, args ...any) ([]string, [][]string, error) {
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	cols, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+	results := make([][]string, 0)
+	for rows.Next() {
+		// DuckDB values are typed, and may be lists or structs.
+		values := make([]any, len(cols))
+		ptrs := make([]any, len(cols))
+		for i := range values {
+			ptrs[i] = &values[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, nil, err
+		}
+		row := make([]string, len(cols))
+		for i, v := range values {
+			row[i] = formatValue(d.NormalizeValue(v))
+		}
+		results = append(results, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	return cols, results, nil
+}
+
+func (d *DuckDB) QueryRows(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return d.db.QueryContext(ctx, query, args...)
+}
+
+// TableNames returns the names of the tables and views of the main schema.
+func (d *DuckDB) TableNames(ctx context.Context) ([]string, error) {
+	_, result, err := d.Query(ctx,
+		"SELECT table_name FROM information_schema.tables WHERE table_schema = 'main' ORDER BY table_name")
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]string, 0, len(result))
+	for _, row := range result {
+		ret = append(ret, row[0])
+	}
+	return ret, nil
+}
+
+// TableInfo returns a CREATE TABLE statement with the columns of the table or
+// view.
+func (d *DuckDB) TableInfo(ctx context.Context, table string) (string, error) {
+	_, result, err := d.Query(ctx, `SELECT column_name, data_type FROM information_schema.columns
+		WHERE table_schema = 'main' AND table_name = ? ORDER BY ordinal_position`, table)
+	if err != nil {
+		return "", err
+	}
+	if len(result) == 0 {
+		return "", sqldatabase.ErrTableNotFound
+	}
+	columns := make([]string, 0, len(result))
+	for _, row := range result {
+		if len(row) < 2 { //nolint:gomnd
+			return "", sqldatabase.ErrInvalidResult
+		}
+		columns = append(columns, "  "+quote(row[0], '"')+" "+row[1])
+	}
+	return fmt.Sprintf("CREATE TABLE %s (\n%s\n)", quote(table, '"'), strings.Join(columns, ",\n")), nil
+}
+
+// NormalizeValue converts the decimals to strings, not to lose their
+// precision, and the huge integers to int64 if they fit or to strings.
+func (d *DuckDB) NormalizeValue(v any) any {
+	switch v := v.(type) {
+	case duckdb.Decimal:
+		return formatDecimal(v)
+	case *big.Int:
+		if v.IsInt64() {
+			return v.Int64()
+		}
+		return v.String()
+	default:
+		return v
+	}
+}
+
+func (d *DuckDB) Close() error {
+	return d.db.Close()
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// quote quotes an identifier or a string.
+func quote(s string, q rune) string {
+	return string(q) + strings.ReplaceAll(s, string(q), string(q)+string(q)) + string(q)
+}
+
+// formatDecimal formats a decimal without its trailing zeros, e.g. 29.5 for
+// the DECIMAL(38,2) 29.50.
+func formatDecimal(d duckdb.Decimal) string {
+	if d.Value == nil {
+		return ""
+	}
+	digits := new(big.Int).Abs(d.Value).String()
+	scale := int(d.Scale)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	s := digits[:len(digits)-scale]
+	if fraction := strings.TrimRight(digits[len(digits)-scale:], "0"); fraction != "" {
+		s += "." + fraction
+	}
+	if d.Value.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
@@ -0,0 +1,99 @@
+package duckdb_test
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/tools/sqldatabase"
+	"github.com/tmc/langchaingo/tools/sqldatabase/duckdb"
+)
+
+func Test(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	// Some example data: a CSV file and a Parquet file written by DuckDB.
+	customersPath := filepath.Join(dir, "customers.csv")
+	require.NoError(t, os.WriteFile(customersPath, []byte("id,name\n1,Ada\n2,Linus\n"), 0o600))
+	ordersPath := filepath.Join(dir, "orders.parquet")
+	tmpDB, err := sql.Open("duckdb", "")
+	require.NoError(t, err)
+	_, err = tmpDB.Exec(`COPY (SELECT * FROM (VALUES (1, 1, 9.5), (2, 1, 20.0), (3, 2, 5.0)) AS t(id, customer_id, total))
+		TO '` + ordersPath + `' (FORMAT PARQUET)`)
+	require.NoError(t, err)
+	require.NoError(t, tmpDB.Close())
+
+	engine, err := duckdb.New(ctx, "",
+		duckdb.WithFile("customers", customersPath),
+		duckdb.WithFile("orders", ordersPath))
+	require.NoError(t, err)
+	db, err := sqldatabase.NewSQLDatabase(engine, nil)
+	require.NoError(t, err)
+	defer db.Close()
+
+	require.Equal(t, "duckdb", db.Dialect())
+	require.Equal(t, []string{"customers", "orders"}, db.TableNames())
+
+	info, err := db.TableInfo(ctx, []string{"customers"})
+	require.NoError(t, err)
+	require.Contains(t, info, "CREATE TABLE \"customers\" (\n  \"id\" BIGINT,\n  \"name\" VARCHAR\n)")
+	require.Contains(t, info, "1\tAda")
+
+	out, err := db.Query(ctx, `SELECT c.name, sum(o.total) AS total FROM customers c
+		JOIN orders o ON o.customer_id = c.id GROUP BY c.name ORDER BY total DESC`)
+	require.NoError(t, err)
+	require.Equal(t, "name\ttotal\nAda\t29.5\nLinus\t5\n", out)
+
+	result, err := db.QueryResult(ctx, "SELECT id, name FROM customers ORDER BY id", sqldatabase.WithPageSize(1))
+	require.NoError(t, err)
+	require.Equal(t, []sqldatabase.Column{{Name: "id", Type: "BIGINT"}, {Name: "name", Type: "VARCHAR"}}, result.Columns)
+	require.Equal(t, [][]any{{int64(1), "Ada"}}, result.Rows)
+	require.True(t, result.HasMore)
+
+	result, err = db.QueryResult(ctx, "SELECT sum(total) AS total FROM orders")
+	require.NoError(t, err)
+	require.Equal(t, [][]any{{"34.5"}}, result.Rows)
+
+	// The files are only read when they are attached, the external access is
+	// disabled afterwards.
+	_, err = db.Query(ctx, "SELECT * FROM read_csv_auto('"+customersPath+"')")
+	require.ErrorIs(t, err, sqldatabase.ErrQueryNotAllowed)
+	_, err = db.Query(ctx, "SELECT * FROM '"+customersPath+"'")
+	require.ErrorIs(t, err, sqldatabase.ErrQueryNotAllowed)
+	_, err = db.Query(ctx, `SELECT * FROM "`+customersPath+`"`)
+	require.ErrorIs(t, err, sqldatabase.ErrQueryNotAllowed)
+	_, err = db.Query(ctx, `SELECT * FROM "read_csv_auto"('`+customersPath+`')`)
+	require.ErrorIs(t, err, sqldatabase.ErrQueryNotAllowed)
+	out, err = db.Query(ctx, `SELECT "name" FROM "customers" WHERE id = 2`)
+	require.NoError(t, err)
+	require.Equal(t, "name\nLinus\n", out)
+
+	_, err = db.Query(ctx, "SELECT * FROM users, '"+customersPath+"'")
+	require.ErrorIs(t, err, sqldatabase.ErrQueryNotAllowed)
+	_, _, err = engine.Query(ctx, "SELECT * FROM '"+customersPath+"'")
+	require.ErrorContains(t, err, "disabled through configuration")
+
+	_, err = duckdb.New(ctx, "", duckdb.WithFile("notes", "notes.txt"))
+	require.ErrorIs(t, err, duckdb.ErrUnsupportedFile)
+
+	restricted, err := sqldatabase.NewSQLDatabase(engine, nil, sqldatabase.WithAllowedColumns("customers", "id"))
+	require.NoError(t, err)
+	for _, query := range []string{
+		"SELECT c FROM customers c",
+		"SELECT COLUMNS(*) FROM customers",
+		"SELECT COLUMNS('na.*') FROM customers",
+		"SELECT struct_pack(*) FROM customers",
+	} {
+		_, err = restricted.Query(ctx, query)
+		require.ErrorIs(t, err, sqldatabase.ErrQueryNotAllowed, query)
+	}
+	out, err = restricted.Query(ctx, "SELECT count(*) AS n FROM customers")
+	require.NoError(t, err)
+	require.Equal(t, "n\n2\n", out)
+}
@@ -0,0 +1,21 @@
+package duckdb
+
+type file struct {
+	name, path string
+}
+
+type options struct {
+	files []file
+}
+
+// Option is a function for configuring a DuckDB engine.
+type Option func(*options)
+
+// WithFile loads a local Parquet or CSV file into a table named name. The
+// format is chosen by the extension of the path: .parquet, .csv or .tsv,
+// possibly compressed.
+func WithFile(name, path string) Option {
+	return func(opts *options) {
+		opts.files = append(opts.files, file{name: name, path: path})
+	}
+}
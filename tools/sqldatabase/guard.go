@@ -0,0 +1,699 @@
+package sqldatabase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//nolint:gochecknoglobals
+var (
+	// _writeKeywords are the keywords of the statements modifying the
+	// database, rejected anywhere in read-only queries, e.g. in the common
+	// table expressions of PostgreSQL or in SELECT INTO.
+	_writeKeywords = []string{
+		"insert", "update", "delete", "merge", "upsert", "replace", "drop", "alter", "create",
+		"truncate", "into", "grant", "revoke", "attach", "detach", "pragma", "vacuum",
+	}
+
+	// _deniedFunctions are the functions reading files, sleeping, modifying
+	// the server, running queries given as strings or selecting the columns
+	// matching a pattern, per dialect.
+	_deniedFunctions = map[string][]string{
+		"sqlite3": {"load_extension", "readfile", "writefile", "edit", "fts3_tokenizer"},
+		"mysql":   {"sleep", "benchmark", "load_file", "get_lock", "release_lock", "release_all_locks"},
+		"pgx": {
+			"pg_sleep", "pg_sleep_for", "pg_sleep_until", "pg_read_file", "pg_read_binary_file",
+			"pg_ls_dir", "pg_ls_logdir", "pg_ls_waldir", "pg_ls_tmpdir", "pg_ls_archive_statusdir",
+			"pg_stat_file", "lo_import", "lo_export", "dblink", "dblink_exec", "dblink_connect",
+			"dblink_connect_u", "dblink_send_query", "dblink_open",
+			"pg_terminate_backend", "pg_cancel_backend", "pg_reload_conf", "pg_rotate_logfile",
+			"set_config", "pg_advisory_lock", "query_to_xml", "query_to_xml_and_xmlschema",
+			"query_to_xmlschema", "cursor_to_xml", "table_to_xml", "schema_to_xml", "database_to_xml",
+		},
+		"duckdb": {
+			"read_csv", "read_csv_auto", "read_parquet", "parquet_scan", "read_json", "read_json_auto",
+			"read_json_objects", "read_json_objects_auto", "read_ndjson", "read_ndjson_auto",
+			"read_ndjson_objects", "read_text", "read_blob", "glob", "sniff_csv", "parquet_metadata",
+			"parquet_schema", "parquet_file_metadata", "parquet_kv_metadata", "sqlite_scan",
+			"postgres_scan", "mysql_scan", "iceberg_scan", "delta_scan", "query", "query_table", "columns",
+		},
+	}
+
+	// _aliasStopWords are the keywords which may follow a table and are not
+	// its alias.
+	_aliasStopWords = []string{
+		"where", "join", "inner", "left", "right", "full", "outer", "cross", "natural", "on", "using",
+		"group", "order", "having", "limit", "offset", "union", "intersect", "except", "window", "fetch",
+		"for", "set", "values", "select", "returning", "straight_join", "tablesample", "indexed", "not",
+		"use", "force", "ignore", "default", "partition", "lock", "into", "from", "qualify",
+	}
+
+	// _fromEndWords are the keywords ending a list of tables.
+	_fromEndWords = []string{
+		"where", "group", "having", "order", "limit", "offset", "union", "intersect", "except", "window",
+		"qualify", "fetch", "returning", "set", "select", "values", "for", "into", "duplicate", "lock",
+	}
+
+	// _sampleWords are the keywords of the sample of a table.
+	_sampleWords = []string{"bernoulli", "system", "reservoir", "percent", "rows", "repeatable"}
+
+	// _fromFunctions are the functions with a FROM in their arguments.
+	_fromFunctions = []string{"extract", "substring", "substr", "trim", "overlay", "position"}
+
+	// _defaultSchemas are the schemas of the tables of unqualified names.
+	_defaultSchemas = map[string]string{"sqlite3": "main", "pgx": "public", "duckdb": "main"}
+)
+
+// queryGuard checks the queries run on a database and rewrites them to limit
+// their results.
+type queryGuard struct {
+	dialect string
+	options
+	// columns are the columns of the tables with allowed columns.
+	columns map[string][]string
+	// tables are the tables of the database.
+	tables []string
+}
+
+func newQueryGuard(ctx context.Context, engine Engine, tables []string, o options) (*queryGuard, error) {
+	g := &queryGuard{dialect: engine.Dialect(), options: o, columns: make(map[string][]string), tables: tables}
+	for table := range o.allowedColumns {
+		cols, _, err := engine.Query(ctx, "SELECT * FROM "+quoteIdentifier(g.dialect, table)+" LIMIT 0")
+		if err != nil {
+			return nil, fmt.Errorf("columns of %s: %w", table, err)
+		}
+		g.columns[table] = cols
+	}
+	return g, nil
+}
+
+// tableAllowed reports whether the table, possibly qualified by a schema, is
+// allowed.
+func (g *queryGuard) tableAllowed(schema, table string) bool {
+	if len(g.allowedTables) == 0 {
+		return true
+	}
+	if schema != "" && containsFold(g.allowedTables, schema+"."+table) {
+		return true
+	}
+	return containsFold(g.allowedTables, table) &&
+		(schema == "" || strings.EqualFold(schema, _defaultSchemas[g.dialect]))
+}
+
+// allowedColumnsOf returns the allowed columns of a table, and whether its
+// columns are restricted.
+func (g *queryGuard) allowedColumnsOf(table string) ([]string, bool) {
+	for t, cols := range g.allowedColumns {
+		if strings.EqualFold(t, table) {
+			return cols, true
+		}
+	}
+	return nil, false
+}
+
+// deniedColumnsOf returns the columns of a table which are not allowed.
+func (g *queryGuard) deniedColumnsOf(table string) []string {
+	var denied []string
+	for t, cols := range g.columns {
+		if !strings.EqualFold(t, table) {
+			continue
+		}
+		allowed, _ := g.allowedColumnsOf(table)
+		for _, col := range cols {
+			if !containsFold(allowed, col) {
+				denied = append(denied, col)
+			}
+		}
+	}
+	return denied
+}
+
+// check returns the query to run, with its rows limited to maxRows if it is
+// positive, or an error explaining why the query is not allowed.
+func (g *queryGuard) check(query string, maxRows int) (string, error) {
+	tokens, err := tokenize(g.dialect, query)
+	if err != nil {
+		return "", err
+	}
+
+	var statements [][]token
+	var current []token
+	for _, t := range append(tokens, token{kind: tokenSymbol, text: ";"}) {
+		if !t.isSymbol(";") {
+			current = append(current, t)
+			continue
+		}
+		if len(current) > 0 {
+			statements = append(statements, current)
+		}
+		current = nil
+	}
+	if len(statements) == 0 {
+		return "", fmt.Errorf("%w: the query is empty", ErrQueryNotAllowed)
+	}
+	if len(statements) > 1 && !g.allowMultipleStatements {
+		return "", fmt.Errorf("%w: only one statement can be run at a time, the query has %d statements",
+			ErrQueryNotAllowed, len(statements))
+	}
+
+	checked := make([]string, 0, len(statements))
+	for _, statement := range statements {
+		s, err := g.checkStatement(query, statement, maxRows)
+		if err != nil {
+			return "", err
+		}
+		checked = append(checked, s)
+	}
+	return strings.Join(checked, ";\n"), nil
+}
+
+func (g *queryGuard) checkStatement(query string, tokens []token, maxRows int) (string, error) {
+	first := tokens[0]
+	for i := 1; first.isSymbol("(") && i < len(tokens); i++ {
+		first = tokens[i]
+	}
+	isSelect := first.isWord("select", "with", "values")
+	for i, t := range tokens {
+		if !t.isIdentifier() {
+			continue
+		}
+		isCall := i+1 < len(tokens) && tokens[i+1].isSymbol("(")
+		if isCall && containsFold(_deniedFunctions[g.dialect], t.text) {
+			return "", fmt.Errorf("%w: the function %s is not allowed", ErrQueryNotAllowed, t.text)
+		}
+		if t.kind != tokenWord {
+			continue
+		}
+		if slices.Contains(_writeKeywords, t.text) && !(t.text == "replace" && isCall) {
+			isSelect = false
+		}
+	}
+	if !isSelect && !g.allowWrites {
+		return "", fmt.Errorf("%w: only SELECT queries are allowed, the query is a %s statement",
+			ErrQueryNotAllowed, strings.ToUpper(query[first.start:first.end]))
+	}
+
+	refs, ctes := tableRefs(tokens)
+	for _, ref := range refs {
+		// A string is read as a table by SQLite, or as a file by DuckDB.
+		if tokens[ref.index].kind == tokenString {
+			return "", fmt.Errorf("%w: tables are identifiers, not strings: %s", ErrQueryNotAllowed, ref.name)
+		}
+		if ref.schema == "" && containsFold(ctes, ref.name) {
+			continue
+		}
+		// DuckDB reads a file named by a quoted identifier which is not a
+		// table, e.g. FROM "data.csv".
+		if g.dialect == "duckdb" && tokens[ref.index].kind == tokenQuoted && !containsFold(g.tables, ref.name) {
+			return "", fmt.Errorf("%w: %s is not a table of the database", ErrQueryNotAllowed, ref.name)
+		}
+		if !g.tableAllowed(ref.schema, ref.name) {
+			return "", fmt.Errorf("%w: the table %s is not allowed, the allowed tables are: %s",
+				ErrQueryNotAllowed, ref.name, strings.Join(g.allowedTables, ", "))
+		}
+	}
+	if err := g.checkColumns(tokens, refs); err != nil {
+		return "", err
+	}
+
+	statement := query[tokens[0].start:tokens[len(tokens)-1].end]
+	if isSelect && maxRows > 0 {
+		statement = limit(statement, tokens, maxRows)
+	}
+	return statement, nil
+}
+
+// checkColumns checks the columns used with the tables with restricted
+// columns.
+func (g *queryGuard) checkColumns(tokens []token, refs []tableRef) error { //nolint:cyclop,funlen
+	restricted := make(map[string][]string) // Names and aliases of the tables.
+	var tables []string
+	isRef := make(map[int]bool)
+	for _, ref := range refs {
+		isRef[ref.index] = true
+		for _, a := range ref.aliases {
+			isRef[a.index] = true
+		}
+		allowed, ok := g.allowedColumnsOf(ref.name)
+		switch {
+		case !ok:
+			continue
+		case ref.whole:
+			return g.columnsError(ref.name, "*")
+		case ref.columnAliases:
+			return fmt.Errorf("%w: the columns of the table %s cannot be renamed, the allowed columns are: %s",
+				ErrQueryNotAllowed, ref.name, strings.Join(allowed, ", "))
+		}
+		restricted[strings.ToLower(ref.name)] = append(restricted[strings.ToLower(ref.name)], ref.name)
+		for _, a := range ref.aliases {
+			restricted[strings.ToLower(a.name)] = append(restricted[strings.ToLower(a.name)], ref.name)
+		}
+		tables = append(tables, ref.name)
+	}
+	if len(tables) == 0 {
+		return nil
+	}
+
+	for i, t := range tokens {
+		var qualifier string
+		if i > 1 && tokens[i-1].isSymbol(".") {
+			qualifier = strings.ToLower(tokens[i-2].text)
+		}
+
+		if t.isSymbol("*") {
+			var prev token
+			if i > 0 {
+				prev = tokens[i-1]
+			}
+			switch {
+			case qualifier != "" && len(restricted[qualifier]) > 0:
+				return g.columnsError(restricted[qualifier][0], "*")
+			case qualifier == "" && (prev.isWord("select", "distinct", "all") || prev.isSymbol(",")):
+				return g.columnsError(tables[0], "*")
+			// A * argument is all the columns, e.g. in struct_pack(*), but in
+			// count(*).
+			case qualifier == "" && prev.isSymbol("(") && !(i > 1 && tokens[i-2].isWord("count")):
+				return g.columnsError(tables[0], "*")
+			}
+			continue
+		}
+
+		if !t.isIdentifier() || isRef[i] ||
+			(i+1 < len(tokens) && (tokens[i+1].isSymbol(".") || tokens[i+1].isSymbol("("))) ||
+			(i > 0 && tokens[i-1].isSymbol("::")) {
+			continue
+		}
+		if qualifier != "" {
+			for _, table := range restricted[qualifier] {
+				if containsFold(g.deniedColumnsOf(table), t.text) {
+					return g.columnsError(table, t.text)
+				}
+			}
+			continue
+		}
+		// A table used as a value is its whole row, e.g. in to_json(u).
+		if names := restricted[strings.ToLower(t.text)]; len(names) > 0 && !g.isAllowedColumn(tables, t.text) {
+			allowed, _ := g.allowedColumnsOf(names[0])
+			return fmt.Errorf("%w: the rows of the table %s cannot be used as values, the allowed columns are: %s",
+				ErrQueryNotAllowed, names[0], strings.Join(allowed, ", "))
+		}
+		for _, table := range tables {
+			if containsFold(g.deniedColumnsOf(table), t.text) {
+				return g.columnsError(table, t.text)
+			}
+		}
+	}
+	return nil
+}
+
+// isAllowedColumn reports whether the name is an allowed column of one of the
+// tables.
+func (g *queryGuard) isAllowedColumn(tables []string, name string) bool {
+	for _, table := range tables {
+		if allowed, _ := g.allowedColumnsOf(table); containsFold(allowed, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *queryGuard) columnsError(table, column string) error {
+	allowed, _ := g.allowedColumnsOf(table)
+	return fmt.Errorf("%w: the column %s of the table %s is not allowed, the allowed columns are: %s",
+		ErrQueryNotAllowed, column, table, strings.Join(allowed, ", "))
+}
+
+// limit returns the statement with its number of rows limited: a LIMIT is
+// added if the statement has none, and lowered if it is greater than the
+// maximum number of rows.
+func limit(statement string, tokens []token, maxRows int) string {
+	offset := tokens[0].start
+	depth := 0
+	limitIndex, offsetIndex := -1, -1
+	for i, t := range tokens {
+		switch {
+		case t.isSymbol("("):
+			depth++
+		case t.isSymbol(")"):
+			depth--
+		case depth == 0 && t.isWord("fetch"):
+			// The rows are limited by FETCH FIRST, the results are truncated.
+			return statement
+		case depth == 0 && t.isWord("limit"):
+			limitIndex = i
+		case depth == 0 && t.isWord("offset") && offsetIndex < 0:
+			offsetIndex = i
+		}
+	}
+
+	rows := strconv.Itoa(maxRows)
+	if limitIndex < 0 {
+		if offsetIndex >= 0 {
+			at := tokens[offsetIndex].start - offset
+			return statement[:at] + "LIMIT " + rows + " " + statement[at:]
+		}
+		return statement + " LIMIT " + rows
+	}
+
+	count := limitIndex + 1
+	// LIMIT offset, count in MySQL and SQLite.
+	if count+2 < len(tokens) && tokens[count+1].isSymbol(",") {
+		count += 2
+	}
+	if count >= len(tokens) {
+		return statement
+	}
+	if n, err := strconv.Atoi(tokens[count].text); (err == nil && n > maxRows) || tokens[count].isWord("all") {
+		return statement[:tokens[count].start-offset] + rows + statement[tokens[count].end-offset:]
+	}
+	return statement
+}
+
+// tableRef is a reference to a table in a statement.
+type tableRef struct {
+	schema, name string
+	// index is the index of the token of the name.
+	index int
+	// aliases are the alias of the table and the aliases of the
+	// parenthesized joins it is in.
+	aliases []tableAlias
+	// columnAliases reports whether its columns are renamed by a list of
+	// column aliases, e.g. AS t(a, b).
+	columnAliases bool
+	// whole reports whether the table is a whole statement, e.g. TABLE t.
+	whole bool
+}
+
+// tableAlias is an alias of a table, and the index of its token.
+type tableAlias struct {
+	name  string
+	index int
+}
+
+// tableRefs returns the tables referenced by a statement, and the names of
+// its common table expressions.
+func tableRefs(tokens []token) ([]tableRef, []string) {
+	r := &tableReader{tokens: tokens, frames: []tableFrame{{}}}
+	r.read()
+	return r.refs, r.ctes
+}
+
+// tableReader reads the tables referenced by a statement.
+type tableReader struct {
+	tokens []token
+	refs   []tableRef
+	ctes   []string
+	// frames are the enclosing parentheses, the last one being the
+	// innermost.
+	frames []tableFrame
+}
+
+// tableFrame is a pair of parentheses, or of brackets, of a statement.
+type tableFrame struct {
+	// call is the function called by the parentheses, if any.
+	call string
+	// from reports whether a list of tables is read, in which a comma is
+	// followed by a table.
+	from bool
+	// item reports whether the parentheses are a table of a list: a
+	// subquery, a table-valued function or a parenthesized join, followed by
+	// an alias.
+	item bool
+	// group reports whether the parentheses are a parenthesized join, whose
+	// alias is an alias of its tables, starting at firstRef.
+	group    bool
+	firstRef int
+}
+
+func (r *tableReader) read() { //nolint:cyclop
+	tokens := r.tokens
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		top := &r.frames[len(r.frames)-1]
+		var prev, next token
+		if i > 0 {
+			prev = tokens[i-1]
+		}
+		if i+1 < len(tokens) {
+			next = tokens[i+1]
+		}
+		switch {
+		case t.isSymbol("("), t.isSymbol("["):
+			call := ""
+			if prev.kind == tokenWord {
+				call = prev.text
+			}
+			r.frames = append(r.frames, tableFrame{call: call})
+		case t.isSymbol(")"), t.isSymbol("]"):
+			if len(r.frames) == 1 {
+				continue
+			}
+			f := r.frames[len(r.frames)-1]
+			r.frames = r.frames[:len(r.frames)-1]
+			if f.item {
+				i = r.itemEnd(i+1, f) - 1
+			}
+		case t.isWord("with") && !next.isWord("ordinality"):
+			r.ctes = append(r.ctes, cteNames(tokens, i+1)...)
+		case t.isWord("from"):
+			if prev.isWord("distinct") || slices.Contains(_fromFunctions, top.call) {
+				continue
+			}
+			top.from = true
+			i = r.item(i+1, true)
+		case t.isWord("join", "straight_join"), t.isSymbol(",") && top.from,
+			t.isWord("using") && next.isIdentifier() && !next.isWord("sample"),
+			t.isWord("update") && !prev.isWord("for", "do", "key") && !next.isWord("set"):
+			top.from = true
+			i = r.item(i+1, true)
+		case t.isWord("into", "table"):
+			top.from = false
+			// TABLE t is SELECT * FROM t.
+			whole := t.text == "table" &&
+				(i == 0 || prev.isSymbol("(") || prev.isWord("union", "intersect", "except", "all", "distinct"))
+			first := len(r.refs)
+			if i = r.item(i+1, false); whole && len(r.refs) > first {
+				r.refs[first].whole = true
+			}
+		case top.from && t.kind == tokenWord && slices.Contains(_fromEndWords, t.text):
+			top.from = false
+		}
+	}
+}
+
+// item reads the table of a list starting at index i, and returns the index
+// of its last token. isFrom reports whether the list follows a FROM, a JOIN
+// or an UPDATE, where functions and subqueries can be used as tables, rather
+// than an INTO or a TABLE keyword. A subquery, a function or a parenthesized
+// join is read up to its opening parenthesis, as the rest of the statement.
+func (r *tableReader) item(i int, isFrom bool) int { //nolint:cyclop
+	tokens := r.tokens
+	for i < len(tokens) && tokens[i].isWord("lateral", "only", "if", "not", "exists") {
+		i++
+	}
+	switch {
+	case i >= len(tokens):
+		return i
+	case isFrom && tokens[i].isSymbol("("):
+		if i+1 < len(tokens) && tokens[i+1].isWord("select", "with", "values", "table") {
+			r.frames = append(r.frames, tableFrame{item: true})
+			return i
+		}
+		r.frames = append(r.frames, tableFrame{from: true, item: true, group: true, firstRef: len(r.refs)})
+		return r.item(i+1, true)
+	case tokens[i].kind == tokenString:
+		// A string literal, returned to be rejected.
+		ref := tableRef{name: tokens[i].text, index: i}
+		a, next := readAlias(tokens, i+1)
+		if a != nil {
+			ref.aliases = append(ref.aliases, *a)
+		}
+		r.refs = append(r.refs, ref)
+		return skipModifiers(tokens, next) - 1
+	case !tokens[i].isIdentifier():
+		return i - 1
+	}
+
+	parts := []string{tokens[i].text}
+	for i+2 < len(tokens) && tokens[i+1].isSymbol(".") && tokens[i+2].isIdentifier() {
+		parts = append(parts, tokens[i+2].text)
+		i += 2
+	}
+	if isFrom && i+1 < len(tokens) && tokens[i+1].isSymbol("(") {
+		// A table-valued function, whose arguments are read as the rest of
+		// the statement.
+		r.frames = append(r.frames, tableFrame{call: strings.ToLower(parts[len(parts)-1]), item: true})
+		return i + 1
+	}
+	ref := tableRef{
+		schema: strings.Join(parts[:len(parts)-1], "."),
+		name:   parts[len(parts)-1],
+		index:  i,
+	}
+	next := i + 1
+	if !isFrom {
+		var a *tableAlias
+		if a, next = readAlias(tokens, next); a != nil {
+			ref.aliases = append(ref.aliases, *a)
+		}
+		r.refs = append(r.refs, ref)
+		return next - 1
+	}
+	// The tables inheriting from the table, in PostgreSQL.
+	if next < len(tokens) && tokens[next].isSymbol("*") {
+		next++
+	}
+	next = skipModifiers(tokens, next)
+	var a *tableAlias
+	if a, next = readAlias(tokens, next); a != nil {
+		ref.aliases = append(ref.aliases, *a)
+	}
+	if columns := skipColumnAliases(tokens, next); columns != next {
+		ref.columnAliases = true
+		next = columns
+	}
+	r.refs = append(r.refs, ref)
+	return skipModifiers(tokens, next) - 1
+}
+
+// itemEnd reads the alias following the closing parenthesis of a table of a
+// list, starting at index i, and returns the index of the token following
+// it.
+func (r *tableReader) itemEnd(i int, f tableFrame) int {
+	tokens := r.tokens
+	if i+1 < len(tokens) && tokens[i].isWord("with") && tokens[i+1].isWord("ordinality") {
+		i += 2
+	}
+	a, i := readAlias(tokens, i)
+	columns := skipColumnAliases(tokens, i)
+	for j := f.firstRef; f.group && j < len(r.refs); j++ {
+		if a != nil {
+			r.refs[j].aliases = append(r.refs[j].aliases, *a)
+		}
+		r.refs[j].columnAliases = r.refs[j].columnAliases || columns != i
+	}
+	return skipModifiers(tokens, columns)
+}
+
+// cteNames returns the names of the common table expressions of a WITH
+// clause.
+func cteNames(tokens []token, i int) []string {
+	var names []string
+	if i < len(tokens) && tokens[i].isWord("recursive") {
+		i++
+	}
+	for i < len(tokens) && tokens[i].isIdentifier() {
+		names = append(names, tokens[i].text)
+		i++
+		if i < len(tokens) && tokens[i].isSymbol("(") {
+			i = closingParenthesis(tokens, i) + 1
+		}
+		for i < len(tokens) && tokens[i].isWord("as", "not", "materialized") {
+			i++
+		}
+		if i >= len(tokens) || !tokens[i].isSymbol("(") {
+			break
+		}
+		i = closingParenthesis(tokens, i) + 1
+		if i >= len(tokens) || !tokens[i].isSymbol(",") {
+			break
+		}
+		i++
+	}
+	return names
+}
+
+// readAlias returns the alias of a table or a subquery starting at index i,
+// if any, and the index of the token following it.
+func readAlias(tokens []token, i int) (*tableAlias, int) {
+	if i < len(tokens) && tokens[i].isWord("as") {
+		i++
+	}
+	if i < len(tokens) && (tokens[i].kind == tokenQuoted ||
+		(tokens[i].kind == tokenWord && !slices.Contains(_aliasStopWords, tokens[i].text))) {
+		return &tableAlias{name: tokens[i].text, index: i}, i + 1
+	}
+	return nil, i
+}
+
+// skipColumnAliases returns the index of the token following the list of
+// column aliases starting at index i, e.g. (a, b) in AS t(a, b), or i if
+// there is none.
+func skipColumnAliases(tokens []token, i int) int {
+	if i < len(tokens) && tokens[i].isSymbol("(") {
+		return closingParenthesis(tokens, i) + 1
+	}
+	return i
+}
+
+// skipModifiers returns the index of the token following the modifiers of a
+// table starting at index i: its partitions, its sample and its index hints.
+func skipModifiers(tokens []token, i int) int { //nolint:cyclop
+	at := func(j int, words ...string) bool { return j < len(tokens) && tokens[j].isWord(words...) }
+	for i < len(tokens) {
+		switch {
+		case at(i, "partition") && i+1 < len(tokens) && tokens[i+1].isSymbol("("):
+			i = closingParenthesis(tokens, i+1) + 1
+		case at(i, "tablesample"):
+			for i++; i < len(tokens) && (tokens[i].kind == tokenNumber || tokens[i].isSymbol("%") ||
+				tokens[i].isSymbol("(") || tokens[i].isWord(_sampleWords...)); i++ {
+				if tokens[i].isSymbol("(") {
+					i = closingParenthesis(tokens, i)
+				}
+			}
+		case at(i, "use", "force", "ignore") && at(i+1, "index", "key"):
+			i += 2
+			if at(i, "for") {
+				i++
+				for at(i, "join", "order", "group", "by") {
+					i++
+				}
+			}
+			i = skipColumnAliases(tokens, i)
+			// The index hints are separated by commas.
+			if i+2 < len(tokens) && tokens[i].isSymbol(",") &&
+				at(i+1, "use", "force", "ignore") && at(i+2, "index", "key") {
+				i++
+			}
+		case at(i, "indexed") && at(i+1, "by"):
+			i += 3
+		case at(i, "not") && at(i+1, "indexed"):
+			i += 2
+		default:
+			return i
+		}
+	}
+	return i
+}
+
+// closingParenthesis returns the index of the parenthesis closing the one at
+// index i, or the last index.
+func closingParenthesis(tokens []token, i int) int {
+	depth := 0
+	for ; i < len(tokens); i++ {
+		switch {
+		case tokens[i].isSymbol("("):
+			depth++
+		case tokens[i].isSymbol(")"):
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+func quoteIdentifier(dialect, name string) string {
+	if dialect == "mysql" {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func containsFold(list []string, s string) bool {
+	return slices.ContainsFunc(list, func(e string) bool { return strings.EqualFold(e, s) })
+}
@@ -0,0 +1,267 @@
+package sqldatabase_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/tools/sqldatabase"
+	_ "github.com/tmc/langchaingo/tools/sqldatabase/sqlite3"
+)
+
+// fakeEngine records the queries it runs.
+type fakeEngine struct {
+	dialect string
+	queries []string
+}
+
+func (e *fakeEngine) Dialect() string { return e.dialect }
+
+func (e *fakeEngine) Query(_ context.Context, query string, _ ...any) ([]string, [][]string, error) {
+	e.queries = append(e.queries, query)
+	return []string{"id", "name", "salary"}, nil, nil
+}
+
+func (e *fakeEngine) TableNames(context.Context) ([]string, error) {
+	return []string{"users", "orders", "secrets"}, nil
+}
+
+func (e *fakeEngine) TableInfo(context.Context, string) (string, error) { return "", nil }
+
+func (e *fakeEngine) Close() error { return nil }
+
+func TestQueryGuard(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		dialect  string
+		query    string
+		expected string
+		err      string
+	}{
+		{"sqlite3", "SELECT name FROM users;", "SELECT name FROM users LIMIT 100", ""},
+		{"sqlite3", "SELECT name FROM users LIMIT 5", "SELECT name FROM users LIMIT 5", ""},
+		{"sqlite3", "SELECT name FROM users LIMIT 500", "SELECT name FROM users LIMIT 100", ""},
+		{"mysql", "SELECT name FROM users LIMIT 10, 500", "SELECT name FROM users LIMIT 10, 100", ""},
+		{"pgx", "SELECT name FROM users OFFSET 5", "SELECT name FROM users LIMIT 100 OFFSET 5", ""},
+		{"pgx", "SELECT name FROM users FETCH FIRST 5 ROWS ONLY", "SELECT name FROM users FETCH FIRST 5 ROWS ONLY", ""},
+		{
+			"sqlite3", "SELECT u.name, (SELECT count(*) FROM orders o WHERE o.user_id = u.id LIMIT 1) FROM users u",
+			"SELECT u.name, (SELECT count(*) FROM orders o WHERE o.user_id = u.id LIMIT 1) FROM users u LIMIT 100", "",
+		},
+		{
+			"sqlite3", "WITH big AS (SELECT * FROM orders) SELECT * FROM big -- ; DROP TABLE users",
+			"WITH big AS (SELECT * FROM orders) SELECT * FROM big LIMIT 100", "",
+		},
+		{"sqlite3", "SELECT 'DROP TABLE users; --' FROM users", "SELECT 'DROP TABLE users; --' FROM users LIMIT 100", ""},
+		{"mysql", `SELECT "it\"s; DELETE" FROM users`, `SELECT "it\"s; DELETE" FROM users LIMIT 100`, ""},
+		{"pgx", "SELECT $$; DELETE$$, replace(name, 'a', 'b') FROM users", "SELECT $$; DELETE$$, replace(name, 'a', 'b') FROM users LIMIT 100", ""}, //nolint:lll
+		{"sqlite3", "SELECT extract(year FROM created) FROM users", "SELECT extract(year FROM created) FROM users LIMIT 100", ""},
+		{"sqlite3", "DROP TABLE users", "", "only SELECT queries are allowed, the query is a DROP statement"},
+		{"sqlite3", "SELECT 1; DROP TABLE users", "", "only one statement can be run at a time, the query has 2 statements"},
+		{"pgx", "WITH d AS (DELETE FROM users RETURNING *) SELECT * FROM d", "", "only SELECT queries are allowed"},
+		{"pgx", "SELECT * INTO backup FROM users", "", "only SELECT queries are allowed"},
+		{"pgx", "SELECT pg_sleep(100)", "", "the function pg_sleep is not allowed"},
+		{"sqlite3", "SELECT name FROM users WHERE name = 'unterminated", "", "unterminated quote"},
+		{"mysql", "SELECT name FROM users /*! UNION SELECT pw FROM secrets */", "", "executable comments are not allowed"},
+		{"mysql", "SELECT name FROM users /*M!100000 , secrets */", "", "executable comments are not allowed"},
+		{"pgx", "SELECT name /*! comment */ FROM users", "SELECT name /*! comment */ FROM users LIMIT 100", ""},
+		{"sqlite3", "", "", "the query is empty"},
+		{"sqlite3", "SELECT * FROM secrets", "", "the table secrets is not allowed, the allowed tables are: users, orders"},
+		{"sqlite3", "SELECT * FROM users JOIN `secrets` ON 1", "", "the table secrets is not allowed"},
+		{"sqlite3", "SELECT * FROM users, other.users", "", "the table users is not allowed"},
+		{"pgx", "SELECT * FROM public.users", "SELECT * FROM public.users LIMIT 100", ""},
+		{"sqlite3", "SELECT * FROM (secrets)", "", "the table secrets is not allowed"},
+		{"sqlite3", "SELECT pw FROM (secrets CROSS JOIN users)", "", "the table secrets is not allowed"},
+		{"pgx", "SELECT pw FROM ((users) AS u JOIN (secrets) ON true)", "", "the table secrets is not allowed"},
+		{"sqlite3", "SELECT * FROM (SELECT 1) AS s, secrets", "", "the table secrets is not allowed"},
+		{
+			"sqlite3", "SELECT * FROM (users JOIN orders ON 1) AS j, (SELECT 1) s",
+			"SELECT * FROM (users JOIN orders ON 1) AS j, (SELECT 1) s LIMIT 100", "",
+		},
+		{"sqlite3", "SELECT * FROM json_each('[1]'), secrets", "", "the table secrets is not allowed"},
+		{"pgx", "SELECT * FROM generate_series(1, 2), secrets", "", "the table secrets is not allowed"},
+		{"pgx", "SELECT * FROM generate_series(1, 2) WITH ORDINALITY AS g(a, b), secrets", "", "the table secrets is not allowed"},
+		{"pgx", "SELECT * FROM unnest((SELECT array_agg(pw) FROM secrets)) AS s", "", "the table secrets is not allowed"},
+		{"pgx", "SELECT * FROM users AS u(a, b, c), secrets", "", "the table secrets is not allowed"},
+		{"pgx", "SELECT * FROM users TABLESAMPLE BERNOULLI (50), secrets", "", "the table secrets is not allowed"},
+		{"pgx", "SELECT * FROM users * AS u, secrets", "", "the table secrets is not allowed"},
+		{"mysql", "SELECT * FROM users USE INDEX (PRIMARY), secrets", "", "the table secrets is not allowed"},
+		{"mysql", "SELECT * FROM users PARTITION (p0) u IGNORE INDEX FOR JOIN (a), secrets", "", "the table secrets is not allowed"},
+		{"sqlite3", "SELECT * FROM users INDEXED BY idx, secrets", "", "the table secrets is not allowed"},
+		{"sqlite3", "SELECT * FROM users NOT INDEXED, secrets", "", "the table secrets is not allowed"},
+		{"sqlite3", "SELECT * FROM users JOIN orders ON users.id = orders.user_id, secrets", "", "the table secrets is not allowed"},
+		{
+			"mysql", "SELECT * FROM users FORCE INDEX (a), IGNORE KEY (b) JOIN orders ON 1 ORDER BY users.id, orders.id",
+			"SELECT * FROM users FORCE INDEX (a), IGNORE KEY (b) JOIN orders ON 1 ORDER BY users.id, orders.id LIMIT 100", "",
+		},
+		{
+			"pgx", "SELECT * FROM users u(a, b) JOIN unnest(ARRAY[1, 2]) x ON true GROUP BY a, b",
+			"SELECT * FROM users u(a, b) JOIN unnest(ARRAY[1, 2]) x ON true GROUP BY a, b LIMIT 100", "",
+		},
+		{"sqlite3", "SELECT * FROM 'secrets'", "", "tables are identifiers, not strings: 'secrets'"},
+		{"sqlite3", "SELECT * FROM users, 'secrets'", "", "tables are identifiers, not strings: 'secrets'"},
+		{"sqlite3", "SELECT * FROM users, ('secrets')", "", "tables are identifiers, not strings: 'secrets'"},
+		{"duckdb", "SELECT * FROM users, '/tmp/x.csv' AS x", "", "tables are identifiers, not strings: '/tmp/x.csv'"},
+		{"duckdb", "SELECT * FROM read_csv('/etc/passwd')", "", "the function read_csv is not allowed"},
+		{"duckdb", `SELECT * FROM "Read_CSV_Auto"('/etc/passwd')`, "", "the function Read_CSV_Auto is not allowed"},
+		{"duckdb", "SELECT * FROM query_table('secrets')", "", "the function query_table is not allowed"},
+		{"duckdb", "SELECT * FROM query('SELECT * FROM secrets')", "", "the function query is not allowed"},
+		{"duckdb", `SELECT "COLUMNS"('sal.*') FROM users`, "", "the function COLUMNS is not allowed"},
+		{"duckdb", `SELECT * FROM users JOIN "/etc/users.csv" ON true`, "", "/etc/users.csv is not a table of the database"},
+		{"duckdb", `SELECT * FROM "users"`, `SELECT * FROM "users" LIMIT 100`, ""},
+	}
+	for _, c := range cases {
+		engine := &fakeEngine{dialect: c.dialect}
+		db, err := sqldatabase.NewSQLDatabase(engine, nil, sqldatabase.WithAllowedTables("users", "orders"))
+		require.NoError(t, err)
+
+		_, err = db.Query(context.Background(), c.query)
+		if c.err != "" {
+			require.ErrorIs(t, err, sqldatabase.ErrQueryNotAllowed, c.query)
+			require.ErrorContains(t, err, c.err, c.query)
+			require.Empty(t, engine.queries, c.query)
+			continue
+		}
+		require.NoError(t, err, c.query)
+		require.Equal(t, []string{c.expected}, engine.queries, c.query)
+	}
+}
+
+func TestQueryGuardOptions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	engine := &fakeEngine{dialect: "sqlite3"}
+	db, err := sqldatabase.NewSQLDatabase(engine, nil,
+		sqldatabase.WithAllowWrites(),
+		sqldatabase.WithAllowMultipleStatements(),
+		sqldatabase.WithMaxRows(0))
+	require.NoError(t, err)
+
+	_, err = db.Query(ctx, "DELETE FROM users; SELECT name FROM users")
+	require.NoError(t, err)
+	require.Equal(t, []string{"DELETE FROM users;\nSELECT name FROM users"}, engine.queries)
+}
+
+// readOnlyEngine records the queries it runs read-only.
+type readOnlyEngine struct {
+	fakeEngine
+	readOnly []string
+}
+
+func (e *readOnlyEngine) ReadOnly(context.Context) (sqldatabase.Engine, func() error, error) {
+	ro := &fakeEngine{dialect: e.dialect}
+	return ro, func() error {
+		e.readOnly = append(e.readOnly, ro.queries...)
+		return nil
+	}, nil
+}
+
+func TestQueryGuardReadOnly(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	engine := &readOnlyEngine{fakeEngine: fakeEngine{dialect: "pgx"}}
+	db, err := sqldatabase.NewSQLDatabase(engine, nil)
+	require.NoError(t, err)
+	_, err = db.Query(ctx, "SELECT lo_unlink(16384)")
+	require.NoError(t, err)
+	_, err = db.QueryResult(ctx, "SELECT setval('users_id_seq', 1)")
+	require.NoError(t, err)
+	require.Equal(t, []string{"SELECT lo_unlink(16384) LIMIT 100", "SELECT setval('users_id_seq', 1) LIMIT 101"},
+		engine.readOnly)
+	require.Empty(t, engine.queries)
+
+	engine = &readOnlyEngine{fakeEngine: fakeEngine{dialect: "pgx"}}
+	db, err = sqldatabase.NewSQLDatabase(engine, nil, sqldatabase.WithAllowWrites())
+	require.NoError(t, err)
+	_, err = db.Query(ctx, "DELETE FROM users")
+	require.NoError(t, err)
+	require.Equal(t, []string{"DELETE FROM users"}, engine.queries)
+	require.Empty(t, engine.readOnly)
+}
+
+func TestQueryGuardColumns(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn := filepath.Join(t.TempDir(), "test.sqlite")
+	conn, err := sql.Open("sqlite3", dsn)
+	require.NoError(t, err)
+	_, err = conn.Exec(`CREATE TABLE users (id int, name text, salary int);
+		INSERT INTO users VALUES (1, 'Ada', 100), (2, 'Linus', 200);
+		CREATE TABLE orders (id int, user_id int, salary int);`)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	db, err := sqldatabase.NewSQLDatabaseWithDSN("sqlite3", dsn, nil,
+		sqldatabase.WithAllowedColumns("users", "id", "name"),
+		sqldatabase.WithMaxRows(1),
+		sqldatabase.WithQueryTimeout(100*time.Millisecond))
+	require.NoError(t, err)
+	defer db.Close()
+
+	out, err := db.Query(ctx, "SELECT u.id, name FROM users AS u ORDER BY id")
+	require.NoError(t, err)
+	require.Equal(t, "id\tname\n1\tAda\n", out)
+
+	out, err = db.Query(ctx, "SELECT users.name, orders.salary FROM users JOIN orders ON orders.user_id = users.id")
+	require.NoError(t, err)
+	require.Equal(t, "name\tsalary\n", out)
+
+	for _, query := range []string{
+		"SELECT * FROM users",
+		"SELECT u.* FROM users u",
+		"SELECT name FROM users WHERE salary > 100",
+		`SELECT u."salary" FROM users u`,
+		"SELECT j.salary FROM (users JOIN orders ON orders.user_id = users.id) AS j",
+	} {
+		_, err = db.Query(ctx, query)
+		require.ErrorIs(t, err, sqldatabase.ErrQueryNotAllowed, query)
+		require.ErrorContains(t, err, "of the table users is not allowed, the allowed columns are: id, name", query)
+	}
+
+	// The whole rows of the table, with all its columns, can't be read either.
+	for _, query := range []string{
+		"SELECT u FROM users u",
+		"SELECT to_json(u) FROM users AS u",
+		"SELECT row_to_json(users) FROM users",
+		"SELECT u::text FROM users u",
+	} {
+		_, err = db.Query(ctx, query)
+		require.ErrorIs(t, err, sqldatabase.ErrQueryNotAllowed, query)
+		require.ErrorContains(t, err, "the rows of the table users cannot be used as values", query)
+	}
+
+	// Nor can its columns be renamed.
+	for _, query := range []string{
+		"SELECT c FROM users AS u(a, b, c)",
+		"SELECT j.c FROM (users CROSS JOIN orders) AS j(a, b, c)",
+	} {
+		_, err = db.Query(ctx, query)
+		require.ErrorIs(t, err, sqldatabase.ErrQueryNotAllowed, query)
+		require.ErrorContains(t, err, "the columns of the table users cannot be renamed", query)
+	}
+	for _, query := range []string{
+		"SELECT t.c FROM (TABLE users) AS t(a, b, c)",
+		"WITH t(a, b, c) AS (TABLE users) SELECT c FROM t",
+		"SELECT id, name FROM users UNION ALL TABLE users",
+	} {
+		_, err = db.Query(ctx, query)
+		require.ErrorIs(t, err, sqldatabase.ErrQueryNotAllowed, query)
+		require.ErrorContains(t, err, "the column * of the table users is not allowed", query)
+	}
+
+	info, err := db.TableInfo(ctx, []string{"users"})
+	require.NoError(t, err)
+	require.Contains(t, info, "Only the columns id, name of users can be queried.")
+	require.Contains(t, info, "1\tAda")
+	require.NotContains(t, info, "100")
+
+	_, err = db.Query(ctx, "WITH RECURSIVE c(x) AS (SELECT 1 UNION ALL SELECT x + 1 FROM c) SELECT count(*) FROM c")
+	require.ErrorIs(t, err, sqldatabase.ErrQueryTimeout)
+}
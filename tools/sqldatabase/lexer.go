@@ -0,0 +1,214 @@
+package sqldatabase
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tokenKind is the kind of a token of a query.
+type tokenKind int
+
+const (
+	tokenWord   tokenKind = iota // A keyword or an unquoted identifier.
+	tokenQuoted                  // A quoted identifier.
+	tokenString                  // A string literal.
+	tokenNumber                  // A number literal.
+	tokenSymbol                  // An operator, a punctuation or a parameter.
+)
+
+// token is a token of a query.
+type token struct {
+	kind tokenKind
+	// text is the lower case word, the unquoted identifier, or the raw text
+	// of the other tokens.
+	text string
+	// start and end are the byte offsets of the token in the query.
+	start, end int
+}
+
+func (t token) isWord(words ...string) bool {
+	if t.kind != tokenWord {
+		return false
+	}
+	for _, w := range words {
+		if t.text == w {
+			return true
+		}
+	}
+	return false
+}
+
+func (t token) isSymbol(symbol string) bool {
+	return t.kind == tokenSymbol && t.text == symbol
+}
+
+func (t token) isIdentifier() bool {
+	return t.kind == tokenWord || t.kind == tokenQuoted
+}
+
+// tokenize splits a query into tokens, following the quoting rules of the
+// dialect. Comments are skipped, and the executable comments of MySQL are
+// rejected.
+func tokenize(dialect, query string) ([]token, error) { //nolint:cyclop,funlen
+	l := lexer{dialect: dialect, query: query}
+	for {
+		l.skipSpacesAndComments()
+		if l.err != nil {
+			return nil, l.err
+		}
+		if l.pos >= len(query) {
+			return l.tokens, nil
+		}
+
+		start := l.pos
+		c := query[l.pos]
+		switch {
+		case c == '\'':
+			l.quoted(tokenString, '\'', l.dialect == "mysql")
+		case c == '"' && l.dialect == "mysql":
+			l.quoted(tokenString, '"', true)
+		case c == '"':
+			l.quoted(tokenQuoted, '"', false)
+		case c == '`':
+			l.quoted(tokenQuoted, '`', false)
+		case c == '[' && l.dialect == "sqlite3":
+			end := strings.IndexByte(query[l.pos:], ']')
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated identifier", ErrQueryNotAllowed)
+			}
+			l.pos += end + 1
+			l.add(tokenQuoted, query[start+1:l.pos-1], start)
+		case c == '$' && l.dialect == "pgx":
+			l.dollar()
+		case isDigit(c) || (c == '.' && l.pos+1 < len(query) && isDigit(query[l.pos+1])):
+			for l.pos < len(query) && (isWordChar(query[l.pos]) || query[l.pos] == '.') {
+				l.pos++
+			}
+			l.add(tokenNumber, query[start:l.pos], start)
+		case isWordChar(c):
+			for l.pos < len(query) && isWordChar(query[l.pos]) {
+				l.pos++
+			}
+			// Prefixed strings: E'', B'', X'' or N''.
+			if l.pos-start == 1 && l.pos < len(query) && query[l.pos] == '\'' &&
+				strings.ContainsRune("eEbBxXnN", rune(c)) {
+				l.quoted(tokenString, '\'', l.dialect == "mysql" || c == 'e' || c == 'E')
+				l.tokens[len(l.tokens)-1].start = start
+				break
+			}
+			l.add(tokenWord, strings.ToLower(query[start:l.pos]), start)
+		case c == ':' && strings.HasPrefix(query[l.pos:], "::"):
+			l.pos += 2
+			l.add(tokenSymbol, "::", start)
+		default:
+			l.pos++
+			l.add(tokenSymbol, query[start:l.pos], start)
+		}
+		if l.err != nil {
+			return nil, l.err
+		}
+	}
+}
+
+type lexer struct {
+	dialect string
+	query   string
+	pos     int
+	tokens  []token
+	err     error
+}
+
+func (l *lexer) add(kind tokenKind, text string, start int) {
+	l.tokens = append(l.tokens, token{kind: kind, text: text, start: start, end: l.pos})
+}
+
+func (l *lexer) skipSpacesAndComments() {
+	for l.pos < len(l.query) {
+		rest := l.query[l.pos:]
+		switch {
+		case rest[0] == ' ' || rest[0] == '\t' || rest[0] == '\n' || rest[0] == '\r' || rest[0] == '\f':
+			l.pos++
+		case strings.HasPrefix(rest, "--") || (rest[0] == '#' && l.dialect == "mysql"):
+			end := strings.IndexByte(rest, '\n')
+			if end < 0 {
+				end = len(rest)
+			}
+			l.pos += end
+		case l.dialect == "mysql" && (strings.HasPrefix(rest, "/*!") || strings.HasPrefix(rest, "/*M!")):
+			// MySQL and MariaDB run the content of these comments.
+			l.err = fmt.Errorf("%w: executable comments are not allowed", ErrQueryNotAllowed)
+			return
+		case strings.HasPrefix(rest, "/*"):
+			end := strings.Index(rest[2:], "*/")
+			if end < 0 {
+				l.err = fmt.Errorf("%w: unterminated comment", ErrQueryNotAllowed)
+				return
+			}
+			l.pos += end + 4 //nolint:gomnd
+		default:
+			return
+		}
+	}
+}
+
+// quoted reads a quoted string or identifier. The quote is escaped by
+// doubling it, or with a backslash if backslashes are escapes.
+func (l *lexer) quoted(kind tokenKind, quote byte, backslashes bool) {
+	start := l.pos
+	l.pos++
+	var value strings.Builder
+	for l.pos < len(l.query) {
+		c := l.query[l.pos]
+		switch {
+		case c == '\\' && backslashes && l.pos+1 < len(l.query):
+			value.WriteByte(l.query[l.pos+1])
+			l.pos += 2
+		case c == quote && l.pos+1 < len(l.query) && l.query[l.pos+1] == quote:
+			value.WriteByte(quote)
+			l.pos += 2
+		case c == quote:
+			l.pos++
+			if kind == tokenQuoted {
+				l.add(kind, value.String(), start)
+			} else {
+				l.add(kind, l.query[start:l.pos], start)
+			}
+			return
+		default:
+			value.WriteByte(c)
+			l.pos++
+		}
+	}
+	l.err = fmt.Errorf("%w: unterminated quote %c", ErrQueryNotAllowed, quote)
+}
+
+// dollar reads a PostgreSQL positional parameter ($1) or dollar-quoted string
+// ($$text$$ or $tag$text$tag$).
+func (l *lexer) dollar() {
+	start := l.pos
+	end := l.pos + 1
+	for end < len(l.query) && isWordChar(l.query[end]) && l.query[end] != '$' {
+		end++
+	}
+	if end >= len(l.query) || l.query[end] != '$' {
+		l.pos = end
+		l.add(tokenSymbol, l.query[start:l.pos], start)
+		return
+	}
+	tag := l.query[start : end+1]
+	closing := strings.Index(l.query[end+1:], tag)
+	if closing < 0 {
+		l.err = fmt.Errorf("%w: unterminated dollar-quoted string", ErrQueryNotAllowed)
+		return
+	}
+	l.pos = end + 1 + closing + len(tag)
+	l.add(tokenString, l.query[start:l.pos], start)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || c == '$' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
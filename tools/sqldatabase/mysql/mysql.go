@@ -16,11 +16,18 @@ func init() {
 	sqldatabase.RegisterEngine(EngineName, NewMySQL)
 }
 
-var _ sqldatabase.Engine = MySQL{}
+var (
+	_ sqldatabase.Engine           = MySQL{}
+	_ sqldatabase.ForeignKeyEngine = MySQL{}
+	_ sqldatabase.RowsEngine       = MySQL{}
+	_ sqldatabase.ReadOnlyEngine   = MySQL{}
+)
 
 // MySQL is a MySQL engine.
 type MySQL struct {
 	db *sql.DB
+	// tx is the read-only transaction running the queries, if any.
+	tx *sql.Tx
 }
 
 // NewMySQL creates a new MySQL engine.
@@ -42,7 +49,7 @@ func (m MySQL) Dialect() string {
 }
 
 func (m MySQL) Query(ctx context.Context, query string, args ...any) ([]string, [][]string, error) {
-	rows, err := m.db.QueryContext(ctx, query, args...)
+	rows, err := m.queryContext(ctx, query, args...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -71,9 +78,16 @@ func (m MySQL) Query(ctx context.Context, query string, args ...any) ([]string,
 		}
 		results = append(results, row)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
 	return cols, results, nil
 }
 
+func (m MySQL) QueryRows(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return m.queryContext(ctx, query, args...)
+}
+
 func (m MySQL) TableNames(ctx context.Context) ([]string, error) {
 	_, result, err := m.Query(ctx, "SHOW TABLES")
 	if err != nil {
@@ -101,6 +115,46 @@ func (m MySQL) TableInfo(ctx context.Context, table string) (string, error) {
 	return result[0][1], nil //nolint:gomnd
 }
 
+func (m MySQL) ForeignKeys(ctx context.Context, table string) ([]sqldatabase.ForeignKey, error) {
+	_, result, err := m.Query(ctx, `SELECT COLUMN_NAME, REFERENCED_TABLE_NAME, REFERENCED_COLUMN_NAME
+		FROM information_schema.KEY_COLUMN_USAGE
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND REFERENCED_TABLE_NAME IS NOT NULL`, table)
+	if err != nil {
+		return nil, err
+	}
+	return foreignKeys(table, result), nil
+}
+
+// ReadOnly returns an engine running its queries in a read-only transaction,
+// started with START TRANSACTION READ ONLY and rolled back by end.
+func (m MySQL) ReadOnly(ctx context.Context) (sqldatabase.Engine, func() error, error) { //nolint:ireturn
+	tx, err := m.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	return MySQL{db: m.db, tx: tx}, tx.Rollback, nil
+}
+
+func (m MySQL) queryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if m.tx != nil {
+		return m.tx.QueryContext(ctx, query, args...)
+	}
+	return m.db.QueryContext(ctx, query, args...)
+}
+
 func (m MySQL) Close() error {
 	return m.db.Close()
 }
+
+func foreignKeys(table string, result [][]string) []sqldatabase.ForeignKey {
+	keys := make([]sqldatabase.ForeignKey, 0, len(result))
+	for _, row := range result {
+		keys = append(keys, sqldatabase.ForeignKey{
+			Table:            table,
+			Column:           row[0],
+			ReferencedTable:  row[1],
+			ReferencedColumn: row[2],
+		})
+	}
+	return keys
+}
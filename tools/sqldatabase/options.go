@@ -0,0 +1,109 @@
+package sqldatabase
+
+import "time"
+
+const (
+	// DefaultMaxRows is the default maximum number of rows returned by a query.
+	DefaultMaxRows = 100
+	// DefaultQueryTimeout is the default timeout of a query.
+	DefaultQueryTimeout = 30 * time.Second
+)
+
+// Option is a function that configures a SQLDatabase.
+type Option func(*options)
+
+type options struct {
+	allowWrites             bool
+	allowMultipleStatements bool
+	maxRows                 int
+	queryTimeout            time.Duration
+	allowedTables           []string
+	allowedColumns          map[string][]string
+	tableDescriptions       map[string]string
+	columnDescriptions      map[string]map[string]string
+}
+
+func defaultOptions() options {
+	return options{
+		maxRows:      DefaultMaxRows,
+		queryTimeout: DefaultQueryTimeout,
+	}
+}
+
+// WithAllowWrites allows queries modifying the database or its schema. By
+// default, only SELECT queries are allowed.
+func WithAllowWrites() Option {
+	return func(o *options) {
+		o.allowWrites = true
+	}
+}
+
+// WithAllowMultipleStatements allows queries made of several statements
+// separated by semicolons. By default, a query is a single statement.
+func WithAllowMultipleStatements() Option {
+	return func(o *options) {
+		o.allowMultipleStatements = true
+	}
+}
+
+// WithMaxRows sets the maximum number of rows returned by a query: a LIMIT is
+// added to the queries without one, and lowered in the others. 0 means no
+// limit. The default is DefaultMaxRows.
+func WithMaxRows(maxRows int) Option {
+	return func(o *options) {
+		o.maxRows = maxRows
+	}
+}
+
+// WithQueryTimeout sets the timeout of a query. 0 means no timeout. The
+// default is DefaultQueryTimeout.
+func WithQueryTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.queryTimeout = timeout
+	}
+}
+
+// WithAllowedTables restricts the queries to the given tables. Other tables
+// are neither listed nor queryable. By default, all the tables are allowed.
+func WithAllowedTables(tables ...string) Option {
+	return func(o *options) {
+		o.allowedTables = append(o.allowedTables, tables...)
+	}
+}
+
+// WithAllowedColumns restricts the queries of a table to the given columns.
+// Selecting all the columns of the table with * is then rejected.
+func WithAllowedColumns(table string, columns ...string) Option {
+	return func(o *options) {
+		if o.allowedColumns == nil {
+			o.allowedColumns = make(map[string][]string)
+		}
+		o.allowedColumns[table] = append(o.allowedColumns[table], columns...)
+	}
+}
+
+// WithTableDescription describes a table. The description is shown with the
+// schema of the table, and helps to select the tables relevant to a question.
+func WithTableDescription(table, description string) Option {
+	return func(o *options) {
+		if o.tableDescriptions == nil {
+			o.tableDescriptions = make(map[string]string)
+		}
+		o.tableDescriptions[table] = description
+	}
+}
+
+// WithColumnDescription describes a column of a table. The description is
+// shown with the schema of the table, and helps to select the tables relevant
+// to a question.
+func WithColumnDescription(table, column, description string) Option {
+	return func(o *options) {
+		if o.columnDescriptions == nil {
+			o.columnDescriptions = make(map[string]map[string]string)
+		}
+		if o.columnDescriptions[table] == nil {
+			o.columnDescriptions[table] = make(map[string]string)
+		}
+		o.columnDescriptions[table][column] = description
+	}
+}
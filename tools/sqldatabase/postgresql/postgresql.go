@@ -18,11 +18,18 @@ func init() {
 	sqldatabase.RegisterEngine(EngineName, NewPostgreSQL)
 }
 
-var _ sqldatabase.Engine = PostgreSQL{}
+var (
+	_ sqldatabase.Engine           = PostgreSQL{}
+	_ sqldatabase.ForeignKeyEngine = PostgreSQL{}
+	_ sqldatabase.RowsEngine       = PostgreSQL{}
+	_ sqldatabase.ReadOnlyEngine   = PostgreSQL{}
+)
 
 // PostgreSQL represents the PostgreSQL engine.
 type PostgreSQL struct {
 	db *sql.DB
+	// tx is the read-only transaction running the queries, if any.
+	tx *sql.Tx
 }
 
 // NewPostgreSQL creates a new PostgreSQL engine instance.
@@ -49,7 +56,7 @@ func (p PostgreSQL) Dialect() string {
 // It takes a context.Context, a query string, and optional query arguments.
 // It returns the column names, query results as a 2D slice of strings, and an error, if any.
 func (p PostgreSQL) Query(ctx context.Context, query string, args ...any) ([]string, [][]string, error) {
-	rows, err := p.db.QueryContext(ctx, query, args...)
+	rows, err := p.queryContext(ctx, query, args...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -78,9 +85,19 @@ func (p PostgreSQL) Query(ctx context.Context, query string, args ...any) ([]str
 		}
 		results = append(results, row)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
 	return cols, results, nil
 }
 
+// QueryRows executes a query on the PostgreSQL engine.
+// It takes a context.Context, a query string, and optional query arguments.
+// It returns the rows of the query, and an error, if any.
+func (p PostgreSQL) QueryRows(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return p.queryContext(ctx, query, args...)
+}
+
 // TableNames returns the names of all tables in the PostgreSQL database.
 // It takes a context.Context.
 // It returns a slice of table names and an error, if any.
@@ -122,8 +139,56 @@ func (p PostgreSQL) TableInfo(ctx context.Context, table string) (string, error)
 	return result[0][1], nil //nolint:gomnd
 }
 
+// ForeignKeys returns the foreign keys of a specific table in the PostgreSQL database.
+// It takes a context.Context and the name of the table.
+// It returns the foreign keys and an error, if any.
+func (p PostgreSQL) ForeignKeys(ctx context.Context, table string) ([]sqldatabase.ForeignKey, error) {
+	_, result, err := p.Query(ctx, `SELECT
+		kcu.column_name,
+		ccu.table_name,
+		ccu.column_name
+	 FROM
+		information_schema.table_constraints tc
+		JOIN information_schema.key_column_usage kcu
+			ON tc.constraint_name = kcu.constraint_name AND tc.table_schema = kcu.table_schema
+		JOIN information_schema.constraint_column_usage ccu
+			ON tc.constraint_name = ccu.constraint_name AND tc.table_schema = ccu.table_schema
+	 WHERE
+		tc.constraint_type = 'FOREIGN KEY' AND tc.table_schema = 'public' AND tc.table_name = $1`, table)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]sqldatabase.ForeignKey, 0, len(result))
+	for _, row := range result {
+		keys = append(keys, sqldatabase.ForeignKey{
+			Table:            table,
+			Column:           row[0],
+			ReferencedTable:  row[1],
+			ReferencedColumn: row[2],
+		})
+	}
+	return keys, nil
+}
+
 // Close closes the connection to the PostgreSQL database.
 // It returns an error, if any.
+// ReadOnly returns an engine running its queries in a read-only transaction,
+// started with BEGIN READ ONLY and rolled back by end.
+func (p PostgreSQL) ReadOnly(ctx context.Context) (sqldatabase.Engine, func() error, error) { //nolint:ireturn
+	tx, err := p.db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	return PostgreSQL{db: p.db, tx: tx}, tx.Rollback, nil
+}
+
+func (p PostgreSQL) queryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if p.tx != nil {
+		return p.tx.QueryContext(ctx, query, args...)
+	}
+	return p.db.QueryContext(ctx, query, args...)
+}
+
 func (p PostgreSQL) Close() error {
 	return p.db.Close()
 }
@@ -0,0 +1,349 @@
+package sqldatabase
+
+import (
+	"context"
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RowsEngine is implemented by the engines giving access to the rows of a
+// query, to return typed values in results.
+type RowsEngine interface {
+	// QueryRows executes the query and returns its rows.
+	QueryRows(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// ValueNormalizer is implemented by the engines whose drivers return values of
+// their own types, to convert them to the types of the values of a Result.
+type ValueNormalizer interface {
+	// NormalizeValue returns the value converted, or the value itself.
+	NormalizeValue(v any) any
+}
+
+// Column is a column of the result of a query.
+type Column struct {
+	Name string `json:"name"`
+	// Type is the database type of the column, e.g. INTEGER or VARCHAR. It is
+	// empty if unknown.
+	Type string `json:"type,omitempty"`
+}
+
+// Result is a page of the rows of the result of a query.
+type Result struct {
+	Columns []Column `json:"columns"`
+	// Rows are the values of the rows, by column: nil, int64, float64, bool,
+	// string or time.Time.
+	Rows [][]any `json:"rows"`
+	// Offset is the index of the first row of the page in the result.
+	Offset int `json:"offset"`
+	// HasMore reports whether the result has rows after the page.
+	HasMore bool `json:"hasMore"`
+}
+
+// QueryOption is a function for configuring a query returning a Result.
+type QueryOption func(*queryOptions)
+
+type queryOptions struct {
+	offset   int
+	pageSize int
+}
+
+// WithOffset sets the index of the first row returned, to get the next pages
+// of a result.
+func WithOffset(offset int) QueryOption {
+	return func(o *queryOptions) {
+		o.offset = offset
+	}
+}
+
+// WithPageSize sets the maximum number of rows returned. It defaults to, and
+// cannot exceed, the maximum number of rows of the database.
+func WithPageSize(pageSize int) QueryOption {
+	return func(o *queryOptions) {
+		o.pageSize = pageSize
+	}
+}
+
+// QueryResult executes the query and returns a page of its result, with typed
+// values if the engine implements RowsEngine, and strings otherwise. The query
+// is checked and rewritten as by Query.
+func (sd *SQLDatabase) QueryResult(ctx context.Context, query string, opts ...QueryOption) (*Result, error) {
+	var o queryOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.offset = max(o.offset, 0)
+	if sd.guard != nil && sd.guard.maxRows > 0 && (o.pageSize <= 0 || o.pageSize > sd.guard.maxRows) {
+		o.pageSize = sd.guard.maxRows
+	}
+
+	if sd.guard != nil {
+		maxRows := 0
+		if o.pageSize > 0 {
+			// One more row tells whether the result has more rows.
+			maxRows = o.offset + o.pageSize + 1
+		}
+		var err error
+		if query, err = sd.guard.check(query, maxRows); err != nil {
+			return nil, err
+		}
+	}
+	ctx, cancel := sd.withTimeout(ctx)
+	defer cancel()
+
+	engine, end, err := sd.queryEngine(ctx)
+	if err != nil {
+		return nil, sd.queryError(ctx, err)
+	}
+	defer end() //nolint:errcheck
+	result, err := sd.queryResult(ctx, engine, query, o)
+	if err != nil {
+		return nil, sd.queryError(ctx, err)
+	}
+	return result, nil
+}
+
+func (sd *SQLDatabase) queryResult(ctx context.Context, engine Engine, query string, o queryOptions) (*Result, error) {
+	result := &Result{Offset: o.offset, Rows: make([][]any, 0)}
+	// add adds a row of the page, and reports whether the page is full.
+	skipped := 0
+	add := func(row []any) bool {
+		switch {
+		case skipped < o.offset:
+			skipped++
+			return false
+		case o.pageSize > 0 && len(result.Rows) == o.pageSize:
+			result.HasMore = true
+			return true
+		default:
+			result.Rows = append(result.Rows, row)
+			return false
+		}
+	}
+
+	rowsEngine, ok := engine.(RowsEngine)
+	if !ok {
+		cols, results, err := engine.Query(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		for _, col := range cols {
+			result.Columns = append(result.Columns, Column{Name: col})
+		}
+		for _, values := range results {
+			row := make([]any, len(values))
+			for i, v := range values {
+				row[i] = v
+			}
+			if add(row) {
+				break
+			}
+		}
+		return result, nil
+	}
+
+	rows, err := rowsEngine.QueryRows(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	types, err := rows.ColumnTypes()
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range types {
+		result.Columns = append(result.Columns, Column{Name: t.Name(), Type: t.DatabaseTypeName()})
+	}
+	normalizer, _ := sd.Engine.(ValueNormalizer)
+	for rows.Next() {
+		row := make([]any, len(types))
+		ptrs := make([]any, len(types))
+		for i := range row {
+			ptrs[i] = &row[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		for i, v := range row {
+			if normalizer != nil {
+				v = normalizer.NormalizeValue(v)
+			}
+			row[i] = normalizeValue(v, result.Columns[i].Type)
+		}
+		if add(row) {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// normalizeValue converts the integers and floats to int64 and float64, and
+// the values scanned as bytes, which some drivers return for all the types,
+// according to the type of their column. Decimals are kept as strings, not to
+// lose their precision.
+func normalizeValue(v any, columnType string) any { //nolint:cyclop
+	var s string
+	switch v := v.(type) {
+	case []byte:
+		s = string(v)
+	case int:
+		return int64(v)
+	case int8:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case uint8:
+		return int64(v)
+	case uint16:
+		return int64(v)
+	case uint32:
+		return int64(v)
+	case float32:
+		return float64(v)
+	default:
+		return v
+	}
+	switch strings.ToUpper(columnType) {
+	case "INT", "INTEGER", "TINYINT", "SMALLINT", "MEDIUMINT", "BIGINT", "INT2", "INT4", "INT8",
+		"UNSIGNED INT", "UNSIGNED TINYINT", "UNSIGNED SMALLINT", "UNSIGNED MEDIUMINT", "UNSIGNED BIGINT":
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n
+		}
+	case "FLOAT", "DOUBLE", "REAL", "FLOAT4", "FLOAT8":
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	case "BOOL", "BOOLEAN":
+		if v, err := strconv.ParseBool(s); err == nil {
+			return v
+		}
+	}
+	return s
+}
+
+// formatValue formats a value of a result as text.
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// String returns the result as tab-separated values, the format of Query.
+func (r *Result) String() string {
+	var b strings.Builder
+	for i, col := range r.Columns {
+		if i > 0 {
+			b.WriteByte('\t')
+		}
+		b.WriteString(col.Name)
+	}
+	b.WriteByte('\n')
+	for _, row := range r.Rows {
+		for i, v := range row {
+			if i > 0 {
+				b.WriteByte('\t')
+			}
+			b.WriteString(formatValue(v))
+		}
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// Markdown returns the result as a markdown table. NULL values are shown as
+// NULL, and a last line tells if the result has more rows.
+func (r *Result) Markdown() string {
+	escape := strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+	var b strings.Builder
+	b.WriteString("|")
+	for _, col := range r.Columns {
+		b.WriteString(" " + escape.Replace(col.Name) + " |")
+	}
+	b.WriteString("\n|")
+	for range r.Columns {
+		b.WriteString(" --- |")
+	}
+	b.WriteString("\n")
+	for _, row := range r.Rows {
+		b.WriteString("|")
+		for _, v := range row {
+			value := formatValue(v)
+			if v == nil {
+				value = "NULL"
+			}
+			b.WriteString(" " + escape.Replace(value) + " |")
+		}
+		b.WriteString("\n")
+	}
+	if r.HasMore {
+		fmt.Fprintf(&b, "\nRows %d to %d, the result has more rows.\n", r.Offset+1, r.Offset+len(r.Rows))
+	}
+	return b.String()
+}
+
+// CSV returns the result as CSV, with a header of the column names. NULL
+// values are empty.
+func (r *Result) CSV() (string, error) {
+	var b strings.Builder
+	w := csv.NewWriter(&b)
+	record := make([]string, len(r.Columns))
+	for i, col := range r.Columns {
+		record[i] = col.Name
+	}
+	if err := w.Write(record); err != nil {
+		return "", err
+	}
+	for _, row := range r.Rows {
+		for i, v := range row {
+			record[i] = formatValue(v)
+		}
+		if err := w.Write(record); err != nil {
+			return "", err
+		}
+	}
+	w.Flush()
+	return b.String(), w.Error()
+}
+
+// JSON returns the result as a JSON object, with its columns and its rows as
+// arrays of values.
+func (r *Result) JSON() (string, error) {
+	data, err := json.Marshal(r)
+	return string(data), err
+}
+
+// Records returns the rows of the result as maps of values by column name.
+func (r *Result) Records() []map[string]any {
+	records := make([]map[string]any, 0, len(r.Rows))
+	for _, row := range r.Rows {
+		record := make(map[string]any, len(r.Columns))
+		for i, col := range r.Columns {
+			record[col.Name] = row[i]
+		}
+		records = append(records, record)
+	}
+	return records
+}
@@ -0,0 +1,77 @@
+package sqldatabase_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/tools/sqldatabase"
+)
+
+func TestQueryResult(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	dsn := filepath.Join(t.TempDir(), "test.sqlite")
+	conn, err := sql.Open("sqlite3", dsn)
+	require.NoError(t, err)
+	_, err = conn.Exec(`CREATE TABLE products (id INTEGER, title TEXT, price REAL);
+		INSERT INTO products VALUES (1, 'Gopher | plush', 9.5), (2, 'Mug', NULL), (3, 'Book, "Go"', 30);`)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	db, err := sqldatabase.NewSQLDatabaseWithDSN("sqlite3", dsn, nil, sqldatabase.WithMaxRows(2))
+	require.NoError(t, err)
+	defer db.Close()
+
+	result, err := db.QueryResult(ctx, "SELECT id, title, price FROM products ORDER BY id")
+	require.NoError(t, err)
+	require.Equal(t, &sqldatabase.Result{
+		Columns: []sqldatabase.Column{{Name: "id", Type: "INTEGER"}, {Name: "title", Type: "TEXT"}, {Name: "price", Type: "REAL"}},
+		Rows:    [][]any{{int64(1), "Gopher | plush", 9.5}, {int64(2), "Mug", nil}},
+		HasMore: true,
+	}, result)
+
+	require.Equal(t, "id\ttitle\tprice\n1\tGopher | plush\t9.5\n2\tMug\t\n", result.String())
+	require.Equal(t, `| id | title | price |
+| --- | --- | --- |
+| 1 | Gopher \| plush | 9.5 |
+| 2 | Mug | NULL |
+
+Rows 1 to 2, the result has more rows.
+`, result.Markdown())
+
+	next, err := db.QueryResult(ctx, "SELECT id, title, price FROM products ORDER BY id", sqldatabase.WithOffset(2))
+	require.NoError(t, err)
+	require.Equal(t, 2, next.Offset)
+	require.False(t, next.HasMore)
+	require.Equal(t, [][]any{{int64(3), `Book, "Go"`, float64(30)}}, next.Rows)
+
+	csv, err := next.CSV()
+	require.NoError(t, err)
+	require.Equal(t, "id,title,price\n3,\"Book, \"\"Go\"\"\",30\n", csv)
+
+	json, err := next.JSON()
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"columns": [{"name":"id","type":"INTEGER"},{"name":"title","type":"TEXT"},{"name":"price","type":"REAL"}],
+		"rows": [[3,"Book, \"Go\"",30]],
+		"offset": 2,
+		"hasMore": false
+	}`, json)
+	require.Equal(t, []map[string]any{{"id": int64(3), "title": `Book, "Go"`, "price": float64(30)}}, next.Records())
+
+	// The page size cannot exceed the maximum number of rows.
+	result, err = db.QueryResult(ctx, "SELECT id FROM products", sqldatabase.WithPageSize(1))
+	require.NoError(t, err)
+	require.Len(t, result.Rows, 1)
+	require.True(t, result.HasMore)
+	result, err = db.QueryResult(ctx, "SELECT id FROM products", sqldatabase.WithPageSize(10))
+	require.NoError(t, err)
+	require.Len(t, result.Rows, 2)
+
+	_, err = db.QueryResult(ctx, "DELETE FROM products")
+	require.ErrorIs(t, err, sqldatabase.ErrQueryNotAllowed)
+}
@@ -0,0 +1,134 @@
+package sqldatabase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// TableSelector selects the tables relevant to a question, to describe only
+// these tables to a model when the database has many tables.
+type TableSelector interface {
+	// SelectTables returns the names of the tables relevant to the question.
+	SelectTables(ctx context.Context, question string) ([]string, error)
+}
+
+// ForeignKey is a column of a table referencing a column of another table.
+type ForeignKey struct {
+	Table            string
+	Column           string
+	ReferencedTable  string
+	ReferencedColumn string
+}
+
+// ForeignKeyEngine is implemented by the engines listing the foreign keys of
+// the tables.
+type ForeignKeyEngine interface {
+	// ForeignKeys returns the foreign keys of the table.
+	ForeignKeys(ctx context.Context, table string) ([]ForeignKey, error)
+}
+
+// TableDescription returns the description of a table given with
+// WithTableDescription, if any.
+func (sd *SQLDatabase) TableDescription(table string) string {
+	return sd.tableDescriptions[table]
+}
+
+// ColumnDescriptions returns the descriptions of the columns of a table given
+// with WithColumnDescription, by column.
+func (sd *SQLDatabase) ColumnDescriptions(table string) map[string]string {
+	return sd.columnDescriptions[table]
+}
+
+// TableSchema returns the schema of a table, typically its CREATE TABLE
+// statement, with its descriptions and without sample rows.
+func (sd *SQLDatabase) TableSchema(ctx context.Context, table string) (string, error) {
+	if !slices.Contains(sd.allTables, table) {
+		return "", fmt.Errorf("%w: %s", ErrTableNotFound, table)
+	}
+	info, err := sd.Engine.TableInfo(ctx, table)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(info + "\n" + sd.describe(table)), nil
+}
+
+// describe returns the descriptions of a table and of its columns.
+func (sd *SQLDatabase) describe(table string) string {
+	var b strings.Builder
+	if description := sd.TableDescription(table); description != "" {
+		fmt.Fprintf(&b, "Description of %s: %s\n", table, description)
+	}
+	columns := sd.ColumnDescriptions(table)
+	if len(columns) > 0 {
+		names := make([]string, 0, len(columns))
+		for name := range columns {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fmt.Fprintf(&b, "Columns of %s:\n", table)
+		for _, name := range names {
+			fmt.Fprintf(&b, "- %s: %s\n", name, columns[name])
+		}
+	}
+	return b.String()
+}
+
+// ForeignKeys returns the foreign keys between the tables of the database. It
+// returns none if the engine does not implement ForeignKeyEngine. The foreign
+// keys are loaded once.
+func (sd *SQLDatabase) ForeignKeys(ctx context.Context) ([]ForeignKey, error) {
+	sd.mu.Lock()
+	defer sd.mu.Unlock()
+	if sd.foreignKeys != nil {
+		return sd.foreignKeys, nil
+	}
+	engine, ok := sd.Engine.(ForeignKeyEngine)
+	if !ok {
+		return nil, nil
+	}
+
+	foreignKeys := make([]ForeignKey, 0)
+	for _, table := range sd.allTables {
+		keys, err := engine.ForeignKeys(ctx, table)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if slices.Contains(sd.allTables, key.ReferencedTable) {
+				foreignKeys = append(foreignKeys, key)
+			}
+		}
+	}
+	sd.foreignKeys = foreignKeys
+	return foreignKeys, nil
+}
+
+// RelatedTables returns the tables, followed by the tables they reference and
+// the tables referencing them with foreign keys.
+func (sd *SQLDatabase) RelatedTables(ctx context.Context, tables []string) ([]string, error) {
+	foreignKeys, err := sd.ForeignKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	related := slices.Clone(tables)
+	add := func(table string) {
+		if !slices.Contains(related, table) {
+			related = append(related, table)
+		}
+	}
+	for _, table := range tables {
+		for _, key := range foreignKeys {
+			switch table {
+			case key.Table:
+				add(key.ReferencedTable)
+			case key.ReferencedTable:
+				add(key.Table)
+			}
+		}
+	}
+	return related, nil
+}
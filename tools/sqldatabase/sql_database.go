@@ -2,8 +2,10 @@ package sqldatabase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 )
 
@@ -36,11 +38,27 @@ type Engine interface {
 	Close() error
 }
 
+// ReadOnlyEngine is implemented by the engines able to run queries in a
+// read-only transaction or session, where the functions modifying the
+// database fail too. The queries of a SQLDatabase not allowing writes are run
+// this way, in addition to being checked.
+type ReadOnlyEngine interface {
+	// ReadOnly returns an engine running its queries read-only, and a function
+	// ending its transaction or session. The engine must not be closed.
+	ReadOnly(ctx context.Context) (engine Engine, end func() error, err error)
+}
+
 var (
 	ErrUnknownDialect = fmt.Errorf("unknown dialect")
 
 	ErrTableNotFound = fmt.Errorf("table not found")
 	ErrInvalidResult = fmt.Errorf("invalid result")
+
+	// ErrQueryNotAllowed is returned when a query is rejected, with the reason
+	// of the rejection.
+	ErrQueryNotAllowed = fmt.Errorf("query not allowed")
+	// ErrQueryTimeout is returned when a query does not finish in time.
+	ErrQueryTimeout = fmt.Errorf("query timeout")
 )
 
 // SQLDatabase sql wrapper.
@@ -48,13 +66,33 @@ type SQLDatabase struct {
 	Engine           Engine // The database engine.
 	SampleRowsNumber int    // The number of sample rows to show. 0 means no sample rows.
 	allTables        []string
+	guard            *queryGuard
+
+	tableDescriptions  map[string]string
+	columnDescriptions map[string]map[string]string
+
+	mu          sync.Mutex
+	foreignKeys []ForeignKey // Cached by ForeignKeys.
 }
 
 // NewSQLDatabase creates a new SQLDatabase.
-func NewSQLDatabase(engine Engine, ignoreTables map[string]struct{}) (*SQLDatabase, error) {
+//
+// By default, the queries are guarded: only single SELECT statements are run,
+// in a read-only transaction if the engine is a ReadOnlyEngine, their results
+// are limited to DefaultMaxRows rows, and they time out after
+// DefaultQueryTimeout. The options change these restrictions, and restrict
+// the queries to some tables and columns.
+func NewSQLDatabase(engine Engine, ignoreTables map[string]struct{}, opts ...Option) (*SQLDatabase, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
 	sd := &SQLDatabase{
-		Engine:           engine,
-		SampleRowsNumber: 3, //nolint:gomnd
+		Engine:             engine,
+		SampleRowsNumber:   3, //nolint:gomnd
+		tableDescriptions:  o.tableDescriptions,
+		columnDescriptions: o.columnDescriptions,
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second) //nolint:gomnd
 	defer cancel()
@@ -62,10 +100,17 @@ func NewSQLDatabase(engine Engine, ignoreTables map[string]struct{}) (*SQLDataba
 	if err != nil {
 		return nil, err
 	}
+	sd.guard, err = newQueryGuard(ctx, engine, tbs, o)
+	if err != nil {
+		return nil, err
+	}
 	for _, tb := range tbs {
 		if _, ok := ignoreTables[tb]; ok {
 			continue
 		}
+		if !sd.guard.tableAllowed("", tb) {
+			continue
+		}
 		sd.allTables = append(sd.allTables, tb)
 	}
 
@@ -73,7 +118,11 @@ func NewSQLDatabase(engine Engine, ignoreTables map[string]struct{}) (*SQLDataba
 }
 
 // NewSQLDatabaseWithDSN creates a new SQLDatabase with the data source name.
-func NewSQLDatabaseWithDSN(dialect, dsn string, ignoreTables map[string]struct{}) (*SQLDatabase, error) {
+func NewSQLDatabaseWithDSN(
+	dialect, dsn string,
+	ignoreTables map[string]struct{},
+	opts ...Option,
+) (*SQLDatabase, error) {
 	engineFunc, ok := engines[dialect]
 	if !ok {
 		return nil, ErrUnknownDialect
@@ -82,7 +131,7 @@ func NewSQLDatabaseWithDSN(dialect, dsn string, ignoreTables map[string]struct{}
 	if err != nil {
 		return nil, err
 	}
-	return NewSQLDatabase(engine, ignoreTables)
+	return NewSQLDatabase(engine, ignoreTables, opts...)
 }
 
 // Dialect returns the dialect(e.g. mysql, sqlite, postgre) of the database.
@@ -103,12 +152,24 @@ func (sd *SQLDatabase) TableInfo(ctx context.Context, tables []string) (string,
 	}
 	str := ""
 	for _, tb := range tables {
+		if sd.guard != nil && !sd.guard.tableAllowed("", tb) {
+			return "", fmt.Errorf("%w: %s", ErrTableNotFound, tb)
+		}
+
 		// Get table info
 		info, err := sd.Engine.TableInfo(ctx, tb)
 		if err != nil {
 			return "", err
 		}
 		str += info + "\n\n"
+		if description := sd.describe(tb); description != "" {
+			str += "/*\n" + description + "*/\n\n"
+		}
+		if sd.guard != nil {
+			if cols, ok := sd.guard.allowedColumnsOf(tb); ok {
+				str += fmt.Sprintf("/* Only the columns %s of %s can be queried. */\n\n", strings.Join(cols, ", "), tb)
+			}
+		}
 
 		// Get sample rows
 		if sd.SampleRowsNumber > 0 {
@@ -124,8 +185,10 @@ func (sd *SQLDatabase) TableInfo(ctx context.Context, tables []string) (string,
 }
 
 // Query executes the query and returns the string that contains columns and results.
+// The query is checked and rewritten according to the options of the database,
+// and an ErrQueryNotAllowed error describes why a query is rejected.
 func (sd *SQLDatabase) Query(ctx context.Context, query string) (string, error) {
-	cols, results, err := sd.Engine.Query(ctx, query)
+	cols, results, err := sd.query(ctx, query)
 	if err != nil {
 		return "", err
 	}
@@ -142,8 +205,72 @@ func (sd *SQLDatabase) Close() error {
 	return sd.Engine.Close()
 }
 
+// query checks and executes the query, and returns the columns and results.
+func (sd *SQLDatabase) query(ctx context.Context, query string) ([]string, [][]string, error) {
+	if sd.guard == nil {
+		return sd.Engine.Query(ctx, query)
+	}
+
+	query, err := sd.guard.check(query, sd.guard.maxRows)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, cancel := sd.withTimeout(ctx)
+	defer cancel()
+	engine, end, err := sd.queryEngine(ctx)
+	if err != nil {
+		return nil, nil, sd.queryError(ctx, err)
+	}
+	defer end() //nolint:errcheck
+	cols, results, err := engine.Query(ctx, query)
+	if err != nil {
+		return nil, nil, sd.queryError(ctx, err)
+	}
+	if sd.guard.maxRows > 0 && len(results) > sd.guard.maxRows {
+		results = results[:sd.guard.maxRows]
+	}
+	return cols, results, nil
+}
+
+// queryEngine returns the engine running the checked queries, read-only if
+// writes are not allowed and the engine is a ReadOnlyEngine, and a function
+// ending its transaction or session.
+func (sd *SQLDatabase) queryEngine(ctx context.Context) (Engine, func() error, error) { //nolint:ireturn
+	if e, ok := sd.Engine.(ReadOnlyEngine); ok && sd.guard != nil && !sd.guard.allowWrites {
+		return e.ReadOnly(ctx)
+	}
+	return sd.Engine, func() error { return nil }, nil
+}
+
+// withTimeout returns the context of a query, with the timeout of the query.
+func (sd *SQLDatabase) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if sd.guard == nil || sd.guard.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, sd.guard.queryTimeout)
+}
+
+// queryError returns the error of a query, explaining its timeout.
+func (sd *SQLDatabase) queryError(ctx context.Context, err error) error {
+	if sd.guard != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w: the query did not finish within %s, write a simpler query: %w",
+			ErrQueryTimeout, sd.guard.queryTimeout, err)
+	}
+	return err
+}
+
 func (sd *SQLDatabase) sampleRows(ctx context.Context, table string, rows int) (string, error) {
-	query := fmt.Sprintf("SELECT * FROM %s LIMIT %d", table, rows)
+	columns := "*"
+	if sd.guard != nil {
+		if cols, ok := sd.guard.allowedColumnsOf(table); ok {
+			quoted := make([]string, 0, len(cols))
+			for _, col := range cols {
+				quoted = append(quoted, quoteIdentifier(sd.Dialect(), col))
+			}
+			columns = strings.Join(quoted, ", ")
+		}
+	}
+	query := fmt.Sprintf("SELECT %s FROM %s LIMIT %d", columns, table, rows)
 	result, err := sd.Query(ctx, query)
 	if err != nil {
 		return "", err
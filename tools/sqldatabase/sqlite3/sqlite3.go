@@ -3,6 +3,8 @@ package sqlite3
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 
 	_ "github.com/mattn/go-sqlite3" // sqlite3 driver
 	"github.com/tmc/langchaingo/tools/sqldatabase"
@@ -15,11 +17,18 @@ func init() {
 	sqldatabase.RegisterEngine(EngineName, NewSQLite3)
 }
 
-var _ sqldatabase.Engine = SQLite3{}
+var (
+	_ sqldatabase.Engine           = SQLite3{}
+	_ sqldatabase.ForeignKeyEngine = SQLite3{}
+	_ sqldatabase.RowsEngine       = SQLite3{}
+	_ sqldatabase.ReadOnlyEngine   = SQLite3{}
+)
 
 // SQLite3 is a SQLite3 engine.
 type SQLite3 struct {
 	db *sql.DB
+	// conn is the connection in query only mode running the queries, if any.
+	conn *sql.Conn
 }
 
 // NewSQLite3 creates a new SQLite3 engine.
@@ -41,7 +50,7 @@ func (m SQLite3) Dialect() string {
 }
 
 func (m SQLite3) Query(ctx context.Context, query string, args ...any) ([]string, [][]string, error) {
-	rows, err := m.db.QueryContext(ctx, query, args...)
+	rows, err := m.queryContext(ctx, query, args...)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -70,9 +79,16 @@ func (m SQLite3) Query(ctx context.Context, query string, args ...any) ([]string
 		}
 		results = append(results, row)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
 	return cols, results, nil
 }
 
+func (m SQLite3) QueryRows(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return m.queryContext(ctx, query, args...)
+}
+
 func (m SQLite3) TableNames(ctx context.Context) ([]string, error) {
 	_, result, err := m.Query(ctx, "SELECT name FROM sqlite_master WHERE type='table';")
 	if err != nil {
@@ -100,6 +116,55 @@ func (m SQLite3) TableInfo(ctx context.Context, table string) (string, error) {
 	return result[0][0], nil
 }
 
+func (m SQLite3) ForeignKeys(ctx context.Context, table string) ([]sqldatabase.ForeignKey, error) {
+	_, result, err := m.Query(ctx, `SELECT "from", "table", "to" FROM pragma_foreign_key_list(?);`, table)
+	if err != nil {
+		return nil, err
+	}
+	return foreignKeys(table, result), nil
+}
+
+// ReadOnly returns an engine running its queries on a connection in query only
+// mode, set with PRAGMA query_only and reset by end.
+func (m SQLite3) ReadOnly(ctx context.Context) (sqldatabase.Engine, func() error, error) { //nolint:ireturn
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		return nil, nil, errors.Join(err, conn.Close())
+	}
+	end := func() error {
+		// The connection is discarded rather than reused if its mode can't be
+		// reset, e.g. once the query timed out.
+		if _, err := conn.ExecContext(context.Background(), "PRAGMA query_only = OFF"); err != nil {
+			return errors.Join(err, conn.Raw(func(any) error { return driver.ErrBadConn }), conn.Close())
+		}
+		return conn.Close()
+	}
+	return SQLite3{db: m.db, conn: conn}, end, nil
+}
+
+func (m SQLite3) queryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	if m.conn != nil {
+		return m.conn.QueryContext(ctx, query, args...)
+	}
+	return m.db.QueryContext(ctx, query, args...)
+}
+
 func (m SQLite3) Close() error {
 	return m.db.Close()
 }
+
+func foreignKeys(table string, result [][]string) []sqldatabase.ForeignKey {
+	keys := make([]sqldatabase.ForeignKey, 0, len(result))
+	for _, row := range result {
+		keys = append(keys, sqldatabase.ForeignKey{
+			Table:            table,
+			Column:           row[0],
+			ReferencedTable:  row[1],
+			ReferencedColumn: row[2],
+		})
+	}
+	return keys
+}
@@ -6,12 +6,13 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
 	"github.com/tmc/langchaingo/tools/sqldatabase"
-	_ "github.com/tmc/langchaingo/tools/sqldatabase/sqlite3"
+	"github.com/tmc/langchaingo/tools/sqldatabase/sqlite3"
 )
 
 func Test(t *testing.T) {
@@ -59,3 +60,27 @@ func Test(t *testing.T) {
 		require.NoError(t, err)
 	}
 }
+
+func TestReadOnly(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	engine, err := sqlite3.NewSQLite3(filepath.Join(t.TempDir(), "test.sqlite"))
+	require.NoError(t, err)
+	defer engine.Close()
+	_, _, err = engine.Query(ctx, "CREATE TABLE users (id int)")
+	require.NoError(t, err)
+
+	ro, end, err := engine.(sqldatabase.ReadOnlyEngine).ReadOnly(ctx)
+	require.NoError(t, err)
+	_, _, err = ro.Query(ctx, "INSERT INTO users VALUES (1) RETURNING id")
+	require.ErrorContains(t, err, "readonly database")
+	_, rows, err := ro.Query(ctx, "SELECT count(*) FROM users")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"0"}}, rows)
+	require.NoError(t, end())
+
+	_, rows, err = engine.Query(ctx, "INSERT INTO users VALUES (1) RETURNING id")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"1"}}, rows)
+}
@@ -0,0 +1,22 @@
+// Package tableselector selects the tables of a database relevant to a
+// question, to describe only these tables to a model when the database has too
+// many tables for a prompt.
+//
+// VectorSelector searches the schemas and descriptions of the tables in a
+// vector store, and LLMSelector asks a model to pick the tables from their
+// names and descriptions. Both add the tables related to the selected ones
+// with foreign keys, so that the model can join them.
+//
+// The selectors implement sqldatabase.TableSelector, and are used by
+// chains.SQLDatabaseChain and the tools of the sqltoolkit package:
+//
+//	db, err := sqldatabase.NewSQLDatabaseWithDSN("sqlite3", dsn, nil,
+//		sqldatabase.WithTableDescription("orders", "The orders of the customers."))
+//	...
+//	selector := tableselector.NewVectorSelector(db, store)
+//	if err := selector.Index(ctx); err != nil {
+//		...
+//	}
+//	chain := chains.NewSQLDatabaseChain(llm, 10, db)
+//	chain.TableSelector = selector
+package tableselector
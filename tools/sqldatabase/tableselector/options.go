@@ -0,0 +1,47 @@
+package tableselector
+
+import "github.com/tmc/langchaingo/prompts"
+
+const _defaultMaxTables = 5
+
+type options struct {
+	maxTables     int
+	relatedTables bool
+	prompt        prompts.PromptTemplate
+}
+
+func defaultOptions() options {
+	return options{
+		maxTables:     _defaultMaxTables,
+		relatedTables: true,
+		prompt:        DefaultLLMSelectorPrompt(),
+	}
+}
+
+// Option is a function for configuring a selector.
+type Option func(*options)
+
+// WithMaxTables sets the maximum number of tables selected, before adding the
+// related tables. Defaults to 5.
+func WithMaxTables(maxTables int) Option {
+	return func(opts *options) {
+		opts.maxTables = maxTables
+	}
+}
+
+// WithRelatedTables sets whether the tables referencing or referenced by the
+// selected tables with foreign keys are added to the selection. Defaults to
+// true.
+func WithRelatedTables(relatedTables bool) Option {
+	return func(opts *options) {
+		opts.relatedTables = relatedTables
+	}
+}
+
+// WithPrompt sets the prompt of LLMSelector, with the "tables", the
+// "question" and the "max_tables" variables.
+func WithPrompt(prompt prompts.PromptTemplate) Option {
+	return func(opts *options) {
+		opts.prompt = prompt
+	}
+}
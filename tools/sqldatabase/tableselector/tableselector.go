@@ -0,0 +1,167 @@
+package tableselector
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools/sqldatabase"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+// ErrNoTablesSelected is returned when no table of the database is relevant
+// to a question.
+var ErrNoTablesSelected = errors.New("no tables selected")
+
+// TableMetadataKey is the metadata key of the name of the table of the
+// documents indexed by VectorSelector.
+const TableMetadataKey = "table"
+
+// VectorSelector selects the tables whose schemas and descriptions are the
+// most similar to a question in a vector store.
+type VectorSelector struct {
+	db    *sqldatabase.SQLDatabase
+	store vectorstores.VectorStore
+	opts  options
+}
+
+var _ sqldatabase.TableSelector = &VectorSelector{}
+
+// NewVectorSelector creates a selector searching the tables of the database in
+// the vector store. The tables are added to the store by Index.
+func NewVectorSelector(db *sqldatabase.SQLDatabase, store vectorstores.VectorStore, opts ...Option) *VectorSelector {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &VectorSelector{db: db, store: store, opts: o}
+}
+
+// Index adds a document per table of the database to the vector store: the
+// schema of the table with its descriptions. A persistent store only needs to
+// be indexed when the schema or the descriptions change.
+func (s *VectorSelector) Index(ctx context.Context) error {
+	docs := make([]schema.Document, 0, len(s.db.TableNames()))
+	for _, table := range s.db.TableNames() {
+		tableSchema, err := s.db.TableSchema(ctx, table)
+		if err != nil {
+			return err
+		}
+		docs = append(docs, schema.Document{
+			PageContent: "Table " + table + ":\n" + tableSchema,
+			Metadata:    map[string]any{TableMetadataKey: table},
+		})
+	}
+	_, err := s.store.AddDocuments(ctx, docs)
+	return err
+}
+
+// SelectTables returns the tables the most similar to the question, and their
+// related tables.
+func (s *VectorSelector) SelectTables(ctx context.Context, question string) ([]string, error) {
+	docs, err := s.store.SimilaritySearch(ctx, question, s.opts.maxTables)
+	if err != nil {
+		return nil, err
+	}
+	var tables []string
+	for _, doc := range docs {
+		table, _ := doc.Metadata[TableMetadataKey].(string)
+		tables = append(tables, table)
+	}
+	return s.opts.selection(ctx, s.db, tables)
+}
+
+// LLMSelector asks a model to select the tables relevant to a question, from
+// the names and descriptions of the tables.
+type LLMSelector struct {
+	db   *sqldatabase.SQLDatabase
+	llm  llms.Model
+	opts options
+}
+
+var _ sqldatabase.TableSelector = &LLMSelector{}
+
+// NewLLMSelector creates a selector asking the model to select the tables of
+// the database.
+func NewLLMSelector(db *sqldatabase.SQLDatabase, llm llms.Model, opts ...Option) *LLMSelector {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &LLMSelector{db: db, llm: llm, opts: o}
+}
+
+// SelectTables returns the tables selected by the model, and their related
+// tables.
+func (s *LLMSelector) SelectTables(ctx context.Context, question string) ([]string, error) {
+	var tables strings.Builder
+	for _, table := range s.db.TableNames() {
+		tables.WriteString("- " + table)
+		if description := s.db.TableDescription(table); description != "" {
+			tables.WriteString(": " + description)
+		}
+		tables.WriteString("\n")
+	}
+	prompt, err := s.opts.prompt.Format(map[string]any{
+		"tables":     tables.String(),
+		"question":   question,
+		"max_tables": s.opts.maxTables,
+	})
+	if err != nil {
+		return nil, err
+	}
+	answer, err := llms.GenerateFromSinglePrompt(ctx, s.llm, prompt)
+	if err != nil {
+		return nil, err
+	}
+
+	selected := strings.FieldsFunc(answer, func(r rune) bool {
+		return r == ',' || r == '\n'
+	})
+	for i, table := range selected {
+		selected[i] = strings.Trim(strings.TrimSpace(table), "-*`\"'[] ")
+	}
+	return s.opts.selection(ctx, s.db, selected)
+}
+
+//nolint:lll
+const _llmSelectorTemplate = `Here are the tables of a database:
+{{.tables}}
+Select the tables needed to answer the following question with a SQL query, at most {{.max_tables}} tables.
+Answer with the names of the tables only, separated by commas.
+
+Question: {{.question}}
+Tables: `
+
+// DefaultLLMSelectorPrompt returns the default prompt of LLMSelector.
+func DefaultLLMSelectorPrompt() prompts.PromptTemplate {
+	return prompts.NewPromptTemplate(_llmSelectorTemplate, []string{"tables", "question", "max_tables"})
+}
+
+// selection returns the tables of the database among the selected ones, at
+// most maxTables, followed by their related tables.
+func (o options) selection(ctx context.Context, db *sqldatabase.SQLDatabase, selected []string) ([]string, error) {
+	var tables []string
+	for _, name := range selected {
+		for _, table := range db.TableNames() {
+			if strings.EqualFold(name, table) && !slices.Contains(tables, table) {
+				tables = append(tables, table)
+				break
+			}
+		}
+		if o.maxTables > 0 && len(tables) == o.maxTables {
+			break
+		}
+	}
+	if len(tables) == 0 {
+		return nil, ErrNoTablesSelected
+	}
+	if !o.relatedTables {
+		return tables, nil
+	}
+	return db.RelatedTables(ctx, tables)
+}
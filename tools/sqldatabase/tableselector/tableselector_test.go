@@ -0,0 +1,110 @@
+package tableselector_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/llms/fake"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools/sqldatabase"
+	_ "github.com/tmc/langchaingo/tools/sqldatabase/sqlite3"
+	"github.com/tmc/langchaingo/tools/sqldatabase/tableselector"
+	"github.com/tmc/langchaingo/vectorstores"
+)
+
+func newTestDB(t *testing.T) *sqldatabase.SQLDatabase {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "test.sqlite")
+	conn, err := sql.Open("sqlite3", dsn)
+	require.NoError(t, err)
+	_, err = conn.Exec(`CREATE TABLE customers (id int PRIMARY KEY, name text);
+		CREATE TABLE products (id int PRIMARY KEY, title text);
+		CREATE TABLE orders (id int, customer_id int REFERENCES customers(id), product_id int REFERENCES products(id));
+		CREATE TABLE logs (id int, message text);`)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	db, err := sqldatabase.NewSQLDatabaseWithDSN("sqlite3", dsn, nil,
+		sqldatabase.WithTableDescription("customers", "The people buying products."),
+		sqldatabase.WithColumnDescription("customers", "name", "The full name."))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// keywordStore is a vector store returning the documents containing the words
+// of the query.
+type keywordStore struct {
+	docs []schema.Document
+}
+
+func (s *keywordStore) AddDocuments(_ context.Context, docs []schema.Document, _ ...vectorstores.Option) ([]string, error) { //nolint:lll
+	s.docs = append(s.docs, docs...)
+	return nil, nil
+}
+
+func (s *keywordStore) SimilaritySearch(_ context.Context, query string, n int, _ ...vectorstores.Option) ([]schema.Document, error) { //nolint:lll
+	var res []schema.Document
+	for _, doc := range s.docs {
+		for _, word := range strings.Fields(query) {
+			if len(res) < n && strings.Contains(doc.PageContent, word) {
+				res = append(res, doc)
+				break
+			}
+		}
+	}
+	return res, nil
+}
+
+func TestVectorSelector(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := newTestDB(t)
+	store := &keywordStore{}
+	selector := tableselector.NewVectorSelector(db, store, tableselector.WithMaxTables(1))
+	require.NoError(t, selector.Index(ctx))
+	require.Len(t, store.docs, 4)
+	require.Contains(t, store.docs[0].PageContent, "Description of customers: The people buying products.")
+	require.Contains(t, store.docs[0].PageContent, "- name: The full name.")
+
+	// The customers table is referenced by the orders table.
+	tables, err := selector.SelectTables(ctx, "Which people are there?")
+	require.NoError(t, err)
+	require.Equal(t, []string{"customers", "orders"}, tables)
+
+	// The orders table references the customers and products tables.
+	tables, err = selector.SelectTables(ctx, "customer_id")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"orders", "customers", "products"}, tables)
+
+	_, err = selector.SelectTables(ctx, "What is the weather?")
+	require.ErrorIs(t, err, tableselector.ErrNoTablesSelected)
+}
+
+func TestLLMSelector(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	llm := fake.NewFakeLLM([]string{"`Logs`, unknown"})
+	tables, err := tableselector.NewLLMSelector(db, llm).SelectTables(ctx, "What happened?")
+	require.NoError(t, err)
+	require.Equal(t, []string{"logs"}, tables)
+
+	llm = fake.NewFakeLLM([]string{"products\ncustomers"})
+	tables, err = tableselector.NewLLMSelector(db, llm, tableselector.WithRelatedTables(false)).
+		SelectTables(ctx, "Who bought what?")
+	require.NoError(t, err)
+	require.Equal(t, []string{"products", "customers"}, tables)
+
+	info, err := db.TableInfo(ctx, tables)
+	require.NoError(t, err)
+	require.Contains(t, info, "Description of customers: The people buying products.")
+}
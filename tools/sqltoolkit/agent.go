@@ -0,0 +1,44 @@
+package sqltoolkit
+
+import (
+	"fmt"
+
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/llms"
+)
+
+const _defaultAgentMaxIterations = 15
+
+//nolint:lll
+const _agentPrefix = `You are an agent designed to interact with a SQL database.
+Given an input question, create a syntactically correct %s query to run, then look at the results of the query and return the answer.
+Unless the user specifies a specific number of examples they wish to obtain, always limit your query to at most %d results.
+You can order the results by a relevant column to return the most interesting examples in the database.
+Never query for all the columns from a specific table, only ask for the relevant columns given the question.
+You MUST double check your query before running it. If you get an error while running a query, rewrite the query and try again.
+DO NOT make any DML statements (INSERT, UPDATE, DELETE, DROP etc.) to the database.
+If the question does not seem related to the database, just return "I don't know" as the answer.
+
+Today is {{.today}}.
+You have access to the following tools for interacting with the database:
+
+{{.tool_descriptions}}`
+
+// NewAgent creates an executor answering questions about the database of the
+// toolkit. The agent lists and describes the tables it needs, checks its
+// queries and runs them, and rewrites the queries failing: the errors of the
+// tools are given back to the agent. The options are applied after the
+// defaults of the agent, e.g. to change its prompt or its max iterations.
+func NewAgent(llm llms.Model, toolkit *Toolkit, opts ...agents.Option) *agents.Executor {
+	prefix := fmt.Sprintf(_agentPrefix, toolkit.DB.Dialect(), toolkit.TopK)
+	opts = append([]agents.Option{
+		agents.WithPromptPrefix(prefix),
+		agents.WithMaxIterations(_defaultAgentMaxIterations),
+		agents.WithToolErrorHandler(agents.NewToolErrorHandler(func(_ string, err error) string {
+			return "Error: " + err.Error()
+		})),
+	}, opts...)
+
+	agent := agents.NewOneShotAgent(llm, toolkit.Tools(), opts...)
+	return agents.NewExecutor(agent, opts...)
+}
@@ -0,0 +1,6 @@
+// Package sqltoolkit contains the tools of an agent querying a SQL database
+// through sqldatabase.SQLDatabase: listing the tables, describing them with
+// sample rows, checking a query with an LLM and running it. NewAgent creates
+// an agent using them, which rewrites its queries when they fail, unlike the
+// single-shot chains.SQLDatabaseChain.
+package sqltoolkit
@@ -0,0 +1,44 @@
+package sqltoolkit
+
+import (
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/tools/sqldatabase"
+)
+
+const _defaultTopK = 10
+
+type options struct {
+	topK             int
+	tableSelector    sqldatabase.TableSelector
+	callbacksHandler callbacks.Handler
+}
+
+func defaultOptions() options {
+	return options{topK: _defaultTopK}
+}
+
+// Option is a function for configuring a Toolkit.
+type Option func(*options)
+
+// WithTopK sets the number of results the agent is asked to limit its queries
+// to. Defaults to 10.
+func WithTopK(topK int) Option {
+	return func(opts *options) {
+		opts.topK = topK
+	}
+}
+
+// WithTableSelector sets the selector of the tables relevant to the question of
+// the agent, for databases with too many tables to list them all.
+func WithTableSelector(selector sqldatabase.TableSelector) Option {
+	return func(opts *options) {
+		opts.tableSelector = selector
+	}
+}
+
+// WithCallbacksHandler sets the callbacks handler of the tools.
+func WithCallbacksHandler(handler callbacks.Handler) Option {
+	return func(opts *options) {
+		opts.callbacksHandler = handler
+	}
+}
@@ -0,0 +1,281 @@
+package sqltoolkit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tmc/langchaingo/callbacks"
+	"github.com/tmc/langchaingo/llms"
+	"github.com/tmc/langchaingo/prompts"
+	"github.com/tmc/langchaingo/tools"
+	"github.com/tmc/langchaingo/tools/sqldatabase"
+)
+
+// Names of the tools.
+const (
+	ListTablesToolName   = "sql_db_list_tables"
+	SchemaToolName       = "sql_db_schema"
+	QueryCheckerToolName = "sql_db_query_checker"
+	QueryToolName        = "sql_db_query"
+)
+
+// ErrUnknownTables is returned when asking for the schema of tables not in the
+// database.
+var ErrUnknownTables = errors.New("unknown tables")
+
+// Toolkit is the set of tools of an agent querying a SQL database.
+type Toolkit struct {
+	// DB is the database queried.
+	DB *sqldatabase.SQLDatabase
+	// LLM reviews the queries before they are run. Without an LLM, the
+	// toolkit has no query checker.
+	LLM llms.Model
+	// TopK is the number of results the agent is asked to limit its queries
+	// to, unless the question asks for a specific number.
+	TopK int
+	// TableSelector, if set, selects the tables relevant to the question of
+	// the agent, instead of listing all the tables.
+	TableSelector sqldatabase.TableSelector
+	// CallbacksHandler is the callbacks handler of the tools.
+	CallbacksHandler callbacks.Handler
+}
+
+// New creates a toolkit for the database, with the LLM reviewing the queries.
+func New(db *sqldatabase.SQLDatabase, llm llms.Model, opts ...Option) *Toolkit {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return &Toolkit{
+		DB:               db,
+		LLM:              llm,
+		TopK:             o.topK,
+		TableSelector:    o.tableSelector,
+		CallbacksHandler: o.callbacksHandler,
+	}
+}
+
+// Tools returns the tools of the toolkit: listing the tables, describing the
+// tables, checking a query if the toolkit has an LLM, and running a query.
+func (t *Toolkit) Tools() []tools.Tool {
+	res := []tools.Tool{
+		ListTables{DB: t.DB, Selector: t.TableSelector, CallbacksHandler: t.CallbacksHandler},
+		Schema{DB: t.DB, CallbacksHandler: t.CallbacksHandler},
+	}
+	if t.LLM != nil {
+		res = append(res, QueryChecker{
+			DB:               t.DB,
+			LLM:              t.LLM,
+			Prompt:           DefaultQueryCheckerPrompt(),
+			CallbacksHandler: t.CallbacksHandler,
+		})
+	}
+	return append(res, Query{DB: t.DB, CallbacksHandler: t.CallbacksHandler})
+}
+
+// ListTables is a tool listing the tables of a database, or the tables
+// relevant to a question if it has a selector.
+type ListTables struct {
+	DB *sqldatabase.SQLDatabase
+	// Selector, if set, selects the tables relevant to the input.
+	Selector         sqldatabase.TableSelector
+	CallbacksHandler callbacks.Handler
+}
+
+var _ tools.Tool = ListTables{}
+
+func (ListTables) Name() string { return ListTablesToolName }
+
+func (t ListTables) Description() string {
+	if t.Selector != nil {
+		return "Input is the question to answer, output is a comma-separated list of the tables " +
+			"of the database relevant to the question."
+	}
+	return "Input is an empty string, output is a comma-separated list of the tables in the database."
+}
+
+// Call returns the names of the tables, or the names of the tables relevant to
+// the input if the tool has a selector.
+func (t ListTables) Call(ctx context.Context, input string) (string, error) {
+	return call(ctx, t.CallbacksHandler, input, func() (string, error) {
+		if t.Selector == nil {
+			return strings.Join(t.DB.TableNames(), ", "), nil
+		}
+		tables, err := t.Selector.SelectTables(ctx, input)
+		if err != nil {
+			return "", err
+		}
+		return strings.Join(tables, ", "), nil
+	})
+}
+
+// Schema is a tool describing tables of a database, with sample rows.
+type Schema struct {
+	DB               *sqldatabase.SQLDatabase
+	CallbacksHandler callbacks.Handler
+}
+
+var _ tools.Tool = Schema{}
+
+func (Schema) Name() string { return SchemaToolName }
+
+func (Schema) Description() string {
+	return "Input is a comma-separated list of tables, output is the schema and sample rows of these tables. " +
+		"Be sure that the tables exist by calling " + ListTablesToolName + " first! " +
+		"Example input: table1, table2, table3"
+}
+
+// Call returns the schemas of the tables of the input.
+func (t Schema) Call(ctx context.Context, input string) (string, error) {
+	return call(ctx, t.CallbacksHandler, input, func() (string, error) {
+		tables := splitTables(input)
+		if len(tables) == 0 {
+			return "", fmt.Errorf("%w: no tables given, the tables are: %s",
+				ErrUnknownTables, strings.Join(t.DB.TableNames(), ", "))
+		}
+		var unknown []string
+		for _, table := range tables {
+			if !slices.Contains(t.DB.TableNames(), table) {
+				unknown = append(unknown, table)
+			}
+		}
+		if len(unknown) > 0 {
+			return "", fmt.Errorf("%w: %s, the tables are: %s", ErrUnknownTables,
+				strings.Join(unknown, ", "), strings.Join(t.DB.TableNames(), ", "))
+		}
+		return t.DB.TableInfo(ctx, tables)
+	})
+}
+
+// splitTables returns the table names of a comma-separated list, possibly
+// quoted by the model.
+func splitTables(input string) []string {
+	var tables []string
+	for _, table := range strings.Split(input, ",") {
+		if table = strings.Trim(strings.TrimSpace(table), "`\"'[]"); table != "" {
+			tables = append(tables, table)
+		}
+	}
+	return tables
+}
+
+// Query is a tool running a query on a database.
+type Query struct {
+	DB               *sqldatabase.SQLDatabase
+	CallbacksHandler callbacks.Handler
+}
+
+var _ tools.Tool = Query{}
+
+func (Query) Name() string { return QueryToolName }
+
+func (Query) Description() string {
+	return "Input is a detailed and correct SQL query, output is a result from the database. " +
+		"If the query is not correct, an error message is returned: rewrite the query, check it, and try again. " +
+		"If there is an unknown column error, use " + SchemaToolName + " to get the correct table columns."
+}
+
+// Call runs the query of the input and returns the result.
+func (t Query) Call(ctx context.Context, input string) (string, error) {
+	return call(ctx, t.CallbacksHandler, input, func() (string, error) {
+		return t.DB.Query(ctx, cleanQuery(input))
+	})
+}
+
+// cleanQuery removes the markdown code fences models often put around
+// queries.
+func cleanQuery(query string) string {
+	query = strings.TrimSpace(query)
+	query = strings.TrimPrefix(query, "```sql")
+	query = strings.TrimPrefix(query, "```")
+	query = strings.TrimSuffix(query, "```")
+	return strings.TrimSpace(query)
+}
+
+// QueryChecker is a tool asking an LLM to review a query before it is run.
+type QueryChecker struct {
+	DB  *sqldatabase.SQLDatabase
+	LLM llms.Model
+	// Prompt is the prompt of the review, with the "query" and the "dialect"
+	// of the database.
+	Prompt           prompts.PromptTemplate
+	CallbacksHandler callbacks.Handler
+}
+
+var _ tools.Tool = QueryChecker{}
+
+func (QueryChecker) Name() string { return QueryCheckerToolName }
+
+func (QueryChecker) Description() string {
+	return "Use this tool to double check if your query is correct before running it with " + QueryToolName + ". " +
+		"Always use this tool before running a query!"
+}
+
+// Call returns the query of the input, corrected by the LLM if needed.
+func (t QueryChecker) Call(ctx context.Context, input string) (string, error) {
+	return call(ctx, t.CallbacksHandler, input, func() (string, error) {
+		prompt, err := t.Prompt.Format(map[string]any{
+			"query":   cleanQuery(input),
+			"dialect": t.DB.Dialect(),
+		})
+		if err != nil {
+			return "", err
+		}
+		checked, err := llms.GenerateFromSinglePrompt(ctx, t.LLM, prompt)
+		if err != nil {
+			return "", err
+		}
+		return cleanQuery(checked), nil
+	})
+}
+
+//nolint:lll
+const _queryCheckerTemplate = `{{.query}}
+Double check the {{.dialect}} query above for common mistakes, including:
+- Using NOT IN with NULL values
+- Using UNION when UNION ALL should have been used
+- Using BETWEEN for exclusive ranges
+- Data type mismatch in predicates
+- Properly quoting identifiers
+- Using the correct number of arguments for functions
+- Casting to the correct data type
+- Using the proper columns for joins
+
+If there are any of the above mistakes, rewrite the query. If there are no mistakes, just reproduce the original query.
+
+Output the final SQL query only.
+
+SQL Query: `
+
+// DefaultQueryCheckerPrompt returns the default prompt of QueryChecker.
+func DefaultQueryCheckerPrompt() prompts.PromptTemplate {
+	return prompts.NewPromptTemplate(_queryCheckerTemplate, []string{"query", "dialect"})
+}
+
+// call runs a tool function, calling the callbacks handler.
+func call(
+	ctx context.Context,
+	handler callbacks.Handler,
+	input string,
+	fn func() (string, error),
+) (string, error) {
+	if handler != nil {
+		handler.HandleToolStart(ctx, input)
+	}
+
+	result, err := fn()
+	if err != nil {
+		if handler != nil {
+			handler.HandleToolError(ctx, err)
+		}
+		return "", err
+	}
+
+	if handler != nil {
+		handler.HandleToolEnd(ctx, result)
+	}
+	return result, nil
+}
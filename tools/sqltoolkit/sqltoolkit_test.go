@@ -0,0 +1,110 @@
+package sqltoolkit_test
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/agents"
+	"github.com/tmc/langchaingo/chains"
+	"github.com/tmc/langchaingo/llms/fake"
+	"github.com/tmc/langchaingo/schema"
+	"github.com/tmc/langchaingo/tools/sqldatabase"
+	_ "github.com/tmc/langchaingo/tools/sqldatabase/sqlite3"
+	"github.com/tmc/langchaingo/tools/sqltoolkit"
+)
+
+func newTestDB(t *testing.T) *sqldatabase.SQLDatabase {
+	t.Helper()
+
+	dsn := filepath.Join(t.TempDir(), "test.sqlite")
+	conn, err := sql.Open("sqlite3", dsn)
+	require.NoError(t, err)
+	_, err = conn.Exec(`CREATE TABLE users (id int, name text);
+		INSERT INTO users VALUES (1, 'Linus'), (2, 'Ada');
+		CREATE TABLE orders (id int, user_id int, total int);`)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	db, err := sqldatabase.NewSQLDatabaseWithDSN("sqlite3", dsn, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+type staticSelector []string
+
+func (s staticSelector) SelectTables(context.Context, string) ([]string, error) {
+	return s, nil
+}
+
+func TestTools(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db := newTestDB(t)
+
+	out, err := sqltoolkit.ListTables{DB: db}.Call(ctx, "")
+	require.NoError(t, err)
+	require.Equal(t, "users, orders", out)
+
+	out, err = sqltoolkit.ListTables{DB: db, Selector: staticSelector{"orders"}}.Call(ctx, "What was bought?")
+	require.NoError(t, err)
+	require.Equal(t, "orders", out)
+
+	out, err = sqltoolkit.Schema{DB: db}.Call(ctx, "`users`")
+	require.NoError(t, err)
+	require.Contains(t, out, "CREATE TABLE users")
+	require.Contains(t, out, "Linus")
+
+	_, err = sqltoolkit.Schema{DB: db}.Call(ctx, "users, customers")
+	require.ErrorIs(t, err, sqltoolkit.ErrUnknownTables)
+	require.ErrorContains(t, err, "customers, the tables are: users, orders")
+
+	out, err = sqltoolkit.Query{DB: db}.Call(ctx, "```sql\nSELECT name FROM users WHERE id = 2\n```")
+	require.NoError(t, err)
+	require.Equal(t, "name\nAda\n", out)
+
+	checker := sqltoolkit.QueryChecker{
+		DB:     db,
+		LLM:    fake.NewFakeLLM([]string{"```sql\nSELECT name FROM users\n```"}),
+		Prompt: sqltoolkit.DefaultQueryCheckerPrompt(),
+	}
+	out, err = checker.Call(ctx, "SELECT name FROM users")
+	require.NoError(t, err)
+	require.Equal(t, "SELECT name FROM users", out)
+
+	require.Len(t, sqltoolkit.New(db, nil).Tools(), 3)
+}
+
+func TestAgent(t *testing.T) {
+	t.Parallel()
+
+	db := newTestDB(t)
+	llm := fake.NewFakeLLM([]string{
+		"Thought: I should look at the tables.\nAction: sql_db_list_tables\nAction Input: none",
+		"Thought: I should look at the users.\nAction: sql_db_schema\nAction Input: users",
+		"Thought: I should check my query.\nAction: sql_db_query_checker\nAction Input: SELECT nam FROM users",
+		// The review of the query checker.
+		"SELECT nam FROM users",
+		"Thought: I can run my query.\nAction: sql_db_query\nAction Input: SELECT nam FROM users",
+		"Thought: The column is name.\nAction: sql_db_query\nAction Input: SELECT name FROM users ORDER BY name",
+		"Thought: I now know the final answer.\nFinal Answer: Ada and Linus",
+	})
+	executor := sqltoolkit.NewAgent(llm, sqltoolkit.New(db, llm), agents.WithReturnIntermediateSteps())
+
+	outputs, err := chains.Call(context.Background(), executor, map[string]any{"input": "Who are the users?"})
+	require.NoError(t, err)
+	require.Equal(t, "Ada and Linus", strings.TrimSpace(outputs["output"].(string))) //nolint:forcetypeassert
+
+	steps, ok := outputs["intermediateSteps"].([]schema.AgentStep)
+	require.True(t, ok)
+	require.Len(t, steps, 5)
+	require.Equal(t, "users, orders", steps[0].Observation)
+	require.Equal(t, "SELECT nam FROM users", steps[2].Observation)
+	require.Contains(t, steps[3].Observation, "Error: no such column: nam")
+	require.Equal(t, "name\nAda\nLinus\n", steps[4].Observation)
+}
@@ -0,0 +1,154 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/tmc/langchaingo/jsonschema"
+)
+
+// ErrInvalidArguments is returned when a structured tool is called with
+// arguments that do not match its schema.
+var ErrInvalidArguments = errors.New("invalid tool arguments")
+
+// StructuredTool is a tool taking arguments described by a JSON schema. The
+// input given to Call is the JSON object of the arguments.
+type StructuredTool interface {
+	Tool
+	// Parameters returns the JSON schema of the arguments.
+	Parameters() jsonschema.Definition
+}
+
+// Typed is a StructuredTool calling a function with its arguments decoded
+// into a Go value. The schema of the arguments is generated from the type of
+// the value, see jsonschema.GenerateSchemaForType.
+type Typed[T any] struct {
+	name        string
+	description string
+	fn          func(ctx context.Context, args T) (string, error)
+	schema      jsonschema.Definition
+}
+
+var _ StructuredTool = &Typed[struct{}]{}
+
+// NewTyped creates a new Typed tool. An error is returned if no JSON schema can
+// be generated for T.
+func NewTyped[T any](
+	name, description string,
+	fn func(ctx context.Context, args T) (string, error),
+) (*Typed[T], error) {
+	var zero T
+	schema, err := jsonschema.GenerateSchemaForType(zero)
+	if err != nil {
+		return nil, fmt.Errorf("tool %s: %w", name, err)
+	}
+	if schema.Type != jsonschema.Object {
+		return nil, fmt.Errorf("tool %s: %w: arguments must be a struct or a map, got %T",
+			name, jsonschema.ErrUnsupportedType, zero)
+	}
+
+	return &Typed[T]{
+		name:        name,
+		description: description,
+		fn:          fn,
+		schema:      *schema,
+	}, nil
+}
+
+// Name returns the name of the tool.
+func (t *Typed[T]) Name() string {
+	return t.name
+}
+
+// Description returns the description of the tool.
+func (t *Typed[T]) Description() string {
+	return t.description
+}
+
+// Parameters returns the JSON schema of the arguments.
+func (t *Typed[T]) Parameters() jsonschema.Definition {
+	return t.schema
+}
+
+// Call validates and decodes the JSON arguments, and calls the function of the
+// tool with them.
+func (t *Typed[T]) Call(ctx context.Context, input string) (string, error) {
+	var args T
+	if err := jsonschema.VerifySchemaAndUnmarshal(t.schema, []byte(input), &args); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrInvalidArguments, err)
+	}
+	return t.fn(ctx, args)
+}
+
+// InputArgument is the name of the single string argument a plain Tool is
+// given when used as a StructuredTool.
+const InputArgument = "__arg1"
+
+// Parameters returns the JSON schema of the arguments of a tool. A plain Tool
+// takes a single string argument named InputArgument.
+func Parameters(tool Tool) jsonschema.Definition {
+	if st, ok := tool.(StructuredTool); ok {
+		return st.Parameters()
+	}
+	return jsonschema.Definition{
+		Type: jsonschema.Object,
+		Properties: map[string]jsonschema.Definition{
+			InputArgument: {Type: jsonschema.String},
+		},
+		Required: []string{InputArgument},
+	}
+}
+
+// InputFromArguments returns the input to give to the Call method of a tool
+// from the JSON arguments of a tool call. Structured tools take the arguments
+// as they are, and plain tools take the value of the InputArgument argument,
+// falling back to the raw arguments.
+func InputFromArguments(tool Tool, arguments string) string {
+	if _, ok := tool.(StructuredTool); ok {
+		return arguments
+	}
+	var args map[string]any
+	if err := json.Unmarshal([]byte(arguments), &args); err != nil {
+		return arguments
+	}
+	if input, ok := args[InputArgument].(string); ok {
+		return input
+	}
+	return arguments
+}
+
+// ArgumentsFromInput is the inverse of InputFromArguments: it returns the JSON
+// arguments of a tool call from the input given to a tool.
+func ArgumentsFromInput(tool Tool, input string) string {
+	if _, ok := tool.(StructuredTool); ok {
+		return input
+	}
+	arguments, err := json.Marshal(map[string]string{InputArgument: input})
+	if err != nil {
+		return input
+	}
+	return string(arguments)
+}
+
+// DescriptionWithSchema returns the description of a tool, followed for a
+// structured tool by the JSON schema its input must match. It is meant for
+// agents describing tools in the text of their prompt.
+func DescriptionWithSchema(tool Tool) string {
+	st, ok := tool.(StructuredTool)
+	if !ok {
+		return tool.Description()
+	}
+	schema, err := json.Marshal(st.Parameters())
+	if err != nil {
+		return tool.Description()
+	}
+
+	var sb strings.Builder
+	sb.WriteString(tool.Description())
+	sb.WriteString(" The input must be a JSON object matching this JSON schema: ")
+	sb.Write(schema)
+	return sb.String()
+}
@@ -0,0 +1,67 @@
+package tools_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/jsonschema"
+	"github.com/tmc/langchaingo/tools"
+)
+
+type weatherArgs struct {
+	City string `json:"city" description:"The city to get the weather for."`
+	Days int    `json:"days,omitempty"`
+}
+
+func newWeatherTool(t *testing.T) *tools.Typed[weatherArgs] {
+	t.Helper()
+
+	tool, err := tools.NewTyped("weather", "Gets the weather forecast.",
+		func(_ context.Context, args weatherArgs) (string, error) {
+			return fmt.Sprintf("%d days of sun in %s", args.Days, args.City), nil
+		})
+	require.NoError(t, err)
+	return tool
+}
+
+func TestTyped(t *testing.T) {
+	t.Parallel()
+
+	tool := newWeatherTool(t)
+	assert.Equal(t, "weather", tool.Name())
+	assert.Equal(t, []string{"city"}, tool.Parameters().Required)
+	assert.Equal(t, jsonschema.Integer, tool.Parameters().Properties["days"].Type)
+
+	result, err := tool.Call(context.Background(), `{"city":"Paris","days":3}`)
+	require.NoError(t, err)
+	assert.Equal(t, "3 days of sun in Paris", result)
+
+	_, err = tool.Call(context.Background(), `{"days":3}`)
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+	_, err = tool.Call(context.Background(), `Paris`)
+	require.ErrorIs(t, err, tools.ErrInvalidArguments)
+
+	_, err = tools.NewTyped("bad", "", func(context.Context, string) (string, error) { return "", nil })
+	require.ErrorIs(t, err, jsonschema.ErrUnsupportedType)
+}
+
+func TestStringToolAdapters(t *testing.T) {
+	t.Parallel()
+
+	calculator := tools.Calculator{}
+	params := tools.Parameters(calculator)
+	assert.Equal(t, []string{tools.InputArgument}, params.Required)
+
+	assert.Equal(t, "1+1", tools.InputFromArguments(calculator, `{"__arg1":"1+1"}`))
+	assert.Equal(t, "1+1", tools.InputFromArguments(calculator, "1+1"))
+	assert.Equal(t, `{"__arg1":"1+1"}`, tools.ArgumentsFromInput(calculator, "1+1"))
+	assert.Equal(t, calculator.Description(), tools.DescriptionWithSchema(calculator))
+
+	weather := newWeatherTool(t)
+	assert.Equal(t, `{"city":"Paris"}`, tools.InputFromArguments(weather, `{"city":"Paris"}`))
+	assert.Equal(t, `{"city":"Paris"}`, tools.ArgumentsFromInput(weather, `{"city":"Paris"}`))
+	assert.Contains(t, tools.DescriptionWithSchema(weather), `"required":["city"]`)
+}
@@ -0,0 +1,119 @@
+package vectorstores
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/schema"
+)
+
+// Metadata keys used to add image documents to vector stores. A document with
+// one of the image keys in its metadata is embedded as an image instead of as
+// text, which requires an embeddings.MultimodalEmbedder. The page content of
+// an image document is stored as is, and can be used as a caption.
+const (
+	// ImageURLMetadataKey is the metadata key of the image URL.
+	ImageURLMetadataKey = "image_url"
+	// ImageDataMetadataKey is the metadata key of the image data, as []byte or
+	// as a base64 encoded string. Some vector stores cannot store []byte
+	// metadata, prefer a base64 string or an URL with those.
+	ImageDataMetadataKey = "image_data"
+	// ImageMIMETypeMetadataKey is the metadata key of the image MIME type.
+	ImageMIMETypeMetadataKey = "image_mime_type"
+)
+
+// ErrImagesNotSupported is returned when adding image documents with an
+// embedder that cannot embed images.
+var ErrImagesNotSupported = errors.New("embedder does not support images")
+
+// ImageFromDocument returns the image described by the metadata of a
+// document, and whether the document is an image document.
+func ImageFromDocument(doc schema.Document) (embeddings.Image, bool, error) {
+	var image embeddings.Image
+
+	switch data := doc.Metadata[ImageDataMetadataKey].(type) {
+	case nil:
+	case []byte:
+		image.Data = data
+	case string:
+		decoded, err := base64.StdEncoding.DecodeString(data)
+		if err != nil {
+			return image, true, fmt.Errorf("decoding %s: %w", ImageDataMetadataKey, err)
+		}
+		image.Data = decoded
+	default:
+		return image, true, fmt.Errorf("%s must be []byte or string, got %T", ImageDataMetadataKey, data)
+	}
+
+	image.URL, _ = doc.Metadata[ImageURLMetadataKey].(string)
+	image.MIMEType, _ = doc.Metadata[ImageMIMETypeMetadataKey].(string)
+
+	if len(image.Data) == 0 && image.URL == "" {
+		return image, false, nil
+	}
+	return image, true, nil
+}
+
+// EmbedDocuments creates one vector embedding for each document. Text
+// documents are embedded from their page content, and image documents (see
+// ImageURLMetadataKey) from their image, in which case the embedder must be
+// an embeddings.MultimodalEmbedder.
+func EmbedDocuments(ctx context.Context, embedder embeddings.Embedder, docs []schema.Document) ([][]float32, error) {
+	var (
+		texts        []string
+		textIndices  []int
+		images       []embeddings.Image
+		imageIndices []int
+	)
+
+	for i, doc := range docs {
+		image, ok, err := ImageFromDocument(doc)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			images = append(images, image)
+			imageIndices = append(imageIndices, i)
+			continue
+		}
+		texts = append(texts, doc.PageContent)
+		textIndices = append(textIndices, i)
+	}
+
+	vectors := make([][]float32, len(docs))
+
+	if len(texts) > 0 {
+		textVectors, err := embedder.EmbedDocuments(ctx, texts)
+		if err != nil {
+			return nil, err
+		}
+		if len(textVectors) != len(texts) {
+			return nil, fmt.Errorf("embedder returned %d vectors for %d texts", len(textVectors), len(texts))
+		}
+		for i, idx := range textIndices {
+			vectors[idx] = textVectors[i]
+		}
+	}
+
+	if len(images) > 0 {
+		multimodal, ok := embedder.(embeddings.MultimodalEmbedder)
+		if !ok {
+			return nil, ErrImagesNotSupported
+		}
+		imageVectors, err := multimodal.EmbedImages(ctx, images)
+		if err != nil {
+			return nil, err
+		}
+		if len(imageVectors) != len(images) {
+			return nil, fmt.Errorf("embedder returned %d vectors for %d images", len(imageVectors), len(images))
+		}
+		for i, idx := range imageIndices {
+			vectors[idx] = imageVectors[i]
+		}
+	}
+
+	return vectors, nil
+}
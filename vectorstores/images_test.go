@@ -0,0 +1,56 @@
+package vectorstores
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/schema"
+)
+
+type imageClient struct{}
+
+func (imageClient) CreateMultimodalEmbedding(_ context.Context, inputs []embeddings.MultimodalInput) ([][]float32, error) { //nolint:lll
+	emb := make([][]float32, len(inputs))
+	for i, input := range inputs {
+		if input.Image != nil {
+			emb[i] = []float32{0, float32(len(input.Image.Data))}
+		} else {
+			emb[i] = []float32{1, 0}
+		}
+	}
+	return emb, nil
+}
+
+func TestEmbedDocuments(t *testing.T) {
+	t.Parallel()
+
+	docs := []schema.Document{
+		{PageContent: "a cat", Metadata: map[string]any{ImageDataMetadataKey: []byte("cat")}},
+		{PageContent: "some text"},
+		{PageContent: "a dog", Metadata: map[string]any{ImageDataMetadataKey: "ZG9nZ28="}},
+		{PageContent: "a bird", Metadata: map[string]any{ImageURLMetadataKey: "https://example.com/bird.png"}},
+	}
+
+	e, err := embeddings.NewMultimodalEmbedder(imageClient{})
+	require.NoError(t, err)
+
+	vectors, err := EmbedDocuments(context.Background(), e, docs)
+	require.NoError(t, err)
+	assert.Equal(t, [][]float32{{0, 3}, {1, 0}, {0, 5}, {0, 0}}, vectors)
+
+	textOnly, err := embeddings.NewEmbedder(embeddings.EmbedderClientFunc(
+		func(_ context.Context, texts []string) ([][]float32, error) {
+			return make([][]float32, len(texts)), nil
+		}))
+	require.NoError(t, err)
+
+	_, err = EmbedDocuments(context.Background(), textOnly, docs)
+	require.ErrorIs(t, err, ErrImagesNotSupported)
+
+	vectors, err = EmbedDocuments(context.Background(), textOnly, docs[1:2])
+	require.NoError(t, err)
+	assert.Len(t, vectors, 1)
+}
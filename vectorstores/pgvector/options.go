@@ -79,7 +79,9 @@ func WithCollectionMetadata(metadata map[string]any) Option {
 	}
 }
 
-// WithVectorDimensions is an option for specifying the vector size.
+// WithVectorDimensions is an option for specifying the vector size. If not
+// set, the size reported by the embedder is used when it implements
+// embeddings.Dimensioner. Vectors of a different size are rejected.
 func WithVectorDimensions(size int) Option {
 	return func(p *Store) {
 		p.vectorDimensions = size
@@ -122,5 +124,15 @@ func applyClientOptions(opts ...Option) (Store, error) {
 		return Store{}, fmt.Errorf("%w: missing embedder", ErrInvalidOptions)
 	}
 
+	if d, ok := o.embedder.(embeddings.Dimensioner); ok && d.EmbeddingDimensions() > 0 {
+		dimensions := d.EmbeddingDimensions()
+		if o.vectorDimensions == 0 {
+			o.vectorDimensions = dimensions
+		} else if o.vectorDimensions != dimensions {
+			return Store{}, fmt.Errorf("%w: embedder creates vectors of size %d, store expects %d",
+				ErrDimensionsMismatch, dimensions, o.vectorDimensions)
+		}
+	}
+
 	return *o, nil
 }
@@ -34,6 +34,7 @@ var (
 	ErrInvalidScoreThreshold      = errors.New("score threshold must be between 0 and 1")
 	ErrInvalidFilters             = errors.New("invalid filters")
 	ErrUnsupportedOptions         = errors.New("unsupported options")
+	ErrDimensionsMismatch         = errors.New("vector dimensions do not match the store dimensions")
 )
 
 // PGXConn represents both a pgx.Conn and pgxpool.Pool conn.
@@ -237,16 +238,11 @@ func (s Store) AddDocuments(
 
 	docs = s.deduplicate(ctx, opts, docs)
 
-	texts := make([]string, 0, len(docs))
-	for _, doc := range docs {
-		texts = append(texts, doc.PageContent)
-	}
-
 	embedder := s.embedder
 	if opts.Embedder != nil {
 		embedder = opts.Embedder
 	}
-	vectors, err := embedder.EmbedDocuments(ctx, texts)
+	vectors, err := vectorstores.EmbedDocuments(ctx, embedder, docs)
 	if err != nil {
 		return nil, err
 	}
@@ -255,6 +251,12 @@ func (s Store) AddDocuments(
 		return nil, ErrEmbedderWrongNumberVectors
 	}
 
+	for _, vector := range vectors {
+		if err := s.checkDimensions(len(vector)); err != nil {
+			return nil, err
+		}
+	}
+
 	b := &pgx.Batch{}
 	sql := fmt.Sprintf(`INSERT INTO %s (uuid, document, embedding, cmetadata, collection_id)
 		VALUES($1, $2, $3, $4, $5)`, s.embeddingTableName)
@@ -268,6 +270,15 @@ func (s Store) AddDocuments(
 	return ids, s.conn.SendBatch(ctx, b).Close()
 }
 
+// checkDimensions returns an error if the store was configured with a vector
+// size and the given size differs.
+func (s Store) checkDimensions(dimensions int) error {
+	if s.vectorDimensions > 0 && dimensions != s.vectorDimensions {
+		return fmt.Errorf("%w: got %d, want %d", ErrDimensionsMismatch, dimensions, s.vectorDimensions)
+	}
+	return nil
+}
+
 //nolint:cyclop
 func (s Store) SimilaritySearch(
 	ctx context.Context,
@@ -293,6 +304,9 @@ func (s Store) SimilaritySearch(
 	if err != nil {
 		return nil, err
 	}
+	if err := s.checkDimensions(len(embedderData)); err != nil {
+		return nil, err
+	}
 	whereQuerys := make([]string, 0)
 	if scoreThreshold != 0 {
 		whereQuerys = append(whereQuerys, fmt.Sprintf("data.distance < %f", 1-scoreThreshold))
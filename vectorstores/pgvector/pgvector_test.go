@@ -740,3 +740,23 @@ func TestWithAllOptions(t *testing.T) {
 	require.Equal(t, "tokyo", docs[0].PageContent)
 	require.Equal(t, "japan", docs[0].Metadata["country"])
 }
+
+func TestDimensionsMismatch(t *testing.T) {
+	t.Parallel()
+
+	e, err := embeddings.NewEmbedder(
+		embeddings.EmbedderClientFunc(func(_ context.Context, texts []string) ([][]float32, error) {
+			return make([][]float32, len(texts)), nil
+		}),
+		embeddings.WithDimensions(256),
+	)
+	require.NoError(t, err)
+
+	_, err = pgvector.New(
+		context.Background(),
+		pgvector.WithConnectionURL("postgres://localhost:1/unused"),
+		pgvector.WithEmbedder(e),
+		pgvector.WithVectorDimensions(1536),
+	)
+	require.ErrorIs(t, err, pgvector.ErrDimensionsMismatch)
+}
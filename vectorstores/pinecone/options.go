@@ -34,6 +34,15 @@ func WithEmbedder(e embeddings.Embedder) Option {
 	}
 }
 
+// WithSparseEmbedder is an option for setting the sparse embedder used to
+// store and query sparse values alongside the dense vectors. The index must
+// use the dotproduct metric to support sparse-dense vectors.
+func WithSparseEmbedder(e embeddings.SparseEmbedder) Option {
+	return func(p *Store) {
+		p.sparseEmbedder = e
+	}
+}
+
 // WithAPIKey is an option for setting the api key. If the option is not set
 // the api key is read from the PINECONE_API_KEY environment variable. If the
 // variable is not present, an error will be returned.
@@ -30,8 +30,9 @@ var (
 
 // Store is a wrapper around the pinecone rest API and grpc client.
 type Store struct {
-	embedder embeddings.Embedder
-	client   *pinecone.Client
+	embedder       embeddings.Embedder
+	sparseEmbedder embeddings.SparseEmbedder
+	client         *pinecone.Client
 
 	host      string
 	apiKey    string
@@ -70,52 +71,11 @@ func (s Store) AddDocuments(ctx context.Context,
 	}
 	defer indexConn.Close()
 
-	texts := make([]string, 0, len(docs))
-	for _, doc := range docs {
-		texts = append(texts, doc.PageContent)
-	}
-
-	vectors, err := s.embedder.EmbedDocuments(ctx, texts)
+	pineconeVectors, ids, err := s.vectors(ctx, docs)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(vectors) != len(docs) {
-		return nil, ErrEmbedderWrongNumberVectors
-	}
-
-	metadatas := make([]map[string]any, 0, len(docs))
-	for i := 0; i < len(docs); i++ {
-		metadata := make(map[string]any, len(docs[i].Metadata))
-		for key, value := range docs[i].Metadata {
-			metadata[key] = value
-		}
-		metadata[s.textKey] = texts[i]
-
-		metadatas = append(metadatas, metadata)
-	}
-
-	pineconeVectors := make([]*pinecone.Vector, 0, len(vectors))
-
-	ids := make([]string, len(vectors))
-	for i := 0; i < len(vectors); i++ {
-		metadataStruct, err := structpb.NewStruct(metadatas[i])
-		if err != nil {
-			return nil, err
-		}
-
-		id := uuid.New().String()
-		ids[i] = id
-		pineconeVectors = append(
-			pineconeVectors,
-			&pinecone.Vector{
-				Id:       id,
-				Values:   vectors[i],
-				Metadata: metadataStruct,
-			},
-		)
-	}
-
 	_, err = indexConn.UpsertVectors(&ctx, pineconeVectors)
 	if err != nil {
 		return nil, err
@@ -150,21 +110,12 @@ func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 		return nil, err
 	}
 
-	vector, err := s.embedder.EmbedQuery(ctx, query)
+	request, err := s.queryRequest(ctx, query, numDocuments, protoFilterStruct)
 	if err != nil {
 		return nil, err
 	}
 
-	queryResult, err := indexConn.QueryByVectorValues(
-		&ctx,
-		&pinecone.QueryByVectorValuesRequest{
-			Vector:          vector,
-			TopK:            uint32(numDocuments),
-			Filter:          protoFilterStruct,
-			IncludeMetadata: true,
-			IncludeValues:   true,
-		},
-	)
+	queryResult, err := indexConn.QueryByVectorValues(&ctx, request)
 	if err != nil {
 		return nil, err
 	}
@@ -176,6 +127,102 @@ func (s Store) SimilaritySearch(ctx context.Context, query string, numDocuments
 	return s.getDocumentsFromMatches(queryResult, scoreThreshold)
 }
 
+// vectors returns the vectors of the documents to upsert, with their sparse
+// values if the store has a sparse embedder, and their ids.
+func (s Store) vectors(ctx context.Context, docs []schema.Document) ([]*pinecone.Vector, []string, error) {
+	texts := make([]string, 0, len(docs))
+	for _, doc := range docs {
+		texts = append(texts, doc.PageContent)
+	}
+
+	vectors, err := vectorstores.EmbedDocuments(ctx, s.embedder, docs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(vectors) != len(docs) {
+		return nil, nil, ErrEmbedderWrongNumberVectors
+	}
+
+	var sparseVectors []embeddings.SparseVector
+	if s.sparseEmbedder != nil {
+		sparseVectors, err = s.sparseEmbedder.EmbedDocumentsSparse(ctx, texts)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(sparseVectors) != len(docs) {
+			return nil, nil, ErrEmbedderWrongNumberVectors
+		}
+	}
+
+	metadatas := make([]map[string]any, 0, len(docs))
+	for i := 0; i < len(docs); i++ {
+		metadata := make(map[string]any, len(docs[i].Metadata))
+		for key, value := range docs[i].Metadata {
+			metadata[key] = value
+		}
+		metadata[s.textKey] = texts[i]
+
+		metadatas = append(metadatas, metadata)
+	}
+
+	pineconeVectors := make([]*pinecone.Vector, 0, len(vectors))
+
+	ids := make([]string, len(vectors))
+	for i := 0; i < len(vectors); i++ {
+		metadataStruct, err := structpb.NewStruct(metadatas[i])
+		if err != nil {
+			return nil, nil, err
+		}
+
+		id := uuid.New().String()
+		ids[i] = id
+		vector := &pinecone.Vector{
+			Id:       id,
+			Values:   vectors[i],
+			Metadata: metadataStruct,
+		}
+		if sparseVectors != nil {
+			vector.SparseValues = toSparseValues(sparseVectors[i])
+		}
+		pineconeVectors = append(pineconeVectors, vector)
+	}
+
+	return pineconeVectors, ids, nil
+}
+
+// queryRequest returns the request querying the vectors similar to the query,
+// with its sparse values if the store has a sparse embedder.
+func (s Store) queryRequest(
+	ctx context.Context,
+	query string,
+	numDocuments int,
+	filter *structpb.Struct,
+) (*pinecone.QueryByVectorValuesRequest, error) {
+	vector, err := s.embedder.EmbedQuery(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &pinecone.QueryByVectorValuesRequest{
+		Vector:          vector,
+		TopK:            uint32(numDocuments),
+		Filter:          filter,
+		IncludeMetadata: true,
+		IncludeValues:   true,
+	}
+
+	if s.sparseEmbedder != nil {
+		sparseVector, err := s.sparseEmbedder.EmbedQuerySparse(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		request.SparseValues = toSparseValues(sparseVector)
+	}
+
+	return request, nil
+}
+
 func (s Store) getDocumentsFromMatches(queryResult *pinecone.QueryVectorsResponse, scoreThreshold float32) ([]schema.Document, error) {
 	resultDocuments := make([]schema.Document, 0)
 	for _, match := range queryResult.Matches {
@@ -231,6 +278,13 @@ func (s Store) getOptions(options ...vectorstores.Option) vectorstores.Options {
 	return opts
 }
 
+func toSparseValues(v embeddings.SparseVector) *pinecone.SparseValues {
+	return &pinecone.SparseValues{
+		Indices: v.Indices,
+		Values:  v.Values,
+	}
+}
+
 func (s Store) createProtoStructFilter(filter any) (*structpb.Struct, error) {
 	filterBytes, err := json.Marshal(filter)
 	if err != nil {
@@ -0,0 +1,58 @@
+package pinecone
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/embeddings/bm25"
+	"github.com/tmc/langchaingo/schema"
+)
+
+func TestSparseValues(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	e, err := embeddings.NewEmbedder(embeddings.EmbedderClientFunc(
+		func(_ context.Context, texts []string) ([][]float32, error) {
+			vectors := make([][]float32, len(texts))
+			for i := range texts {
+				vectors[i] = []float32{1, 0}
+			}
+			return vectors, nil
+		}))
+	require.NoError(t, err)
+	sparse := bm25.New()
+	sparse.Fit([]string{"tokyo", "potato"})
+
+	s := Store{embedder: e, sparseEmbedder: sparse, textKey: "text"}
+	vectors, ids, err := s.vectors(ctx, []schema.Document{{PageContent: "tokyo"}, {PageContent: "potato"}})
+	require.NoError(t, err)
+	require.Len(t, ids, 2)
+	require.Len(t, vectors, 2)
+	for i, text := range []string{"tokyo", "potato"} {
+		require.Equal(t, ids[i], vectors[i].Id)
+		require.Equal(t, []float32{1, 0}, vectors[i].Values)
+		require.Equal(t, text, vectors[i].Metadata.AsMap()["text"])
+		require.NotNil(t, vectors[i].SparseValues)
+		require.Equal(t, []uint32{bm25.TermIndex(text)}, vectors[i].SparseValues.Indices)
+		require.Len(t, vectors[i].SparseValues.Values, 1)
+	}
+
+	request, err := s.queryRequest(ctx, "tokyo", 3, nil)
+	require.NoError(t, err)
+	require.Equal(t, uint32(3), request.TopK)
+	require.Equal(t, []float32{1, 0}, request.Vector)
+	require.NotNil(t, request.SparseValues)
+	require.Equal(t, []uint32{bm25.TermIndex("tokyo")}, request.SparseValues.Indices)
+
+	// Without a sparse embedder, only the dense values are sent.
+	s.sparseEmbedder = nil
+	vectors, _, err = s.vectors(ctx, []schema.Document{{PageContent: "tokyo"}})
+	require.NoError(t, err)
+	require.Nil(t, vectors[0].SparseValues)
+	request, err = s.queryRequest(ctx, "tokyo", 3, nil)
+	require.NoError(t, err)
+	require.Nil(t, request.SparseValues)
+}
@@ -9,7 +9,8 @@ import (
 )
 
 const (
-	defaultContentKey = "content"
+	defaultContentKey       = "content"
+	defaultSparseVectorName = "sparse"
 )
 
 // ErrInvalidOptions is returned when the options given are invalid.
@@ -56,9 +57,40 @@ func WithContentKey(contentKey string) Option {
 	}
 }
 
+// WithSparseEmbedder returns an Option for setting the sparse embedder used to
+// store a sparse vector alongside the dense vector of each document. When set,
+// SimilaritySearch runs a hybrid query fusing the dense and sparse results
+// with reciprocal rank fusion. The score threshold of a hybrid search applies
+// to the fused scores, which are reciprocal ranks, not similarities. The
+// collection must be configured with a sparse vector named after
+// WithSparseVectorName. Optional.
+func WithSparseEmbedder(embedder embeddings.SparseEmbedder) Option {
+	return func(p *Store) {
+		p.sparseEmbedder = embedder
+	}
+}
+
+// WithVectorName returns an Option for setting the name of the dense vector
+// in collections using named vectors. Optional. Defaults to the unnamed
+// vector.
+func WithVectorName(name string) Option {
+	return func(p *Store) {
+		p.vectorName = name
+	}
+}
+
+// WithSparseVectorName returns an Option for setting the name of the sparse
+// vector in the collection. Optional. Defaults to "sparse".
+func WithSparseVectorName(name string) Option {
+	return func(p *Store) {
+		p.sparseVectorName = name
+	}
+}
+
 func applyClientOptions(opts ...Option) (Store, error) {
 	o := &Store{
-		contentKey: defaultContentKey,
+		contentKey:       defaultContentKey,
+		sparseVectorName: defaultSparseVectorName,
 	}
 
 	for _, opt := range opts {
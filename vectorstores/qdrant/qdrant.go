@@ -11,11 +11,14 @@ import (
 )
 
 type Store struct {
-	embedder       embeddings.Embedder
-	collectionName string
-	qdrantURL      url.URL
-	apiKey         string
-	contentKey     string
+	embedder         embeddings.Embedder
+	sparseEmbedder   embeddings.SparseEmbedder
+	collectionName   string
+	qdrantURL        url.URL
+	apiKey           string
+	contentKey       string
+	vectorName       string
+	sparseVectorName string
 }
 
 var _ vectorstores.VectorStore = Store{}
@@ -38,7 +41,7 @@ func (s Store) AddDocuments(ctx context.Context,
 	}
 
 	vectors,
-		err := s.embedder.EmbedDocuments(ctx, texts)
+		err := vectorstores.EmbedDocuments(ctx, s.embedder, docs)
 	if err != nil {
 		return nil, err
 	}
@@ -47,6 +50,17 @@ func (s Store) AddDocuments(ctx context.Context,
 		return nil, errors.New("number of vectors from embedder does not match number of documents")
 	}
 
+	var sparseVectors []embeddings.SparseVector
+	if s.sparseEmbedder != nil {
+		sparseVectors, err = s.sparseEmbedder.EmbedDocumentsSparse(ctx, texts)
+		if err != nil {
+			return nil, err
+		}
+		if len(sparseVectors) != len(docs) {
+			return nil, errors.New("number of vectors from sparse embedder does not match number of documents")
+		}
+	}
+
 	metadatas := make([]map[string]interface{}, 0, len(docs))
 	for i := 0; i < len(docs); i++ {
 		metadata := make(map[string]interface{}, len(docs[i].Metadata))
@@ -58,7 +72,7 @@ func (s Store) AddDocuments(ctx context.Context,
 		metadatas = append(metadatas, metadata)
 	}
 
-	return s.upsertPoints(ctx, &s.qdrantURL, vectors, metadatas)
+	return s.upsertPoints(ctx, &s.qdrantURL, vectors, sparseVectors, metadatas)
 }
 
 func (s Store) SimilaritySearch(ctx context.Context,
@@ -81,7 +95,17 @@ func (s Store) SimilaritySearch(ctx context.Context,
 		return nil, err
 	}
 
-	return s.searchPoints(ctx, &s.qdrantURL, vector, numDocuments, scoreThreshold, filters)
+	if s.sparseEmbedder == nil {
+		return s.searchPoints(ctx, &s.qdrantURL, vector, numDocuments, scoreThreshold, filters)
+	}
+
+	sparseVector,
+		err := s.sparseEmbedder.EmbedQuerySparse(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.queryPoints(ctx, &s.qdrantURL, vector, sparseVector, numDocuments, scoreThreshold, filters)
 }
 
 func (s Store) getScoreThreshold(opts vectorstores.Options) (float32, error) {
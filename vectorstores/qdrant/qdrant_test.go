@@ -2,7 +2,10 @@ package qdrant_test
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"net/url"
 	"os"
 	"strconv"
@@ -15,6 +18,7 @@ import (
 	tcqdrant "github.com/testcontainers/testcontainers-go/modules/qdrant"
 	"github.com/tmc/langchaingo/chains"
 	"github.com/tmc/langchaingo/embeddings"
+	"github.com/tmc/langchaingo/embeddings/bm25"
 	"github.com/tmc/langchaingo/llms/openai"
 	"github.com/tmc/langchaingo/schema"
 	"github.com/tmc/langchaingo/vectorstores"
@@ -423,3 +427,71 @@ func setupCollection(t *testing.T, qdrantURL, apiKey string, dimension int, dist
 	})
 	return collectionName
 }
+
+func TestQdrantStoreHybridSearch(t *testing.T) {
+	t.Parallel()
+
+	var upsert, query map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/points"):
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&upsert))
+			fmt.Fprint(w, `{"result":{"status":"completed"}}`)
+		case strings.HasSuffix(r.URL.Path, "/points/query"):
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&query))
+			fmt.Fprint(w, `{"result":{"points":[{"score":0.5,"payload":{"content":"tokyo"}}]}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	e, err := embeddings.NewEmbedder(embeddings.EmbedderClientFunc(
+		func(_ context.Context, texts []string) ([][]float32, error) {
+			vectors := make([][]float32, len(texts))
+			for i := range texts {
+				vectors[i] = []float32{1, 0}
+			}
+			return vectors, nil
+		}))
+	require.NoError(t, err)
+
+	sparse := bm25.New()
+	sparse.Fit([]string{"tokyo", "potato"})
+
+	url, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	store, err := qdrant.New(
+		qdrant.WithURL(*url),
+		qdrant.WithCollectionName("hybrid"),
+		qdrant.WithEmbedder(e),
+		qdrant.WithSparseEmbedder(sparse),
+	)
+	require.NoError(t, err)
+
+	_, err = store.AddDocuments(context.Background(), []schema.Document{
+		{PageContent: "tokyo"},
+		{PageContent: "potato"},
+	})
+	require.NoError(t, err)
+
+	vectors, ok := upsert["batch"].(map[string]any)["vectors"].(map[string]any)
+	require.True(t, ok)
+	require.Len(t, vectors[""], 2)
+	require.Len(t, vectors["sparse"], 2)
+
+	docs, err := store.SimilaritySearch(context.Background(), "tokyo", 1, vectorstores.WithScoreThreshold(0.25))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "tokyo", docs[0].PageContent)
+
+	prefetch, ok := query["prefetch"].([]any)
+	require.True(t, ok)
+	require.Len(t, prefetch, 2)
+	require.Equal(t, "sparse", prefetch[1].(map[string]any)["using"])
+	require.Equal(t, map[string]any{"fusion": "rrf"}, query["query"])
+	require.InDelta(t, 0.25, query["score_threshold"], 1e-6)
+	for _, p := range prefetch {
+		require.NotContains(t, p, "score_threshold")
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"net/url"
 
 	"github.com/google/uuid"
+	"github.com/tmc/langchaingo/embeddings"
 	"github.com/tmc/langchaingo/schema"
 )
 
@@ -18,6 +19,7 @@ func (s Store) upsertPoints(
 	ctx context.Context,
 	baseURL *url.URL,
 	vectors [][]float32,
+	sparseVectors []embeddings.SparseVector,
 	payloads []map[string]interface{},
 ) ([]string, error) {
 	ids := make([]string, len(vectors))
@@ -28,7 +30,7 @@ func (s Store) upsertPoints(
 	payload := upsertBody{
 		Batch: upsertBatch{
 			IDs:      ids,
-			Vectors:  vectors,
+			Vectors:  s.batchVectors(vectors, sparseVectors),
 			Payloads: payloads,
 		},
 	}
@@ -72,6 +74,10 @@ func (s Store) searchPoints(
 		Filter:      filter,
 	}
 
+	if s.vectorName != "" {
+		payload.Vector = namedVector{Name: s.vectorName, Vector: vector}
+	}
+
 	if scoreThreshold != 0 {
 		payload.ScoreThreshold = scoreThreshold
 	}
@@ -101,8 +107,92 @@ func (s Store) searchPoints(
 	if err != nil {
 		return nil, err
 	}
-	docs := make([]schema.Document, len(response.Result))
-	for i, match := range response.Result {
+
+	return s.resultsToDocuments(response.Result)
+}
+
+// queryPoints runs a hybrid query on the Qdrant collection, fusing the results
+// of a dense and a sparse vector search with reciprocal rank fusion. The score
+// threshold applies to the fused scores.
+func (s Store) queryPoints(
+	ctx context.Context,
+	baseURL *url.URL,
+	vector []float32,
+	sparseVector embeddings.SparseVector,
+	numVectors int,
+	scoreThreshold float32,
+	filter any,
+) ([]schema.Document, error) {
+	payload := queryBody{
+		Prefetch: []prefetchBody{
+			{
+				Query:  vector,
+				Using:  s.vectorName,
+				Filter: filter,
+				Limit:  numVectors,
+			},
+			{
+				Query:  sparseVector,
+				Using:  s.sparseVectorName,
+				Filter: filter,
+				Limit:  numVectors,
+			},
+		},
+		Query:          fusionQuery{Fusion: "rrf"},
+		Filter:         filter,
+		Limit:          numVectors,
+		ScoreThreshold: scoreThreshold,
+		WithPayload:    true,
+		WithVector:     false,
+	}
+
+	url := baseURL.JoinPath("collections", s.collectionName, "points", "query")
+	body,
+		statusCode,
+		err := DoRequest(
+		ctx, *url,
+		s.apiKey,
+		http.MethodPost,
+		payload,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	if statusCode != http.StatusOK {
+		return nil, newAPIError("querying collection", body)
+	}
+
+	var response queryResponse
+
+	decoder := json.NewDecoder(body)
+	err = decoder.Decode(&response)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.resultsToDocuments(response.Result.Points)
+}
+
+// batchVectors returns the vectors of an upsert batch. When sparse vectors
+// are given, named vectors are used.
+func (s Store) batchVectors(vectors [][]float32, sparseVectors []embeddings.SparseVector) any {
+	if sparseVectors == nil && s.vectorName == "" {
+		return vectors
+	}
+
+	named := map[string]any{s.vectorName: vectors}
+	if sparseVectors != nil {
+		named[s.sparseVectorName] = sparseVectors
+	}
+	return named
+}
+
+// resultsToDocuments converts scored points to documents.
+func (s Store) resultsToDocuments(results []result) ([]schema.Document, error) {
+	docs := make([]schema.Document, len(results))
+	for i, match := range results {
 		pageContent, ok := match.Payload[s.contentKey].(string)
 		if !ok {
 			return nil, fmt.Errorf("payload does not contain content key '%s'", s.contentKey)
@@ -8,7 +8,9 @@ package qdrant
 type upsertBatch struct {
 	IDs      []string                 `json:"ids"`
 	Payloads []map[string]interface{} `json:"payloads"`
-	Vectors  [][]float32              `json:"vectors"`
+	// Vectors is either a list of dense vectors, or a map of vector names
+	// to lists of dense or sparse vectors when using named vectors.
+	Vectors any `json:"vectors"`
 }
 
 type upsertBody struct {
@@ -25,10 +27,43 @@ type searchResponse struct {
 }
 
 type searchBody struct {
-	Vector         []float32 `json:"vector"`
-	Filter         any       `json:"filter"`
-	Limit          int       `json:"limit"`
-	ScoreThreshold float32   `json:"score_threshold"`
-	WithVector     bool      `json:"with_vector"`
-	WithPayload    bool      `json:"with_payload"`
+	// Vector is either a dense vector or a namedVector.
+	Vector         any     `json:"vector"`
+	Filter         any     `json:"filter"`
+	Limit          int     `json:"limit"`
+	ScoreThreshold float32 `json:"score_threshold"`
+	WithVector     bool    `json:"with_vector"`
+	WithPayload    bool    `json:"with_payload"`
+}
+
+type namedVector struct {
+	Name   string    `json:"name"`
+	Vector []float32 `json:"vector"`
+}
+
+type prefetchBody struct {
+	Query  any    `json:"query"`
+	Using  string `json:"using,omitempty"`
+	Filter any    `json:"filter,omitempty"`
+	Limit  int    `json:"limit"`
+}
+
+type fusionQuery struct {
+	Fusion string `json:"fusion"`
+}
+
+type queryBody struct {
+	Prefetch       []prefetchBody `json:"prefetch"`
+	Query          fusionQuery    `json:"query"`
+	Filter         any            `json:"filter,omitempty"`
+	Limit          int            `json:"limit"`
+	ScoreThreshold float32        `json:"score_threshold,omitempty"`
+	WithVector     bool           `json:"with_vector"`
+	WithPayload    bool           `json:"with_payload"`
+}
+
+type queryResponse struct {
+	Result struct {
+		Points []result `json:"points"`
+	} `json:"result"`
 }
@@ -105,6 +105,18 @@ func WithQueryAttrs(queryAttrs []string) Option {
 	}
 }
 
+// WithHybridSearch is an option for running hybrid searches, fusing the
+// results of a vector search and of a keyword (BM25) search of the text key,
+// both run by weaviate. Alpha weighs the two searches: 1 is a pure vector
+// search and 0 a pure keyword search. The score threshold of a hybrid search
+// applies to the fused scores, between 0 and 1.
+func WithHybridSearch(alpha float32) Option {
+	return func(p *Store) {
+		p.hybridSearch = true
+		p.alpha = alpha
+	}
+}
+
 // WithAdditionalFields is an option for setting additional fields query attributes of the weaviate server.
 func WithAdditionalFields(additionalFields []string) Option {
 	return func(p *Store) {
@@ -139,6 +151,10 @@ func applyClientOptions(opts ...Option) (Store, error) {
 		return Store{}, fmt.Errorf("%w: missing embedder", ErrInvalidOptions)
 	}
 
+	if o.hybridSearch && (o.alpha < 0 || o.alpha > 1) {
+		return Store{}, fmt.Errorf("%w: alpha must be between 0 and 1", ErrInvalidOptions)
+	}
+
 	// add default Attributes
 	if o.queryAttrs == nil {
 		o.queryAttrs = []string{o.textKey, o.nameSpaceKey}
@@ -152,6 +168,10 @@ func applyClientOptions(opts ...Option) (Store, error) {
 
 	// add additional fields
 	defaultAdditionalFields := []string{"certainty"}
+	if o.hybridSearch {
+		// Hybrid searches have a fused score instead of a certainty.
+		defaultAdditionalFields = []string{"score"}
+	}
 
 	if o.additionalFields == nil {
 		o.additionalFields = defaultAdditionalFields
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/go-openapi/strfmt"
@@ -59,6 +60,10 @@ type Store struct {
 	// optional
 	queryAttrs       []string
 	additionalFields []string
+
+	// optional
+	hybridSearch bool
+	alpha        float32
 }
 
 var _ vectorstores.VectorStore = Store{}
@@ -171,21 +176,47 @@ func (s Store) SimilaritySearch(
 		return nil, err
 	}
 
-	res, err := s.client.GraphQL().
+	get := s.client.GraphQL().
 		Get().
-		WithNearVector(s.client.GraphQL().
-			NearVectorArgBuilder().
-			WithVector(vector).
-			WithCertainty(scoreThreshold),
-		).
 		WithWhere(whereBuilder).
 		WithClassName(s.indexName).
 		WithLimit(numDocuments).
-		WithFields(s.createFields()...).Do(ctx)
+		WithFields(s.createFields()...)
+	if s.hybridSearch {
+		get = get.WithHybrid(s.client.GraphQL().
+			HybridArgumentBuilder().
+			WithQuery(query).
+			WithVector(vector).
+			WithAlpha(s.alpha).
+			WithProperties([]string{s.textKey}),
+		)
+	} else {
+		get = get.WithNearVector(s.client.GraphQL().
+			NearVectorArgBuilder().
+			WithVector(vector).
+			WithCertainty(scoreThreshold),
+		)
+	}
+	res, err := get.Do(ctx)
 	if err != nil {
 		return nil, err
 	}
-	return s.parseDocumentsByGraphQLResponse(res)
+	docs, err := s.parseDocumentsByGraphQLResponse(res)
+	if err != nil || !s.hybridSearch || scoreThreshold == 0 {
+		return docs, err
+	}
+
+	// The threshold of a hybrid search applies to the fused scores.
+	filtered := make([]schema.Document, 0, len(docs))
+	for _, doc := range docs {
+		if doc.Score >= scoreThreshold {
+			filtered = append(filtered, doc)
+		}
+	}
+	if len(filtered) == 0 {
+		return nil, ErrEmptyResponse
+	}
+	return filtered, nil
 }
 
 // MetadataSearch searches weaviate based on metadata rather than based on similarity.
@@ -248,6 +279,10 @@ func (s Store) parseDocumentsByGraphQLResponse(res *models.GraphQLResponse) ([]s
 		var score float64
 		if additional, ok := itemMap["_additional"].(map[string]any); ok {
 			score, _ = additional["certainty"].(float64)
+			// The fused score of a hybrid search is a string.
+			if fused, ok := additional["score"].(string); ok {
+				score, _ = strconv.ParseFloat(fused, 64)
+			}
 		}
 		delete(itemMap, s.textKey)
 		doc := schema.Document{
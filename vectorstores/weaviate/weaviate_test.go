@@ -2,7 +2,10 @@ package weaviate
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"strings"
 	"testing"
@@ -783,3 +786,59 @@ func TestWeaviateWithOptionEmbedder(t *testing.T) {
 	require.Equal(t, "tokyo", docs[0].PageContent)
 	require.Equal(t, "japan", docs[0].Metadata["country"])
 }
+
+func TestWeaviateHybridSearch(t *testing.T) {
+	t.Parallel()
+
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/graphql" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var body struct {
+			Query string `json:"query"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		query = body.Query
+		fmt.Fprint(w, `{"data":{"Get":{"Hybrid":[
+			{"text":"tokyo","nameSpace":"default","_additional":{"score":"0.9"}},
+			{"text":"potato","nameSpace":"default","_additional":{"score":"0.2"}}
+		]}}}`)
+	}))
+	t.Cleanup(server.Close)
+
+	e, err := embeddings.NewEmbedder(embeddings.EmbedderClientFunc(
+		func(_ context.Context, texts []string) ([][]float32, error) {
+			vectors := make([][]float32, len(texts))
+			for i := range texts {
+				vectors[i] = []float32{1, 0}
+			}
+			return vectors, nil
+		}))
+	require.NoError(t, err)
+
+	_, err = New(WithScheme("http"), WithHost("localhost"), WithIndexName("Hybrid"),
+		WithEmbedder(e), WithHybridSearch(2))
+	require.ErrorIs(t, err, ErrInvalidOptions)
+
+	store, err := New(
+		WithScheme("http"),
+		WithHost(strings.TrimPrefix(server.URL, "http://")),
+		WithIndexName("Hybrid"),
+		WithEmbedder(e),
+		WithHybridSearch(0.5),
+	)
+	require.NoError(t, err)
+
+	docs, err := store.SimilaritySearch(context.Background(), "tokyo", 2, vectorstores.WithScoreThreshold(0.5))
+	require.NoError(t, err)
+	require.Len(t, docs, 1)
+	require.Equal(t, "tokyo", docs[0].PageContent)
+	require.InDelta(t, 0.9, docs[0].Score, 1e-6)
+
+	require.Contains(t, query, `hybrid:{query: "tokyo"`)
+	require.Contains(t, query, "alpha: 0.5")
+	require.Contains(t, query, "score")
+	require.NotContains(t, query, "nearVector")
+}